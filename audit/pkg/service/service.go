@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/internal/audit"
+	"github.com/euvsvirus-banan/backend/internal/tracing"
+	"github.com/euvsvirus-banan/backend/internal/version"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Service struct {
+	logger *logrus.Entry
+	log    *audit.Log
+}
+
+func New(logger *logrus.Entry, log *audit.Log) *Service {
+	return &Service{
+		logger: logger,
+		log:    log,
+	}
+}
+
+func (svc *Service) GetVersion(ctx context.Context, req *auditpb.GetVersionRequest) (*auditpb.GetVersionResponse, error) {
+	return &auditpb.GetVersionResponse{
+		Project:     version.Project,
+		Version:     version.Version,
+		BuildDate:   version.BuildDate,
+		GitRevision: version.GitRevision,
+		GoVersion:   version.GoVersion,
+	}, nil
+}
+
+func (svc *Service) QueryAuditLog(req *auditpb.QueryAuditLogRequest, stream auditpb.AuditRPC_QueryAuditLogServer) error {
+	filter := audit.Filter{
+		EntityType: req.EntityType,
+		EntityID:   req.EntityId,
+		Actor:      req.Actor,
+		Limit:      int(req.Limit),
+	}
+	var err error
+	if filter.Since, err = parseDate(req.Since); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid since date: %s", err)
+	}
+	if filter.Until, err = parseDate(req.Until); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid until date: %s", err)
+	}
+
+	var entries []*auditpb.Entry
+	err = tracing.Trace(stream.Context(), "AuditLog.Query", func() error {
+		var err error
+		entries, err = svc.log.Query(filter)
+		return err
+	})
+	if errors.Is(err, audit.ErrBroken) {
+		svc.logger.WithError(err).Error("audit log is tampered with")
+		return status.Error(codes.DataLoss, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	for _, e := range entries {
+		if err := stream.Send(&auditpb.QueryAuditLogResponse{Entry: e}); err != nil {
+			return status.Error(codes.Unknown, err.Error())
+		}
+	}
+	return nil
+}
+
+// parseDate parses an RFC 3339 date, the zero time if empty.
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
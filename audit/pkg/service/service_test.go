@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/internal/audit"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type entriesStream struct {
+	auditpb.AuditRPC_QueryAuditLogServer
+	responses []*auditpb.QueryAuditLogResponse
+}
+
+func (s *entriesStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *entriesStream) Send(resp *auditpb.QueryAuditLogResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func TestQueryAuditLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.jsonl")
+	log, err := audit.Open(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close()
+	for _, e := range []*auditpb.Entry{
+		{Date: "2026-10-01T10:00:00Z", Actor: "ops", EntityType: audit.Request, EntityId: "r1", Method: "/requestspb.RequestsRPC/AddRequest"},
+		{Date: "2026-10-02T10:00:00Z", Actor: "support", EntityType: audit.Request, EntityId: "r1", Method: "/requestspb.RequestsRPC/DeleteRequest"},
+	} {
+		if err := log.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc := New(logrus.NewEntry(logrus.New()), log)
+
+	stream := &entriesStream{}
+	err = svc.QueryAuditLog(&auditpb.QueryAuditLogRequest{EntityId: "r1", Since: "2026-10-02T00:00:00Z"}, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.responses) != 1 || stream.responses[0].Entry.Actor != "support" {
+		t.Errorf("expected the deletion by support, got %v", stream.responses)
+	}
+
+	err = svc.QueryAuditLog(&auditpb.QueryAuditLogRequest{Until: "yesterday"}, &entriesStream{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	// removing the deletion from the log breaks the chain
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, b[:bytes.IndexByte(b, '\n')+1], 0640); err != nil {
+		t.Fatal(err)
+	}
+	err = svc.QueryAuditLog(&auditpb.QueryAuditLogRequest{}, &entriesStream{})
+	if status.Code(err) != codes.DataLoss {
+		t.Errorf("expected DataLoss, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	"github.com/euvsvirus-banan/backend/backups/rpc/backupspb"
+	"github.com/euvsvirus-banan/backend/internal/backup"
+	"github.com/euvsvirus-banan/backend/internal/tracing"
+	"github.com/euvsvirus-banan/backend/internal/version"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errDisabled is returned when the service has no backup directory.
+var errDisabled = status.Error(codes.FailedPrecondition, "backups are disabled, the service has no backup directory")
+
+type Service struct {
+	logger  *logrus.Entry
+	backups *backup.Backups
+}
+
+// New returns the service, backups is nil when the service has no backup directory.
+func New(logger *logrus.Entry, backups *backup.Backups) *Service {
+	return &Service{
+		logger:  logger,
+		backups: backups,
+	}
+}
+
+func (svc *Service) GetVersion(ctx context.Context, req *backupspb.GetVersionRequest) (*backupspb.GetVersionResponse, error) {
+	return &backupspb.GetVersionResponse{
+		Project:     version.Project,
+		Version:     version.Version,
+		BuildDate:   version.BuildDate,
+		GitRevision: version.GitRevision,
+		GoVersion:   version.GoVersion,
+	}, nil
+}
+
+func (svc *Service) CreateBackup(ctx context.Context, req *backupspb.CreateBackupRequest) (*backupspb.CreateBackupResponse, error) {
+	if svc.backups == nil {
+		return nil, errDisabled
+	}
+	var b *backup.Backup
+	err := tracing.Trace(ctx, "Backups.Create", func() error {
+		var err error
+		b, err = svc.backups.Create()
+		return err
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	svc.logger.WithField("backup", b.Name).Info("created backup")
+	return &backupspb.CreateBackupResponse{
+		Backup: toProto(b),
+	}, nil
+}
+
+func (svc *Service) GetBackups(req *backupspb.GetBackupsRequest, stream backupspb.BackupsRPC_GetBackupsServer) error {
+	if svc.backups == nil {
+		return errDisabled
+	}
+	backups, err := svc.backups.List()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	for _, b := range backups {
+		err := stream.Send(&backupspb.GetBackupsResponse{
+			Backup: toProto(b),
+		})
+		if err != nil {
+			return status.Error(codes.Unknown, err.Error())
+		}
+	}
+	return nil
+}
+
+func toProto(b *backup.Backup) *backupspb.Backup {
+	return &backupspb.Backup{
+		Name:          b.Name,
+		SizeBytes:     b.Size,
+		CreationDate:  b.Metadata.CreationDate,
+		SchemaVersion: uint32(b.Metadata.SchemaVersion),
+		Version:       b.Metadata.Version,
+		Users:         uint32(b.Metadata.Files[backup.UsersFile].Entries),
+		Requests:      uint32(b.Metadata.Files[backup.RequestsFile].Entries),
+		News:          uint32(b.Metadata.Files[backup.NewsFile].Entries),
+	}
+}
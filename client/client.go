@@ -0,0 +1,158 @@
+// Package client calls the users, requests and news services of the backend. Calls without a
+// deadline get the default one, the idempotent calls are retried with backoff while the service
+// is unavailable or rate limiting, and the errors of the service are returned as *Error, matched
+// by errors.Is with the errors of this package.
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"math/rand"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Config struct {
+	// Token is sent as a bearer token with every call when set
+	Token string
+	// TLS is used to connect to the service, the connection is insecure when it's nil
+	TLS *tls.Config
+	// Timeout is the deadline of the calls made with a context without one, the iterators
+	// included, there's no deadline when it's 0
+	Timeout time.Duration
+	// MaxAttempts is the number of times an idempotent call is tried, 1 disables the retries
+	MaxAttempts int
+	// Backoff is the time waited after the first failed attempt, doubled after each failure up
+	// to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// DialOptions are added to the options of Dial
+	DialOptions []grpc.DialOption
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:     10 * time.Second,
+		MaxAttempts: 4,
+		Backoff:     100 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+	}
+}
+
+// Client calls the services over a single connection, it's safe for concurrent use.
+type Client struct {
+	conn     *grpc.ClientConn
+	ownConn  bool
+	cfg      Config
+	users    userspb.UsersRPCClient
+	requests requestspb.RequestsRPCClient
+	news     newspb.NewsRPCClient
+}
+
+// Dial connects to the service at addr, the connection is established in the background and
+// closed by Close.
+func Dial(ctx context.Context, addr string, cfg Config) (*Client, error) {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if cfg.TLS != nil {
+		opts[0] = grpc.WithTransportCredentials(credentials.NewTLS(cfg.TLS))
+	}
+	conn, err := grpc.DialContext(ctx, addr, append(opts, cfg.DialOptions...)...)
+	if err != nil {
+		return nil, err
+	}
+	c := New(conn, cfg)
+	c.ownConn = true
+	return c, nil
+}
+
+// New returns a client using an existing connection, which isn't closed by Close. The TLS and
+// DialOptions of the config are ignored.
+func New(conn *grpc.ClientConn, cfg Config) *Client {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	return &Client{
+		conn:     conn,
+		cfg:      cfg,
+		users:    userspb.NewUsersRPCClient(conn),
+		requests: requestspb.NewRequestsRPCClient(conn),
+		news:     newspb.NewNewsRPCClient(conn),
+	}
+}
+
+// Conn returns the connection of the client, for the services it doesn't wrap.
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Close closes the connection opened by Dial.
+func (c *Client) Close() error {
+	if !c.ownConn {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// context adds the token and the default deadline to the context of a call.
+func (c *Client) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.cfg.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.cfg.Token)
+	}
+	if _, ok := ctx.Deadline(); !ok && c.cfg.Timeout > 0 {
+		return context.WithTimeout(ctx, c.cfg.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// call makes a unary call, which is tried again after a retryable failure when it's idempotent.
+func (c *Client) call(ctx context.Context, idempotent bool, f func(ctx context.Context) error) error {
+	ctx, cancel := c.context(ctx)
+	defer cancel()
+	for attempt := 1; ; attempt++ {
+		err := f(ctx)
+		if err == nil || !idempotent || !c.backoff(ctx, attempt, err) {
+			return wrapError(err)
+		}
+	}
+}
+
+// backoff waits before the next attempt after err, it returns false when there's no next attempt.
+func (c *Client) backoff(ctx context.Context, attempt int, err error) bool {
+	if attempt >= c.cfg.MaxAttempts || !retryable(err) {
+		return false
+	}
+	d := c.cfg.Backoff << uint(attempt-1)
+	if d > c.cfg.MaxBackoff || d < c.cfg.Backoff {
+		d = c.cfg.MaxBackoff
+	}
+	// the jitter spreads the attempts of the clients that failed together
+	if d > 1 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)))
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// retryable reports whether the call failed before being handled by the service.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeUsers fails the first calls of each method as unavailable.
+type fakeUsers struct {
+	userspb.UnimplementedUsersRPCServer
+	failures int
+	calls    map[string]int
+	token    string
+}
+
+func (f *fakeUsers) fail(ctx context.Context, method string) error {
+	f.calls[method]++
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md["authorization"]) > 0 {
+		f.token = md["authorization"][0]
+	}
+	if f.calls[method] <= f.failures {
+		return status.Error(codes.Unavailable, "starting")
+	}
+	return nil
+}
+
+func (f *fakeUsers) AddUser(ctx context.Context, req *userspb.AddUserRequest) (*userspb.AddUserResponse, error) {
+	if err := f.fail(ctx, "AddUser"); err != nil {
+		return nil, err
+	}
+	return &userspb.AddUserResponse{UserId: "u1"}, nil
+}
+
+func (f *fakeUsers) GetUserByID(ctx context.Context, req *userspb.GetUserByIDRequest) (*userspb.GetUserByIDResponse, error) {
+	if err := f.fail(ctx, "GetUserByID"); err != nil {
+		return nil, err
+	}
+	if req.UserId != "u1" {
+		s, _ := status.New(codes.NotFound, "user not found").WithDetails(&errdetails.RequestInfo{RequestId: "r1"})
+		return nil, s.Err()
+	}
+	return &userspb.GetUserByIDResponse{User: &userspb.User{Name: "Ada"}}, nil
+}
+
+func (f *fakeUsers) GetUsers(req *userspb.GetUsersRequest, stream userspb.UsersRPC_GetUsersServer) error {
+	if err := f.fail(stream.Context(), "GetUsers"); err != nil {
+		return err
+	}
+	for _, id := range []string{"u1", "u2"} {
+		if err := stream.Send(&userspb.GetUsersResponse{UserId: id, User: &userspb.User{Name: id}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeUsers) GetUserReputation(ctx context.Context, req *userspb.GetUserReputationRequest) (*userspb.GetUserReputationResponse, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		return nil, status.Error(codes.InvalidArgument, "no default deadline")
+	}
+	return &userspb.GetUserReputationResponse{Reputation: &userspb.User_Reputation{Total: 1}}, nil
+}
+
+func newTestClient(t *testing.T, failures int) (*Client, *fakeUsers) {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	users := &fakeUsers{failures: failures, calls: make(map[string]int)}
+	userspb.RegisterUsersRPCServer(srv, users)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	cfg := DefaultConfig()
+	cfg.Token = "secret"
+	cfg.Backoff = time.Millisecond
+	cfg.MaxBackoff = 5 * time.Millisecond
+	cfg.DialOptions = []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.Dial()
+	})}
+	c, err := Dial(context.Background(), "bufnet", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c, users
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+	c, users := newTestClient(t, 2)
+
+	user, err := c.GetUser(ctx, "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Name != "Ada" || users.calls["GetUserByID"] != 3 {
+		t.Errorf("expected Ada after 3 calls, got %v after %d", user, users.calls["GetUserByID"])
+	}
+	if users.token != "Bearer secret" {
+		t.Errorf("expected the token to be sent, got %q", users.token)
+	}
+
+	// adding isn't idempotent
+	if _, err := c.AddUser(ctx, &userspb.User{Name: "Ada"}); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+	if users.calls["AddUser"] != 1 {
+		t.Errorf("expected AddUser to be called once, got %d", users.calls["AddUser"])
+	}
+
+	c, users = newTestClient(t, 4)
+	if _, err := c.GetUser(ctx, "u1"); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected the last failure, got %v", err)
+	}
+	if users.calls["GetUserByID"] != 4 {
+		t.Errorf("expected %d attempts, got %d", 4, users.calls["GetUserByID"])
+	}
+}
+
+func TestErrors(t *testing.T) {
+	c, _ := newTestClient(t, 0)
+
+	_, err := c.GetUser(context.Background(), "u2")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	var e *Error
+	if !errors.As(err, &e) || e.RequestID != "r1" {
+		t.Errorf("expected the request id r1, got %v", err)
+	}
+	if err.Error() != "NotFound: user not found (request r1)" {
+		t.Errorf("unexpected message %q", err)
+	}
+
+	if _, err := c.UserReputation(context.Background(), "u1"); err != nil {
+		t.Error(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetUser(ctx, "u1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	c, users := newTestClient(t, 1)
+
+	var ids []string
+	it := c.Users(context.Background())
+	defer it.Close()
+	for it.Next() {
+		if it.User().Name != it.ID() {
+			t.Errorf("expected the user of %s, got %v", it.ID(), it.User())
+		}
+		ids = append(ids, it.ID())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(ids)
+	if diff := cmp.Diff([]string{"u1", "u2"}, ids); diff != "" {
+		t.Errorf("users differ (-want +got):\n%s", diff)
+	}
+	if users.calls["GetUsers"] != 2 {
+		t.Errorf("expected the stream to be opened again, got %d calls", users.calls["GetUsers"])
+	}
+	if it.Next() {
+		t.Error("expected the iteration to stay done")
+	}
+}
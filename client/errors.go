@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors matched by the *Error of their codes.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrUnavailable        = errors.New("unavailable")
+)
+
+var codeErrors = map[codes.Code]error{
+	codes.NotFound:           ErrNotFound,
+	codes.AlreadyExists:      ErrAlreadyExists,
+	codes.InvalidArgument:    ErrInvalidArgument,
+	codes.OutOfRange:         ErrInvalidArgument,
+	codes.FailedPrecondition: ErrFailedPrecondition,
+	codes.PermissionDenied:   ErrPermissionDenied,
+	codes.Unauthenticated:    ErrUnauthenticated,
+	codes.ResourceExhausted:  ErrRateLimited,
+	codes.Unavailable:        ErrUnavailable,
+	codes.DeadlineExceeded:   context.DeadlineExceeded,
+	codes.Canceled:           context.Canceled,
+}
+
+// Error is a failed call. The request id is the one of the traces and logs of the call.
+type Error struct {
+	Code      codes.Code
+	Message   string
+	RequestID string
+
+	status *status.Status
+}
+
+func (e *Error) Error() string {
+	if e.RequestID == "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s: %s (request %s)", e.Code, e.Message, e.RequestID)
+}
+
+// Unwrap returns the error of the code, nil for the codes without one.
+func (e *Error) Unwrap() error {
+	return codeErrors[e.Code]
+}
+
+// GRPCStatus returns the status of the call, for status.FromError and status.Code.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.status
+}
+
+func wrapError(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	e := &Error{Code: s.Code(), Message: s.Message(), status: s}
+	for _, d := range s.Details() {
+		if info, ok := d.(*errdetails.RequestInfo); ok {
+			e.RequestID = info.RequestId
+		}
+	}
+	return e
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+// receiver returns the next id and entry of a stream.
+type receiver func() (string, interface{}, error)
+
+// stream receives the entries of a server stream. The stream is opened again after a retryable
+// failure until the first entry is received, the entries would be repeated after that.
+type stream struct {
+	c      *Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	open   func(ctx context.Context) (receiver, error)
+
+	recv     receiver
+	received bool
+	done     bool
+	id       string
+	entry    interface{}
+	err      error
+}
+
+func newStream(ctx context.Context, c *Client, open func(ctx context.Context) (receiver, error)) stream {
+	ctx, cancel := c.context(ctx)
+	return stream{c: c, ctx: ctx, cancel: cancel, open: open}
+}
+
+func (s *stream) next() bool {
+	if s.done {
+		return false
+	}
+	for attempt := 1; ; attempt++ {
+		var err error
+		if s.recv == nil {
+			s.recv, err = s.open(s.ctx)
+		}
+		if err == nil {
+			s.id, s.entry, err = s.recv()
+		}
+		if err == nil {
+			s.received = true
+			return true
+		}
+		if err == io.EOF || s.received || !s.c.backoff(s.ctx, attempt, err) {
+			s.finish(err)
+			return false
+		}
+		s.recv = nil
+	}
+}
+
+func (s *stream) finish(err error) {
+	s.done = true
+	s.id, s.entry = "", nil
+	if err != io.EOF {
+		s.err = wrapError(err)
+	}
+	s.cancel()
+}
+
+// UserIterator iterates over users:
+//
+//	it := c.Users(ctx)
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.ID(), it.User().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type UserIterator struct {
+	s stream
+}
+
+// Next receives the next user, it returns false at the end of the users or after a failure.
+func (it *UserIterator) Next() bool { return it.s.next() }
+
+func (it *UserIterator) ID() string { return it.s.id }
+
+func (it *UserIterator) User() *userspb.User {
+	user, _ := it.s.entry.(*userspb.User)
+	return user
+}
+
+// Err returns the failure that ended the iteration.
+func (it *UserIterator) Err() error { return it.s.err }
+
+// Close stops the iteration, it's only needed when Next didn't return false.
+func (it *UserIterator) Close() {
+	if !it.s.done {
+		it.s.finish(io.EOF)
+	}
+}
+
+// RequestIterator iterates over requests like UserIterator.
+type RequestIterator struct {
+	s stream
+}
+
+func (it *RequestIterator) Next() bool { return it.s.next() }
+
+func (it *RequestIterator) ID() string { return it.s.id }
+
+func (it *RequestIterator) Request() *requestspb.Request {
+	request, _ := it.s.entry.(*requestspb.Request)
+	return request
+}
+
+func (it *RequestIterator) Err() error { return it.s.err }
+
+func (it *RequestIterator) Close() {
+	if !it.s.done {
+		it.s.finish(io.EOF)
+	}
+}
+
+// NewsIterator iterates over news like UserIterator.
+type NewsIterator struct {
+	s stream
+}
+
+func (it *NewsIterator) Next() bool { return it.s.next() }
+
+func (it *NewsIterator) ID() string { return it.s.id }
+
+func (it *NewsIterator) News() *newspb.News {
+	news, _ := it.s.entry.(*newspb.News)
+	return news
+}
+
+func (it *NewsIterator) Err() error { return it.s.err }
+
+func (it *NewsIterator) Close() {
+	if !it.s.done {
+		it.s.finish(io.EOF)
+	}
+}
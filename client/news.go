@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+)
+
+// AddNews adds the news and returns its id.
+func (c *Client) AddNews(ctx context.Context, news *newspb.News) (string, error) {
+	var id string
+	err := c.call(ctx, false, func(ctx context.Context) error {
+		resp, err := c.news.AddNew(ctx, &newspb.AddNewRequest{New: news})
+		if err == nil {
+			id = resp.NewId
+		}
+		return err
+	})
+	return id, err
+}
+
+func (c *Client) GetNews(ctx context.Context, id string) (*newspb.News, error) {
+	var news *newspb.News
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.news.GetNewsByID(ctx, &newspb.GetNewsByIDRequest{NewsId: id})
+		if err == nil {
+			news = resp.News
+		}
+		return err
+	})
+	return news, err
+}
+
+// UpdateNews replaces the news, it's retried as replacing twice changes nothing.
+func (c *Client) UpdateNews(ctx context.Context, id string, news *newspb.News) (*newspb.News, error) {
+	var updated *newspb.News
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.news.UpdateNew(ctx, &newspb.UpdateNewRequest{NewId: id, New: news})
+		if err == nil {
+			updated = resp.New
+		}
+		return err
+	})
+	return updated, err
+}
+
+func (c *Client) DeleteNews(ctx context.Context, id string) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.news.DeleteNew(ctx, &newspb.DeleteNewRequest{NewId: id})
+		return err
+	})
+}
+
+// News iterates over all the news.
+func (c *Client) News(ctx context.Context) *NewsIterator {
+	return &NewsIterator{s: newStream(ctx, c, func(ctx context.Context) (receiver, error) {
+		stream, err := c.news.GetNews(ctx, &newspb.GetNewsRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return func() (string, interface{}, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return "", nil, err
+			}
+			return resp.NewId, resp.New, nil
+		}, nil
+	})}
+}
+
+// SearchNews iterates over the news of the postcode.
+func (c *Client) SearchNews(ctx context.Context, postcode string) *NewsIterator {
+	return &NewsIterator{s: newStream(ctx, c, func(ctx context.Context) (receiver, error) {
+		stream, err := c.news.SearchNewsByPostcode(ctx, &newspb.SearchNewsByPostcodeRequest{Postcode: postcode})
+		if err != nil {
+			return nil, err
+		}
+		return func() (string, interface{}, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return "", nil, err
+			}
+			return resp.NewsId, resp.News, nil
+		}, nil
+	})}
+}
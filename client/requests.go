@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+)
+
+// AddRequest adds the request and returns its id.
+func (c *Client) AddRequest(ctx context.Context, request *requestspb.Request) (string, error) {
+	var id string
+	err := c.call(ctx, false, func(ctx context.Context) error {
+		resp, err := c.requests.AddRequest(ctx, &requestspb.AddRequestRequest{Request: request})
+		if err == nil {
+			id = resp.RequestId
+		}
+		return err
+	})
+	return id, err
+}
+
+func (c *Client) GetRequest(ctx context.Context, id string) (*requestspb.Request, error) {
+	var request *requestspb.Request
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.requests.GetRequestByID(ctx, &requestspb.GetRequestByIDRequest{RequestId: id})
+		if err == nil {
+			request = resp.Request
+		}
+		return err
+	})
+	return request, err
+}
+
+// UpdateRequest replaces the request, it's retried as replacing twice changes nothing.
+func (c *Client) UpdateRequest(ctx context.Context, id string, request *requestspb.Request) (*requestspb.Request, error) {
+	var updated *requestspb.Request
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.requests.UpdateRequest(ctx, &requestspb.UpdateRequestRequest{RequestId: id, Request: request})
+		if err == nil {
+			updated = resp.Request
+		}
+		return err
+	})
+	return updated, err
+}
+
+func (c *Client) DeleteRequest(ctx context.Context, id string) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.requests.DeleteRequest(ctx, &requestspb.DeleteRequestRequest{RequestId: id})
+		return err
+	})
+}
+
+func (c *Client) AnswerRequest(ctx context.Context, id string, answer *requestspb.Request_Answer) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.requests.AnswerRequest(ctx, &requestspb.AnswerRequestRequest{RequestId: id, Answer: answer})
+		return err
+	})
+}
+
+func (c *Client) AcceptHelp(ctx context.Context, id, volunteerID string) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.requests.AcceptHelp(ctx, &requestspb.AcceptHelpRequest{RequestId: id, VolunteerId: volunteerID})
+		return err
+	})
+}
+
+func (c *Client) CompleteHelp(ctx context.Context, id string) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.requests.CompleteHelp(ctx, &requestspb.CompleteHelpRequest{RequestId: id})
+		return err
+	})
+}
+
+func (c *Client) CancelHelp(ctx context.Context, id string) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.requests.CancelHelp(ctx, &requestspb.CancelHelpRequest{RequestId: id})
+		return err
+	})
+}
+
+// RateHelp rates the other party of a completed request, the score goes from 1 to 5.
+func (c *Client) RateHelp(ctx context.Context, id, raterID string, score int32, comment string) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.requests.RateHelp(ctx, &requestspb.RateHelpRequest{
+			RequestId: id,
+			RaterId:   raterID,
+			Score:     score,
+			Comment:   comment,
+		})
+		return err
+	})
+}
+
+// Requests iterates over all the requests.
+func (c *Client) Requests(ctx context.Context) *RequestIterator {
+	return &RequestIterator{s: newStream(ctx, c, func(ctx context.Context) (receiver, error) {
+		stream, err := c.requests.GetRequests(ctx, &requestspb.GetRequestsRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return func() (string, interface{}, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return "", nil, err
+			}
+			return resp.RequestId, resp.Request, nil
+		}, nil
+	})}
+}
+
+// SearchRequests iterates over the requests of the postcode.
+func (c *Client) SearchRequests(ctx context.Context, postcode string) *RequestIterator {
+	return &RequestIterator{s: newStream(ctx, c, func(ctx context.Context) (receiver, error) {
+		stream, err := c.requests.SearchRequestsByPostcode(ctx, &requestspb.SearchRequestsByPostcodeRequest{Postcode: postcode})
+		if err != nil {
+			return nil, err
+		}
+		return func() (string, interface{}, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return "", nil, err
+			}
+			return resp.RequestId, resp.Request, nil
+		}, nil
+	})}
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+// AddUser adds the user and returns its id.
+func (c *Client) AddUser(ctx context.Context, user *userspb.User) (string, error) {
+	var id string
+	err := c.call(ctx, false, func(ctx context.Context) error {
+		resp, err := c.users.AddUser(ctx, &userspb.AddUserRequest{User: user})
+		if err == nil {
+			id = resp.UserId
+		}
+		return err
+	})
+	return id, err
+}
+
+func (c *Client) GetUser(ctx context.Context, id string) (*userspb.User, error) {
+	var user *userspb.User
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.users.GetUserByID(ctx, &userspb.GetUserByIDRequest{UserId: id})
+		if err == nil {
+			user = resp.User
+		}
+		return err
+	})
+	return user, err
+}
+
+// UpdateUser replaces the user, it's retried as replacing twice changes nothing.
+func (c *Client) UpdateUser(ctx context.Context, id string, user *userspb.User) (*userspb.User, error) {
+	var updated *userspb.User
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.users.UpdateUser(ctx, &userspb.UpdateUserRequest{UserId: id, User: user})
+		if err == nil {
+			updated = resp.User
+		}
+		return err
+	})
+	return updated, err
+}
+
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.users.DeleteUser(ctx, &userspb.DeleteUserRequest{UserId: id})
+		return err
+	})
+}
+
+func (c *Client) UserReputation(ctx context.Context, id string) (*userspb.User_Reputation, error) {
+	var reputation *userspb.User_Reputation
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.users.GetUserReputation(ctx, &userspb.GetUserReputationRequest{UserId: id})
+		if err == nil {
+			reputation = resp.Reputation
+		}
+		return err
+	})
+	return reputation, err
+}
+
+// ExportMyData returns the JSON document holding everything stored about the user and the name
+// suggested for it.
+func (c *Client) ExportMyData(ctx context.Context, id string) ([]byte, string, error) {
+	var bundle []byte
+	var name string
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.users.ExportMyData(ctx, &userspb.ExportMyDataRequest{UserId: id})
+		if err == nil {
+			bundle, name = []byte(resp.Bundle), resp.FileName
+		}
+		return err
+	})
+	return bundle, name, err
+}
+
+// EraseUser deletes the user and pseudonymizes the requests they took part in, it isn't retried as
+// a second erasure fails with ErrNotFound.
+func (c *Client) EraseUser(ctx context.Context, id string) (*userspb.EraseUserResponse, error) {
+	var erasure *userspb.EraseUserResponse
+	err := c.call(ctx, false, func(ctx context.Context) error {
+		resp, err := c.users.EraseUser(ctx, &userspb.EraseUserRequest{UserId: id})
+		if err == nil {
+			erasure = resp
+		}
+		return err
+	})
+	return erasure, err
+}
+
+// Consent returns the consents in effect of the user and whether they have to accept the current
+// privacy policy.
+func (c *Client) Consent(ctx context.Context, id string) (*userspb.GetConsentResponse, error) {
+	var consent *userspb.GetConsentResponse
+	err := c.call(ctx, true, func(ctx context.Context) error {
+		resp, err := c.users.GetConsent(ctx, &userspb.GetConsentRequest{UserId: id})
+		if err == nil {
+			consent = resp
+		}
+		return err
+	})
+	return consent, err
+}
+
+// GrantConsent records the consent of the user to the purposes and returns the consents in effect,
+// the current privacy policy is accepted when version is empty. It isn't retried, each call is
+// recorded in the history of the consents.
+func (c *Client) GrantConsent(ctx context.Context, id string, purposes []userspb.User_Consent_Purpose, version, source string) ([]*userspb.User_Consent, error) {
+	var consents []*userspb.User_Consent
+	err := c.call(ctx, false, func(ctx context.Context) error {
+		resp, err := c.users.GrantConsent(ctx, &userspb.GrantConsentRequest{
+			UserId:        id,
+			Purposes:      purposes,
+			PolicyVersion: version,
+			Source:        source,
+		})
+		if err == nil {
+			consents = resp.Consents
+		}
+		return err
+	})
+	return consents, err
+}
+
+// RevokeConsent records that the user withdrew their consent to the purposes and returns the
+// consents in effect. It isn't retried either.
+func (c *Client) RevokeConsent(ctx context.Context, id string, purposes []userspb.User_Consent_Purpose, source string) ([]*userspb.User_Consent, error) {
+	var consents []*userspb.User_Consent
+	err := c.call(ctx, false, func(ctx context.Context) error {
+		resp, err := c.users.RevokeConsent(ctx, &userspb.RevokeConsentRequest{
+			UserId:   id,
+			Purposes: purposes,
+			Source:   source,
+		})
+		if err == nil {
+			consents = resp.Consents
+		}
+		return err
+	})
+	return consents, err
+}
+
+// Users iterates over all the users.
+func (c *Client) Users(ctx context.Context) *UserIterator {
+	return &UserIterator{s: newStream(ctx, c, func(ctx context.Context) (receiver, error) {
+		stream, err := c.users.GetUsers(ctx, &userspb.GetUsersRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return func() (string, interface{}, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return "", nil, err
+			}
+			return resp.UserId, resp.User, nil
+		}, nil
+	})}
+}
+
+// SearchUsers iterates over the users of the postcode.
+func (c *Client) SearchUsers(ctx context.Context, postcode string) *UserIterator {
+	return &UserIterator{s: newStream(ctx, c, func(ctx context.Context) (receiver, error) {
+		stream, err := c.users.SearchUsersByPostcode(ctx, &userspb.SearchUsersByPostcodeRequest{Postcode: postcode})
+		if err != nil {
+			return nil, err
+		}
+		return func() (string, interface{}, error) {
+			resp, err := stream.Recv()
+			if err != nil {
+				return "", nil, err
+			}
+			return resp.UserId, resp.User, nil
+		}, nil
+	})}
+}
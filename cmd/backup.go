@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/internal/audit"
+	"github.com/euvsvirus-banan/backend/internal/backup"
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// backupDirectory returns the directory of the backups, the backups directory of the data
+// directory unless given.
+func backupDirectory(dataDir, backupDir string) string {
+	if backupDir == "" && dataDir != "" {
+		return filepath.Join(dataDir, backupsDir)
+	}
+	return backupDir
+}
+
+func snapshot(d *adminData) *backup.Data {
+	users, requests, news := storage.Snapshot(d.users, d.requests, d.news)
+	return &backup.Data{Users: users, Requests: requests, News: news}
+}
+
+// eraseAgain erases the users from the data like EraseUser: they're removed and their requests
+// pseudonymized. It returns the number of users the data held.
+func eraseAgain(data *backup.Data, userIDs []string) int {
+	erased := 0
+	for _, id := range userIDs {
+		_, found := data.Users[id]
+		delete(data.Users, id)
+		pseudonym := storage.Pseudonym()
+		for _, r := range data.Requests {
+			if storage.References(r, id) {
+				storage.Pseudonymize(r, id, pseudonym)
+				found = true
+			}
+		}
+		if found {
+			erased++
+		}
+	}
+	return erased
+}
+
+func backupSummary(b *backup.Backup) string {
+	files := b.Metadata.Files
+	return fmt.Sprintf("%s (%d users, %d requests and %d news)",
+		b.Path, files[backup.UsersFile].Entries, files[backup.RequestsFile].Entries, files[backup.NewsFile].Entries)
+}
+
+func runBackup(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	paths := addDataFlags(flags)
+	backupDir := flags.String("backup-dir", "", "Directory of the backup archives, the backups directory of --data-dir if empty")
+	keep := flags.Int("keep", 7, "Number of backups kept, the oldest are deleted, all are kept if 0")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: backend backup [flags], backend client backups create while the service runs")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	dir := backupDirectory(*paths.dir, *backupDir)
+	if dir == "" {
+		fmt.Println("--backup-dir or --data-dir is required")
+		return 2
+	}
+
+	d, err := openData(flags, paths)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer d.Close()
+
+	backups := backup.New(getLogger(logrus.WarnLevel), dir, *keep, d.codec, func() *backup.Data {
+		return snapshot(d)
+	})
+	b, err := backups.Create()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	fmt.Printf("%s created\n", backupSummary(b))
+	return 0
+}
+
+func runRestore(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	paths := addDataFlags(flags)
+	backupDir := flags.String("backup-dir", "", "Directory of the backup archives, the backups directory of --data-dir if empty")
+	at := flags.String("at", "", "Restore the newest backup of the backup directory created at or before this RFC 3339 time")
+	dryRun := flags.Bool("dry-run", false, "Validate the archive without replacing anything")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: backend restore [flags] archive, or backend restore --at time [flags]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	if flags.NArg() > 1 || (flags.NArg() == 1) == (*at != "") {
+		flags.Usage()
+		return 2
+	}
+	dir := backupDirectory(*paths.dir, *backupDir)
+	keys, err := encryptionKeys(*paths.keys, *paths.keysFile)
+	if err != nil {
+		fmt.Println(err)
+		return 2
+	}
+
+	path := flags.Arg(0)
+	if *at != "" {
+		if dir == "" {
+			fmt.Println("--at needs --backup-dir or --data-dir")
+			return 2
+		}
+		t, err := time.Parse(time.RFC3339, *at)
+		if err != nil {
+			fmt.Println(err)
+			return 2
+		}
+		b, err := backup.At(dir, t)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		path = b.Path
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	data, meta, err := backup.Read(f, dataCodec(keys))
+	f.Close()
+	if err != nil {
+		fmt.Printf("%s isn't a valid backup: %s\n", path, err)
+		return 1
+	}
+	if meta.SchemaVersion > datadir.SchemaVersion {
+		fmt.Printf("%s has schema version %d, this binary only knows up to %d\n", path, meta.SchemaVersion, datadir.SchemaVersion)
+		return 1
+	}
+	summary := fmt.Sprintf("%s of %s (%d users, %d requests and %d news)",
+		path, meta.CreationDate, len(data.Users), len(data.Requests), len(data.News))
+	if *dryRun {
+		fmt.Printf("%s would be restored\n", summary)
+		return 0
+	}
+	if *paths.dir == "" {
+		fmt.Println(errUnlocked)
+		return 2
+	}
+
+	// the data directory is locked, the service can't be running
+	d, err := openData(flags, paths)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer d.Close()
+	// the restore is recorded, the entries of the changes it undoes are kept
+	auditLog, err := audit.Open(*paths.auditLog, d.keys)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer auditLog.Close()
+	// the archive can hold users erased since, the erasures recorded in the log are applied again
+	erasedUsers, err := auditLog.ErasedUsers()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	if dir != "" {
+		// the data replaced can be restored too
+		current, err := backup.Save(dir, snapshot(d), time.Now(), d.codec)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		fmt.Printf("current data saved as %s\n", backupSummary(current))
+	}
+	// the archives of the older schema versions are read in the current one, the files are
+	// written like the stores write them
+	erased := eraseAgain(data, erasedUsers)
+	if err := backup.Restore(data, d.codec, *paths.users, *paths.requests, *paths.news); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	err = auditLog.Append(&auditpb.Entry{
+		Date:       time.Now().UTC().Format(time.RFC3339Nano),
+		Method:     "restore",
+		EntityType: "backup",
+		EntityId:   filepath.Base(path),
+		Code:       codes.OK.String(),
+	})
+	if err != nil {
+		fmt.Printf("%s restored but not recorded in the audit log: %s\n", summary, err)
+		return 1
+	}
+	fmt.Printf("%s restored\n", summary)
+	if erased > 0 {
+		fmt.Printf("%d users erased since the backup were erased again\n", erased)
+	}
+	// only the users, requests and news are archived
+	fmt.Println("the preferences, dead letters, webhooks and pending webhook events were kept, see the backups documentation")
+	return 0
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/internal/audit"
+	"github.com/euvsvirus-banan/backend/internal/backup"
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBackupRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dataDir := filepath.Join(dir, "data")
+	ctx := context.Background()
+
+	if code := dispatch(ctx, []string{"seed", "--data-dir", dataDir, filepath.Join("..", "data", "requests")}); code != 0 {
+		t.Fatalf("seed exited with %d", code)
+	}
+	seeded := readUsers(t, filepath.Join(dataDir, "users.json"))
+	if code := dispatch(ctx, []string{"backup", "--data-dir", dataDir}); code != 0 {
+		t.Fatalf("backup exited with %d", code)
+	}
+	backups, err := backup.List(filepath.Join(dataDir, backupsDir))
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("expected a backup, got %v, %v", backups, err)
+	}
+	// the backups are named after their creation time
+	time.Sleep(10 * time.Millisecond)
+	at := time.Now().UTC().Format(time.RFC3339Nano)
+
+	file := filepath.Join(dir, "import.jsonl")
+	if err := ioutil.WriteFile(file, []byte(`{"user":{"name":"Grace"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code := dispatch(ctx, []string{"import", "--data-dir", dataDir, file}); code != 0 {
+		t.Fatalf("import exited with %d", code)
+	}
+
+	// the archives are validated before anything is replaced
+	corrupted := filepath.Join(dir, "corrupted.tar.gz")
+	b, err := ioutil.ReadFile(backups[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(corrupted, b[:len(b)/2], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code := dispatch(ctx, []string{"restore", "--data-dir", dataDir, corrupted}); code != 1 {
+		t.Errorf("expected restoring a truncated archive to exit with 1, got %d", code)
+	}
+	if users := readUsers(t, filepath.Join(dataDir, "users.json")); len(users) != len(seeded)+1 {
+		t.Errorf("expected the users to be left as they were, got %d", len(users))
+	}
+
+	if code := dispatch(ctx, []string{"restore", "--data-dir", dataDir, "--at", at}); code != 0 {
+		t.Fatalf("restore exited with %d", code)
+	}
+	if diff := cmp.Diff(seeded, readUsers(t, filepath.Join(dataDir, "users.json"))); diff != "" {
+		t.Errorf("restored users differ (-want +got):\n%s", diff)
+	}
+	// the replaced data is backed up too
+	backups, err = backup.List(filepath.Join(dataDir, backupsDir))
+	if err != nil || len(backups) != 2 || backups[0].Metadata.Files[backup.UsersFile].Entries != len(seeded)+1 {
+		t.Errorf("expected a backup of the replaced data, got %v, %v", backups, err)
+	}
+
+	if code := dispatch(ctx, []string{"restore", "--data-dir", dataDir}); code != 2 {
+		t.Errorf("expected restoring without archive to exit with 2, got %d", code)
+	}
+}
+
+func TestRestoreErased(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dataDir := filepath.Join(dir, "data")
+	ctx := context.Background()
+
+	file := filepath.Join(dir, "import.jsonl")
+	records := `{"id":"grace","user":{"name":"Grace"}}
+{"id":"ada","user":{"name":"Ada"}}
+{"id":"r1","request":{"title":"groceries","requesterId":"grace","answers":[{"volunteerId":"ada","comment":"I can"}]}}
+{"id":"r2","request":{"title":"dog walk","requesterId":"ada","volunteerId":"grace","state":"ACCEPTED"}}
+`
+	if err := ioutil.WriteFile(file, []byte(records), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code := dispatch(ctx, []string{"import", "--data-dir", dataDir, file}); code != 0 {
+		t.Fatalf("import exited with %d", code)
+	}
+	requests := readRequests(t, filepath.Join(dataDir, "requests.json"))
+	userID := "grace"
+	if code := dispatch(ctx, []string{"backup", "--data-dir", dataDir}); code != 0 {
+		t.Fatalf("backup exited with %d", code)
+	}
+	backups, err := backup.List(filepath.Join(dataDir, backupsDir))
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("expected a backup, got %v, %v", backups, err)
+	}
+
+	// the user is erased after the backup
+	auditLog, err := audit.Open(filepath.Join(dataDir, "audit.jsonl"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = auditLog.Append(&auditpb.Entry{
+		Date:       time.Now().UTC().Format(time.RFC3339Nano),
+		Method:     "/userspb.UsersRPC/EraseUser",
+		EntityType: audit.User,
+		EntityId:   userID,
+		Code:       "OK",
+	})
+	auditLog.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := dispatch(ctx, []string{"restore", "--data-dir", dataDir, backups[0].Path}); code != 0 {
+		t.Fatalf("restore exited with %d", code)
+	}
+	users := readUsers(t, filepath.Join(dataDir, "users.json"))
+	if _, ok := users[userID]; ok || len(users) != 1 {
+		t.Errorf("expected only the erased user to be erased again, got %v", users)
+	}
+	restored := readRequests(t, filepath.Join(dataDir, "requests.json"))
+	if len(restored) != len(requests) {
+		t.Errorf("expected the %d requests to be restored, got %d", len(requests), len(restored))
+	}
+	for id, r := range restored {
+		if storage.References(r, userID) || r.Title != "" && r.RequesterId != "ada" {
+			t.Errorf("expected request %s to be pseudonymized, got %v", id, r)
+		}
+	}
+	if restored["r2"].Title != "dog walk" || len(restored["r1"].Answers) != 1 {
+		t.Errorf("expected only the data of the erased user to be erased, got %v", restored)
+	}
+}
+
+func readRequests(t *testing.T, path string) map[string]*requestspb.Request {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := make(map[string]*requestspb.Request)
+	if err := storage.Decode(b, &requests); err != nil {
+		t.Fatal(err)
+	}
+	return requests
+}
+
+// writeArchive writes a backup archive of the data files, with their number of entries.
+func writeArchive(t *testing.T, path string, schemaVersion int, files map[string]string, entries map[string]int) {
+	meta := backup.Metadata{
+		Format:        backup.Format,
+		SchemaVersion: schemaVersion,
+		CreationDate:  "2020-04-25T07:30:00Z",
+		Files:         make(map[string]backup.File),
+	}
+	for name, content := range files {
+		sum := sha256.Sum256([]byte(content))
+		meta.Files[name] = backup.File{Size: int64(len(content)), SHA256: hex.EncodeToString(sum[:]), Entries: entries[name]}
+	}
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{backup.MetadataFile, backup.UsersFile, backup.RequestsFile, backup.NewsFile} {
+		b := []byte(files[name])
+		if name == backup.MetadataFile {
+			b = metaBytes
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0640, Size: int64(len(b))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreOlderSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dataDir := filepath.Join(dir, "data")
+	ctx := context.Background()
+	key := "k1:" + strings.Repeat("A", 43) + "="
+
+	if code := dispatch(ctx, []string{"seed", "--data-dir", dataDir, "--encryption-keys", key, filepath.Join("..", "data", "requests")}); code != 0 {
+		t.Fatalf("seed exited with %d", code)
+	}
+	// the files of schema version 1 are the messages encoded with encoding/json, enums as numbers
+	archive := filepath.Join(dir, "backup-v1.tar.gz")
+	writeArchive(t, archive, 1, map[string]string{
+		backup.UsersFile:    `{"ada":{"name":"Ada","contact_details":[{"platform":1,"identifier":"ada@example.org"}]}}`,
+		backup.RequestsFile: `{}`,
+		backup.NewsFile:     `{}`,
+	}, map[string]int{backup.UsersFile: 1})
+	if code := dispatch(ctx, []string{"restore", "--data-dir", dataDir, "--encryption-keys", key, archive}); code != 0 {
+		t.Fatalf("restore exited with %d", code)
+	}
+
+	// the data directory is left in the current schema, encrypted
+	if code := dispatch(ctx, []string{"verify", "--data-dir", dataDir, "--encryption-keys", key}); code != 0 {
+		t.Errorf("verify exited with %d", code)
+	}
+	keys, err := crypt.ParseKeys(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dataDir, "users.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "ada@example.org") {
+		t.Error("expected the restored users to be encrypted")
+	}
+	users := make(map[string]*userspb.User)
+	if err := storage.Encrypted(keys).Decode(b, &users); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*userspb.User{"ada": {
+		Name:           "Ada",
+		ContactDetails: []*userspb.User_ContactDetails{{Platform: userspb.User_ContactDetails_EMAIL, Identifier: "ada@example.org"}},
+	}}
+	if diff := cmp.Diff(want, users); diff != "" {
+		t.Errorf("restored users differ (-want +got):\n%s", diff)
+	}
+
+	// the restore is recorded
+	auditLog, err := audit.Open(filepath.Join(dataDir, "audit.jsonl"), keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer auditLog.Close()
+	entries, err := auditLog.Query(audit.Filter{EntityType: "backup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Method != "restore" || entries[0].EntityId != "backup-v1.tar.gz" {
+		t.Errorf("expected the restore to be recorded, got %v", entries)
+	}
+}
+
+func TestBackupRPC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backupDir := filepath.Join(dir, "backups")
+	conn, exited := start(ctx, t, dir, "--backup-dir", backupDir, "--backup-keep", "2")
+	defer conn.Close()
+	addr := conn.Target()
+
+	call(t, addr, 0, "users", "add", "--name", "Ada")
+	for i := 0; i < 3; i++ {
+		out := call(t, addr, 0, "backups", "create")
+		if !strings.Contains(out, "backup-") {
+			t.Errorf("expected the created backup, got:\n%s", out)
+		}
+	}
+	out := call(t, addr, 0, "backups", "list")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "NAME") {
+		t.Fatalf("expected the 2 backups kept, got:\n%s", out)
+	}
+	if fields := strings.Fields(lines[1]); fields[4] != "1" {
+		t.Errorf("expected the backup of the user, got %v", fields)
+	}
+
+	cancel()
+	wait(t, exited, 10*time.Second)
+}
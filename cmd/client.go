@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/backups/rpc/backupspb"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenEnv is the environment variable with the token sent by the client when --token isn't given.
+const tokenEnv = "EUVSVIRUS_TOKEN"
+
+// errUsage is returned by the client actions called with invalid arguments.
+var errUsage = errors.New("invalid arguments")
+
+// clientRun calls the service, with the arguments left after the flags.
+type clientRun func(ctx context.Context, c *client, args []string) error
+
+// clientAction is a subcommand of the client, setup registers its flags.
+type clientAction struct {
+	args  []string
+	help  string
+	watch bool
+	setup func(flags *flag.FlagSet) clientRun
+}
+
+type client struct {
+	users         userspb.UsersRPCClient
+	requests      requestspb.RequestsRPCClient
+	news          newspb.NewsRPCClient
+	notifications notificationspb.NotificationsRPCClient
+	webhooks      webhookspb.WebhooksRPCClient
+	backups       backupspb.BackupsRPCClient
+	audit         auditpb.AuditRPCClient
+	out           *printer
+}
+
+// printer writes the responses as a table or as JSON, one object per line.
+type printer struct {
+	json      bool
+	flushRows bool
+	w         io.Writer
+	tw        *tabwriter.Writer
+	marshaler jsonpb.Marshaler
+	header    bool
+}
+
+func newPrinter(w io.Writer, asJSON, flushRows bool) *printer {
+	return &printer{
+		json:      asJSON,
+		flushRows: flushRows,
+		w:         w,
+		tw:        tabwriter.NewWriter(w, 0, 4, 2, ' ', 0),
+	}
+}
+
+// row prints the response, as the columns under the header in a table.
+func (p *printer) row(msg proto.Message, header []string, columns ...string) error {
+	if p.json {
+		return p.message(msg)
+	}
+	if !p.header {
+		fmt.Fprintln(p.tw, strings.Join(header, "\t"))
+		p.header = true
+	}
+	fmt.Fprintln(p.tw, strings.Join(columns, "\t"))
+	if p.flushRows {
+		return p.tw.Flush()
+	}
+	return nil
+}
+
+// message prints the response as JSON, nothing is printed in a table.
+func (p *printer) message(msg proto.Message) error {
+	if !p.json {
+		return nil
+	}
+	s, err := p.marshaler.MarshalToString(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(p.w, s)
+	return err
+}
+
+func (p *printer) flush() error {
+	return p.tw.Flush()
+}
+
+// stringList is a flag that can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+func listFlag(flags *flag.FlagSet, name, usage string) *stringList {
+	l := &stringList{}
+	flags.Var(l, name, usage)
+	return l
+}
+
+func clientUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: backend client [flags] resource action [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Actions:")
+	for _, resource := range sortedKeys(clientActions) {
+		actions := clientActions[resource]
+		names := make([]string, 0, len(actions))
+		for name := range actions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			a := actions[name]
+			synopsis := strings.TrimSpace(fmt.Sprintf("%s %s %s", resource, name, argsSynopsis(a.args)))
+			fmt.Fprintf(w, "  %-42s %s\n", synopsis, a.help)
+		}
+	}
+	fmt.Fprintf(w, "  %-42s %s\n", "completion bash|zsh", "Print the shell completion script")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+}
+
+func argsSynopsis(args []string) string {
+	s := make([]string, len(args))
+	for i, a := range args {
+		s[i] = "<" + a + ">"
+	}
+	return strings.Join(s, " ")
+}
+
+func sortedKeys(m map[string]map[string]clientAction) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type clientOptions struct {
+	addr          *string
+	token         *string
+	tokenFile     *string
+	actor         *string
+	output        *string
+	timeout       *time.Duration
+	tls           *bool
+	tlsCA         *string
+	tlsCert       *string
+	tlsKey        *string
+	tlsServerName *string
+}
+
+func addClientFlags(flags *flag.FlagSet) *clientOptions {
+	return &clientOptions{
+		addr:          flags.String("addr", "127.0.0.1:65010", "Address of the service"),
+		token:         flags.String("token", "", "Token sent in the authorization metadata, $"+tokenEnv+" if empty"),
+		tokenFile:     flags.String("token-file", "", "File with the token sent in the authorization metadata"),
+		actor:         flags.String("actor", "", "Name sent in the x-actor metadata, the actor of the changes in the audit log without a client certificate"),
+		output:        flags.String("output", "table", "Format of the responses: table or json, one object per line"),
+		timeout:       flags.Duration("timeout", 10*time.Second, "Time limit of the calls, the watches aren't limited"),
+		tls:           flags.Bool("tls", false, "Connect with TLS, verifying the certificate with the system authorities"),
+		tlsCA:         flags.String("tls-ca", "", "Authorities verifying the certificate of the service, enables TLS"),
+		tlsCert:       flags.String("tls-cert", "", "Client certificate for mutual TLS"),
+		tlsKey:        flags.String("tls-key", "", "Private key of the client certificate"),
+		tlsServerName: flags.String("tls-server-name", "", "Name expected in the certificate of the service, the host of --addr if empty"),
+	}
+}
+
+func runClient(ctx context.Context, args []string) int {
+	return clientMain(ctx, os.Stdout, os.Stderr, args)
+}
+
+// clientMain runs the client, the responses are written to stdout and the errors to stderr.
+func clientMain(ctx context.Context, stdout, stderr io.Writer, args []string) int { // nolint: funlen
+	flags := flag.NewFlagSet("client", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	opts := addClientFlags(flags)
+	flags.Usage = func() {
+		clientUsage(stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(stderr, err)
+		}
+		return 2
+	}
+	args = flags.Args()
+	if len(args) > 0 && args[0] == "completion" {
+		if len(args) != 2 || (args[1] != "bash" && args[1] != "zsh") {
+			fmt.Fprintln(stderr, "usage: backend client completion bash|zsh")
+			return 2
+		}
+		if err := writeCompletion(stdout, args[1]); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		return 0
+	}
+	if len(args) < 2 {
+		flags.Usage()
+		return 2
+	}
+	action, ok := clientActions[args[0]][args[1]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown action %q\n", strings.Join(args[:2], " "))
+		flags.Usage()
+		return 2
+	}
+	if *opts.output != "table" && *opts.output != "json" {
+		fmt.Fprintf(stderr, "unknown output %q, expected table or json\n", *opts.output)
+		return 2
+	}
+
+	actionFlags := flag.NewFlagSet("client "+args[0]+" "+args[1], flag.ContinueOnError)
+	actionFlags.SetOutput(stderr)
+	run := action.setup(actionFlags)
+	actionFlags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: backend client %s %s [flags] %s\n\n%s\n", args[0], args[1], argsSynopsis(action.args), action.help)
+		actionFlags.PrintDefaults()
+	}
+	if err := actionFlags.Parse(args[2:]); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(stderr, err)
+		}
+		return 2
+	}
+	if actionFlags.NArg() != len(action.args) {
+		actionFlags.Usage()
+		return 2
+	}
+
+	dialOpt := grpc.WithInsecure()
+	if *opts.tls || *opts.tlsCA != "" || *opts.tlsCert != "" {
+		cfg, err := clientTLSConfig(*opts.tlsCA, *opts.tlsCert, *opts.tlsKey, *opts.tlsServerName)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		dialOpt = grpc.WithTransportCredentials(credentials.NewTLS(cfg))
+	}
+	conn, err := grpc.DialContext(ctx, *opts.addr, dialOpt)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	defer conn.Close()
+
+	if *opts.token == "" {
+		*opts.token = os.Getenv(tokenEnv)
+	}
+	if *opts.tokenFile != "" {
+		b, err := ioutil.ReadFile(*opts.tokenFile)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		*opts.token = strings.TrimSpace(string(b))
+	}
+	if *opts.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*opts.token)
+	}
+	if *opts.actor != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-actor", *opts.actor)
+	}
+	if !action.watch {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *opts.timeout)
+		defer cancel()
+	}
+
+	c := &client{
+		users:         userspb.NewUsersRPCClient(conn),
+		requests:      requestspb.NewRequestsRPCClient(conn),
+		news:          newspb.NewNewsRPCClient(conn),
+		notifications: notificationspb.NewNotificationsRPCClient(conn),
+		webhooks:      webhookspb.NewWebhooksRPCClient(conn),
+		backups:       backupspb.NewBackupsRPCClient(conn),
+		audit:         auditpb.NewAuditRPCClient(conn),
+		out:           newPrinter(stdout, *opts.output == "json", action.watch),
+	}
+	err = run(ctx, c, actionFlags.Args())
+	if flushErr := c.out.flush(); err == nil {
+		err = flushErr
+	}
+	switch {
+	case action.watch && ctx.Err() != nil:
+		// interrupted
+		return 0
+	case err == errUsage:
+		actionFlags.Usage()
+		return 2
+	case err != nil:
+		if s, ok := status.FromError(err); ok {
+			fmt.Fprintf(stderr, "%s: %s\n", s.Code(), s.Message())
+		} else {
+			fmt.Fprintln(stderr, err)
+		}
+		return 1
+	}
+	return 0
+}
+
+func clientTLSConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	cfg := &tls.Config{ServerName: serverName}
+	if caFile != "" {
+		b, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("problem reading authorities: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificate found in %s", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("problem loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// writeCompletion writes the completion script of the commands, the client actions and their
+// flags. zsh runs the bash script with bashcompinit.
+func writeCompletion(w io.Writer, shell string) error {
+	words := map[string][]string{}
+	for name := range commands {
+		words["backend"] = append(words["backend"], name)
+	}
+	global := flag.NewFlagSet("client", flag.ContinueOnError)
+	addClientFlags(global)
+	global.VisitAll(func(f *flag.Flag) {
+		words["backend client"] = append(words["backend client"], "--"+f.Name)
+	})
+	words["backend client"] = append(words["backend client"], "completion")
+	for resource, actions := range clientActions {
+		words["backend client"] = append(words["backend client"], resource)
+		for name, a := range actions {
+			key := "backend client " + resource
+			words[key] = append(words[key], name)
+			// the actions without flags end the completion too
+			words[key+" "+name] = []string{}
+			flags := flag.NewFlagSet(name, flag.ContinueOnError)
+			a.setup(flags)
+			flags.VisitAll(func(f *flag.Flag) {
+				words[key+" "+name] = append(words[key+" "+name], "--"+f.Name)
+			})
+		}
+	}
+	words["backend client completion"] = []string{"bash", "zsh"}
+
+	var b strings.Builder
+	if shell == "zsh" {
+		b.WriteString("autoload -U +X bashcompinit && bashcompinit\n")
+	}
+	b.WriteString("declare -A _backend_words=(\n")
+	keys := make([]string, 0, len(words))
+	for k := range words {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sort.Strings(words[k])
+		fmt.Fprintf(&b, "\t[%q]=%q\n", k, strings.Join(words[k], " "))
+	}
+	b.WriteString(`)
+
+_backend() {
+	local cur="${COMP_WORDS[COMP_CWORD]}" key=backend w
+	for w in "${COMP_WORDS[@]:1:COMP_CWORD-1}"; do
+		if [[ -n "${_backend_words["$key $w"]}" ]]; then
+			key="$key $w"
+		fi
+	done
+	COMPREPLY=($(compgen -W "${_backend_words["$key"]}" -- "$cur"))
+}
+complete -F _backend backend
+`)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
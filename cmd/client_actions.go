@@ -0,0 +1,1200 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/backups/rpc/backupspb"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	idHeader          = []string{"ID"}
+	versionHeader     = []string{"PROJECT", "VERSION", "REVISION", "BUILD DATE", "GO VERSION"}
+	userHeader        = []string{"ID", "NAME", "POSTCODE", "SKILLS", "CONTACTS", "SCORE", "RATINGS"}
+	reputationHeader  = []string{"SCORE", "RATINGS", "TOTAL"}
+	erasureHeader     = []string{"ERASED", "REQUESTS", "NOTIFICATIONS"}
+	consentHeader     = []string{"PRIVACY POLICY", "CONTACT SHARING", "MARKETING"}
+	requestHeader     = []string{"ID", "TITLE", "STATE", "REQUESTER", "VOLUNTEER", "POSTCODE", "CATEGORY", "SKILLS"}
+	newsHeader        = []string{"ID", "TITLE", "PRIORITY", "POSTCODE", "REGION", "CREATED"}
+	preferencesHeader = []string{"USER", "DISABLED", "DISABLED CHANNELS", "MUTED EVENTS", "WEBHOOK URL"}
+	deadLetterHeader  = []string{"ID", "USER", "EVENT", "CHANNEL", "RECIPIENT", "ATTEMPTS", "FAILED", "ERROR"}
+	webhookHeader     = []string{"ID", "URL", "EVENTS", "LAST SEQUENCE", "FAILED", "LAST ERROR"}
+	testWebhookHeader = []string{"DELIVERED", "STATUS", "ERROR"}
+	backupHeader      = []string{"NAME", "CREATED", "SIZE", "SCHEMA", "USERS", "REQUESTS", "NEWS"}
+	auditHeader       = []string{"SEQUENCE", "DATE", "ACTOR", "ADDRESS", "METHOD", "ENTITY", "ID", "CODE", "CHANGED"}
+)
+
+var clientActions = map[string]map[string]clientAction{
+	"users": {
+		"version": versionAction(func(ctx context.Context, c *client) (versionResponse, error) {
+			return c.users.GetVersion(ctx, &userspb.GetVersionRequest{})
+		}),
+		"add": {
+			help: "Add a user",
+			setup: func(flags *flag.FlagSet) clientRun {
+				uf := addUserFlags(flags)
+				return func(ctx context.Context, c *client, args []string) error {
+					user := &userspb.User{}
+					uf.apply(flags, user)
+					resp, err := c.users.AddUser(ctx, &userspb.AddUserRequest{User: user})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, idHeader, resp.UserId)
+				}
+			},
+		},
+		"update": {
+			args: []string{"user"},
+			help: "Change the fields of a user given as flags",
+			setup: func(flags *flag.FlagSet) clientRun {
+				uf := addUserFlags(flags)
+				clear := flags.Bool("clear-contacts", false, "Remove all the contact details of the user")
+				return func(ctx context.Context, c *client, args []string) error {
+					if *clear && given(flags)["contact"] {
+						return errUsage
+					}
+					current, err := c.users.GetUserByID(ctx, &userspb.GetUserByIDRequest{UserId: args[0]})
+					if err != nil {
+						return err
+					}
+					user := current.User
+					if user == nil {
+						user = &userspb.User{}
+					}
+					uf.apply(flags, user)
+					if *clear {
+						user.ContactDetails = nil
+					}
+					resp, err := c.users.UpdateUser(ctx, &userspb.UpdateUserRequest{UserId: args[0], User: user, ClearContactDetails: *clear})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, userHeader, userRow(args[0], resp.User)...)
+				}
+			},
+		},
+		"delete": {
+			args: []string{"user"},
+			help: "Delete a user",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.users.DeleteUser(ctx, &userspb.DeleteUserRequest{UserId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"get": {
+			args: []string{"user"},
+			help: "Print a user",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.users.GetUserByID(ctx, &userspb.GetUserByIDRequest{UserId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, userHeader, userRow(args[0], resp.User)...)
+				}
+			},
+		},
+		"list": {
+			help: "Print all the users",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					stream, err := c.users.GetUsers(ctx, &userspb.GetUsersRequest{})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, userHeader, userRow(resp.UserId, resp.User)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+		"search": {
+			help: "Print the users of a postcode",
+			setup: func(flags *flag.FlagSet) clientRun {
+				postcode := flags.String("postcode", "", "Postcode of the users")
+				return func(ctx context.Context, c *client, args []string) error {
+					if *postcode == "" {
+						return errUsage
+					}
+					stream, err := c.users.SearchUsersByPostcode(ctx, &userspb.SearchUsersByPostcodeRequest{Postcode: *postcode})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, userHeader, userRow(resp.UserId, resp.User)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+		"reputation": {
+			args: []string{"user"},
+			help: "Print the reputation of a user",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.users.GetUserReputation(ctx, &userspb.GetUserReputationRequest{UserId: args[0]})
+					if err != nil {
+						return err
+					}
+					r := resp.Reputation
+					if r == nil {
+						r = &userspb.User_Reputation{}
+					}
+					return c.out.row(resp, reputationHeader, formatFloat(r.Score), formatUint(uint64(r.Ratings)), formatUint(uint64(r.Total)))
+				}
+			},
+		},
+		"export-data": {
+			args: []string{"user"},
+			help: "Print everything stored about a user as a JSON document, served on the admin listener",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.users.ExportMyData(ctx, &userspb.ExportMyDataRequest{UserId: args[0]})
+					if err != nil {
+						return err
+					}
+					// the document is printed as it is whatever the output
+					_, err = fmt.Fprintln(c.out.w, resp.Bundle)
+					return err
+				}
+			},
+		},
+		"erase": {
+			args: []string{"user"},
+			help: "Delete a user and pseudonymize the requests they took part in, served on the admin listener",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.users.EraseUser(ctx, &userspb.EraseUserRequest{UserId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, erasureHeader, resp.ErasureDate, formatUint(uint64(resp.Requests)), formatUint(uint64(resp.Notifications)))
+				}
+			},
+		},
+		"consent": {
+			args: []string{"user"},
+			help: "Print the consents of a user and whether they have to accept the current privacy policy",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.users.GetConsent(ctx, &userspb.GetConsentRequest{UserId: args[0]})
+					if err != nil {
+						return err
+					}
+					header := append(append([]string{}, consentHeader...), "CURRENT POLICY", "RECONSENT REQUIRED")
+					columns := append(consentColumns(resp.Consents), resp.PolicyVersion, strconv.FormatBool(resp.ReconsentRequired))
+					return c.out.row(resp, header, columns...)
+				}
+			},
+		},
+		"grant-consent": {
+			args: []string{"user"},
+			help: "Record the consent of a user to the purposes given as flags",
+			setup: func(flags *flag.FlagSet) clientRun {
+				purposes := listFlag(flags, "purpose", "Purpose consented to: privacy_policy, contact_sharing or marketing, can be repeated")
+				version := flags.String("policy-version", "", "Version of the privacy policy accepted, the current version if empty")
+				source := flags.String("source", "cli", "Where the consent was given")
+				return func(ctx context.Context, c *client, args []string) error {
+					req := &userspb.GrantConsentRequest{UserId: args[0], PolicyVersion: *version, Source: *source}
+					var err error
+					if req.Purposes, err = parsePurposes(*purposes); err != nil {
+						return err
+					}
+					resp, err := c.users.GrantConsent(ctx, req)
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, consentHeader, consentColumns(resp.Consents)...)
+				}
+			},
+		},
+		"revoke-consent": {
+			args: []string{"user"},
+			help: "Record that a user withdrew their consent to the purposes given as flags",
+			setup: func(flags *flag.FlagSet) clientRun {
+				purposes := listFlag(flags, "purpose", "Purpose withdrawn: privacy_policy, contact_sharing or marketing, can be repeated")
+				source := flags.String("source", "cli", "Where the consent was withdrawn")
+				return func(ctx context.Context, c *client, args []string) error {
+					req := &userspb.RevokeConsentRequest{UserId: args[0], Source: *source}
+					var err error
+					if req.Purposes, err = parsePurposes(*purposes); err != nil {
+						return err
+					}
+					resp, err := c.users.RevokeConsent(ctx, req)
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, consentHeader, consentColumns(resp.Consents)...)
+				}
+			},
+		},
+	},
+	"requests": {
+		"version": versionAction(func(ctx context.Context, c *client) (versionResponse, error) {
+			return c.requests.GetVersion(ctx, &requestspb.GetVersionRequest{})
+		}),
+		"add": {
+			help: "Add a request",
+			setup: func(flags *flag.FlagSet) clientRun {
+				rf := addRequestFlags(flags)
+				return func(ctx context.Context, c *client, args []string) error {
+					request := &requestspb.Request{}
+					rf.apply(flags, request)
+					resp, err := c.requests.AddRequest(ctx, &requestspb.AddRequestRequest{Request: request})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, idHeader, resp.RequestId)
+				}
+			},
+		},
+		"update": {
+			args: []string{"request"},
+			help: "Change the fields of a request given as flags",
+			setup: func(flags *flag.FlagSet) clientRun {
+				rf := addRequestFlags(flags)
+				return func(ctx context.Context, c *client, args []string) error {
+					current, err := c.requests.GetRequestByID(ctx, &requestspb.GetRequestByIDRequest{RequestId: args[0]})
+					if err != nil {
+						return err
+					}
+					request := current.Request
+					if request == nil {
+						request = &requestspb.Request{}
+					}
+					rf.apply(flags, request)
+					resp, err := c.requests.UpdateRequest(ctx, &requestspb.UpdateRequestRequest{RequestId: args[0], Request: request})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, requestHeader, requestRow(args[0], resp.Request)...)
+				}
+			},
+		},
+		"delete": {
+			args: []string{"request"},
+			help: "Delete a request",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.requests.DeleteRequest(ctx, &requestspb.DeleteRequestRequest{RequestId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"get": {
+			args: []string{"request"},
+			help: "Print a request",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.requests.GetRequestByID(ctx, &requestspb.GetRequestByIDRequest{RequestId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, requestHeader, requestRow(args[0], resp.Request)...)
+				}
+			},
+		},
+		"list": {
+			help: "Print all the requests",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					stream, err := c.requests.GetRequests(ctx, &requestspb.GetRequestsRequest{})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, requestHeader, requestRow(resp.RequestId, resp.Request)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+		"search": {
+			help: "Print the requests of a postcode",
+			setup: func(flags *flag.FlagSet) clientRun {
+				postcode := flags.String("postcode", "", "Postcode of the requests")
+				return func(ctx context.Context, c *client, args []string) error {
+					if *postcode == "" {
+						return errUsage
+					}
+					stream, err := c.requests.SearchRequestsByPostcode(ctx, &requestspb.SearchRequestsByPostcodeRequest{Postcode: *postcode})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, requestHeader, requestRow(resp.RequestId, resp.Request)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+		"answer": {
+			args: []string{"request", "volunteer"},
+			help: "Offer to help with a request",
+			setup: func(flags *flag.FlagSet) clientRun {
+				comment := flags.String("comment", "", "Comment of the volunteer")
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.requests.AnswerRequest(ctx, &requestspb.AnswerRequestRequest{
+						RequestId: args[0],
+						Answer:    &requestspb.Request_Answer{VolunteerId: args[1], Comment: *comment},
+					})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"accept": {
+			args: []string{"request", "volunteer"},
+			help: "Accept the help of a volunteer",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.requests.AcceptHelp(ctx, &requestspb.AcceptHelpRequest{RequestId: args[0], VolunteerId: args[1]})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"complete": {
+			args: []string{"request"},
+			help: "Mark the help as done",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.requests.CompleteHelp(ctx, &requestspb.CompleteHelpRequest{RequestId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"cancel": {
+			args: []string{"request"},
+			help: "Cancel a request",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.requests.CancelHelp(ctx, &requestspb.CancelHelpRequest{RequestId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"rate": {
+			args: []string{"request", "rater"},
+			help: "Rate the other party of a completed request",
+			setup: func(flags *flag.FlagSet) clientRun {
+				score := flags.Int("score", 0, "Score from 1 to 5")
+				comment := flags.String("comment", "", "Comment of the rating")
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.requests.RateHelp(ctx, &requestspb.RateHelpRequest{
+						RequestId: args[0],
+						RaterId:   args[1],
+						Score:     int32(*score),
+						Comment:   *comment,
+					})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"watch": {
+			help:  "Print the requests as they change until interrupted",
+			watch: true,
+			setup: func(flags *flag.FlagSet) clientRun {
+				postcodes := listFlag(flags, "postcode", "Postcode of the requests, can be repeated")
+				skills := listFlag(flags, "skill", "Skill needed by the requests, can be repeated")
+				categories := listFlag(flags, "category", "Category of the requests, can be repeated")
+				resumeToken := flags.String("resume-token", "", "Resume token of the last event received, the events missed are replayed")
+				return func(ctx context.Context, c *client, args []string) error {
+					stream, err := c.requests.WatchRequests(ctx, &requestspb.WatchRequestsRequest{
+						Postcodes:   *postcodes,
+						Skills:      *skills,
+						Categories:  *categories,
+						ResumeToken: *resumeToken,
+					})
+					if err != nil {
+						return err
+					}
+					header := append(append([]string{"EVENT"}, requestHeader...), "RESUME TOKEN")
+					for {
+						resp, err := stream.Recv()
+						if err != nil {
+							return err
+						}
+						columns := append(append([]string{resp.Event.String()}, requestRow(resp.RequestId, resp.Request)...), resp.ResumeToken)
+						if err := c.out.row(resp, header, columns...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+	},
+	"news": {
+		"version": versionAction(func(ctx context.Context, c *client) (versionResponse, error) {
+			return c.news.GetVersion(ctx, &newspb.GetVersionRequest{})
+		}),
+		"add": {
+			help: "Add news",
+			setup: func(flags *flag.FlagSet) clientRun {
+				nf := addNewsFlags(flags)
+				return func(ctx context.Context, c *client, args []string) error {
+					news := &newspb.News{}
+					if err := nf.apply(flags, news); err != nil {
+						return err
+					}
+					resp, err := c.news.AddNew(ctx, &newspb.AddNewRequest{New: news})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, idHeader, resp.NewId)
+				}
+			},
+		},
+		"update": {
+			args: []string{"news"},
+			help: "Change the fields of news given as flags",
+			setup: func(flags *flag.FlagSet) clientRun {
+				nf := addNewsFlags(flags)
+				return func(ctx context.Context, c *client, args []string) error {
+					current, err := c.news.GetNewsByID(ctx, &newspb.GetNewsByIDRequest{NewsId: args[0]})
+					if err != nil {
+						return err
+					}
+					news := current.News
+					if news == nil {
+						news = &newspb.News{}
+					}
+					if err := nf.apply(flags, news); err != nil {
+						return err
+					}
+					resp, err := c.news.UpdateNew(ctx, &newspb.UpdateNewRequest{NewId: args[0], New: news})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, newsHeader, newsRow(args[0], resp.New)...)
+				}
+			},
+		},
+		"delete": {
+			args: []string{"news"},
+			help: "Delete news",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.news.DeleteNew(ctx, &newspb.DeleteNewRequest{NewId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"get": {
+			args: []string{"news"},
+			help: "Print news",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.news.GetNewsByID(ctx, &newspb.GetNewsByIDRequest{NewsId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, newsHeader, newsRow(args[0], resp.News)...)
+				}
+			},
+		},
+		"list": {
+			help: "Print all the news",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					stream, err := c.news.GetNews(ctx, &newspb.GetNewsRequest{})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, newsHeader, newsRow(resp.NewId, resp.New)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+		"search": {
+			help: "Print the news of a postcode",
+			setup: func(flags *flag.FlagSet) clientRun {
+				postcode := flags.String("postcode", "", "Postcode of the news")
+				return func(ctx context.Context, c *client, args []string) error {
+					if *postcode == "" {
+						return errUsage
+					}
+					stream, err := c.news.SearchNewsByPostcode(ctx, &newspb.SearchNewsByPostcodeRequest{Postcode: *postcode})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, newsHeader, newsRow(resp.NewsId, resp.News)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+		"watch": {
+			help:  "Print the news as they change until interrupted",
+			watch: true,
+			setup: func(flags *flag.FlagSet) clientRun {
+				postcodes := listFlag(flags, "postcode", "Postcode of the news, can be repeated")
+				regions := listFlag(flags, "region", "Region of the news, can be repeated")
+				minPriority := flags.String("min-priority", "normal", "Minimum priority of the news: normal, high or urgent")
+				replay := flags.Uint("replay", 0, "Number of recent news printed first")
+				return func(ctx context.Context, c *client, args []string) error {
+					priority, err := parseEnum(newspb.News_Priority_value, *minPriority, "priority")
+					if err != nil {
+						return err
+					}
+					stream, err := c.news.WatchNews(ctx, &newspb.WatchNewsRequest{
+						Postcodes:   *postcodes,
+						Regions:     *regions,
+						MinPriority: newspb.News_Priority(priority),
+						Replay:      uint32(*replay),
+					})
+					if err != nil {
+						return err
+					}
+					header := append([]string{"EVENT"}, newsHeader...)
+					for {
+						resp, err := stream.Recv()
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, header, append([]string{resp.Event.String()}, newsRow(resp.NewsId, resp.News)...)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+	},
+	"notifications": {
+		"version": versionAction(func(ctx context.Context, c *client) (versionResponse, error) {
+			return c.notifications.GetVersion(ctx, &notificationspb.GetVersionRequest{})
+		}),
+		"preferences": {
+			args: []string{"user"},
+			help: "Print the notification preferences of a user",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.notifications.GetPreferences(ctx, &notificationspb.GetPreferencesRequest{UserId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, preferencesHeader, preferencesRow(args[0], resp.Preferences)...)
+				}
+			},
+		},
+		"set-preferences": {
+			args: []string{"user"},
+			help: "Replace the notification preferences of a user",
+			setup: func(flags *flag.FlagSet) clientRun {
+				disabled := flags.Bool("disabled", false, "Disable every notification")
+				channels := listFlag(flags, "disable-channel", "Channel disabled: email, sms or webhook, can be repeated")
+				events := listFlag(flags, "mute", "Event muted: answered, accepted, completed or cancelled, can be repeated")
+				webhookURL := flags.String("webhook-url", "", "URL the webhook notifications are posted to")
+				return func(ctx context.Context, c *client, args []string) error {
+					prefs := &notificationspb.Preferences{Disabled: *disabled, WebhookUrl: *webhookURL}
+					for _, name := range *channels {
+						v, err := parseEnum(notificationspb.Channel_value, name, "channel")
+						if err != nil {
+							return err
+						}
+						prefs.DisabledChannels = append(prefs.DisabledChannels, notificationspb.Channel(v))
+					}
+					for _, name := range *events {
+						v, err := parseEnum(notificationspb.Event_value, name, "event")
+						if err != nil {
+							return err
+						}
+						prefs.MutedEvents = append(prefs.MutedEvents, notificationspb.Event(v))
+					}
+					resp, err := c.notifications.UpdatePreferences(ctx, &notificationspb.UpdatePreferencesRequest{UserId: args[0], Preferences: prefs})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, preferencesHeader, preferencesRow(args[0], resp.Preferences)...)
+				}
+			},
+		},
+		"dead-letters": {
+			help: "Print the notifications that couldn't be delivered",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					stream, err := c.notifications.GetDeadLetters(ctx, &notificationspb.GetDeadLettersRequest{})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, deadLetterHeader, deadLetterRow(resp.DeadLetterId, resp.DeadLetter)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+	},
+	"webhooks": {
+		"version": versionAction(func(ctx context.Context, c *client) (versionResponse, error) {
+			return c.webhooks.GetVersion(ctx, &webhookspb.GetVersionRequest{})
+		}),
+		"register": {
+			args: []string{"url"},
+			help: "Register a webhook, its secret is only printed now",
+			setup: func(flags *flag.FlagSet) clientRun {
+				secret := flags.String("secret", "", "Key signing the payloads, generated if empty")
+				events := listFlag(flags, "event", "Type of the events delivered, all of them if not given, can be repeated")
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.webhooks.RegisterWebhook(ctx, &webhookspb.RegisterWebhookRequest{Url: args[0], Secret: *secret, EventTypes: *events})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, []string{"ID", "SECRET"}, resp.WebhookId, resp.GetWebhook().GetSecret())
+				}
+			},
+		},
+		"delete": {
+			args: []string{"webhook"},
+			help: "Delete a webhook",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.webhooks.DeleteWebhook(ctx, &webhookspb.DeleteWebhookRequest{WebhookId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.message(resp)
+				}
+			},
+		},
+		"list": {
+			help: "Print the webhooks",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					stream, err := c.webhooks.GetWebhooks(ctx, &webhookspb.GetWebhooksRequest{})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, webhookHeader, webhookRow(resp.WebhookId, resp.Webhook)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+		"test": {
+			args: []string{"webhook"},
+			help: "Post a ping event to a webhook",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.webhooks.TestWebhook(ctx, &webhookspb.TestWebhookRequest{WebhookId: args[0]})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, testWebhookHeader, strconv.FormatBool(resp.Delivered), strconv.Itoa(int(resp.StatusCode)), resp.Error)
+				}
+			},
+		},
+	},
+	"backups": {
+		"version": versionAction(func(ctx context.Context, c *client) (versionResponse, error) {
+			return c.backups.GetVersion(ctx, &backupspb.GetVersionRequest{})
+		}),
+		"create": {
+			help: "Archive the users, requests and news as they are now",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					resp, err := c.backups.CreateBackup(ctx, &backupspb.CreateBackupRequest{})
+					if err != nil {
+						return err
+					}
+					return c.out.row(resp, backupHeader, backupRow(resp.Backup)...)
+				}
+			},
+		},
+		"list": {
+			help: "Print the backups, the newest first",
+			setup: func(flags *flag.FlagSet) clientRun {
+				return func(ctx context.Context, c *client, args []string) error {
+					stream, err := c.backups.GetBackups(ctx, &backupspb.GetBackupsRequest{})
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, backupHeader, backupRow(resp.Backup)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+	},
+	"audit": {
+		"version": versionAction(func(ctx context.Context, c *client) (versionResponse, error) {
+			return c.audit.GetVersion(ctx, &auditpb.GetVersionRequest{})
+		}),
+		"query": {
+			help: "Print the calls changing the users, requests and news, the oldest first, served on the admin listener",
+			setup: func(flags *flag.FlagSet) clientRun {
+				req := &auditpb.QueryAuditLogRequest{}
+				flags.StringVar(&req.EntityType, "entity-type", "", "Type of the entities changed: user, request or news")
+				flags.StringVar(&req.EntityId, "entity", "", "Id of the entity changed")
+				flags.StringVar(&req.Actor, "actor", "", "Actor of the calls")
+				flags.StringVar(&req.Since, "since", "", "RFC 3339 date of the first calls")
+				flags.StringVar(&req.Until, "until", "", "RFC 3339 date the calls are made before")
+				limit := flags.Uint("limit", 0, "Number of latest calls printed, all if 0")
+				return func(ctx context.Context, c *client, args []string) error {
+					req.Limit = uint32(*limit)
+					stream, err := c.audit.QueryAuditLog(ctx, req)
+					if err != nil {
+						return err
+					}
+					for {
+						resp, err := stream.Recv()
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+						if err := c.out.row(resp, auditHeader, auditRow(resp.Entry)...); err != nil {
+							return err
+						}
+					}
+				}
+			},
+		},
+	},
+}
+
+// versionResponse is the GetVersionResponse of every service.
+type versionResponse interface {
+	proto.Message
+	GetProject() string
+	GetVersion() string
+	GetGitRevision() string
+	GetBuildDate() string
+	GetGoVersion() string
+}
+
+func versionAction(call func(ctx context.Context, c *client) (versionResponse, error)) clientAction {
+	return clientAction{
+		help: "Print the version of the service",
+		setup: func(flags *flag.FlagSet) clientRun {
+			return func(ctx context.Context, c *client, args []string) error {
+				resp, err := call(ctx, c)
+				if err != nil {
+					return err
+				}
+				return c.out.row(resp, versionHeader, resp.GetProject(), resp.GetVersion(), resp.GetGitRevision(), resp.GetBuildDate(), resp.GetGoVersion())
+			}
+		},
+	}
+}
+
+// given returns the flags given on the command line.
+func given(flags *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// parseEnum returns the value of an enum from its name, in any case.
+func parseEnum(values map[string]int32, name, what string) (int32, error) {
+	v, ok := values[strings.ToUpper(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown %s %q", what, name)
+	}
+	return v, nil
+}
+
+type userFlags struct {
+	name     *string
+	address  *string
+	city     *string
+	postcode *string
+	country  *string
+	skills   *stringList
+	contacts *stringList
+}
+
+func addUserFlags(flags *flag.FlagSet) *userFlags {
+	return &userFlags{
+		name:     flags.String("name", "", "Name of the user"),
+		address:  flags.String("address", "", "Street address"),
+		city:     flags.String("city", "", "City"),
+		postcode: flags.String("postcode", "", "Postcode"),
+		country:  flags.String("country", "", "Country"),
+		skills:   listFlag(flags, "skill", "Skill of the user, can be repeated"),
+		contacts: listFlag(flags, "contact", "Contact as platform:identifier, e.g. email:ada@example.org, a phone number if there's no platform, can be repeated"),
+	}
+}
+
+// apply sets the fields of the flags given, the lists are replaced.
+func (f *userFlags) apply(flags *flag.FlagSet, u *userspb.User) {
+	set := given(flags)
+	if set["name"] {
+		u.Name = *f.name
+	}
+	if set["address"] || set["city"] || set["postcode"] || set["country"] {
+		if u.Address == nil {
+			u.Address = &userspb.User_Address{}
+		}
+		if set["address"] {
+			u.Address.Address = *f.address
+		}
+		if set["city"] {
+			u.Address.City = *f.city
+		}
+		if set["postcode"] {
+			u.Address.Postcode = *f.postcode
+		}
+		if set["country"] {
+			u.Address.Country = *f.country
+		}
+	}
+	if set["skill"] {
+		u.Skills = *f.skills
+	}
+	if set["contact"] {
+		u.ContactDetails = nil
+		for _, contact := range *f.contacts {
+			details := &userspb.User_ContactDetails{Identifier: contact}
+			if i := strings.Index(contact, ":"); i > 0 {
+				if v, err := parseEnum(userspb.User_ContactDetails_Platform_value, contact[:i], "platform"); err == nil {
+					details.Platform = userspb.User_ContactDetails_Platform(v)
+					details.Identifier = contact[i+1:]
+				}
+			}
+			u.ContactDetails = append(u.ContactDetails, details)
+		}
+	}
+}
+
+type requestFlags struct {
+	title     *string
+	body      *string
+	requester *string
+	postcode  *string
+	category  *string
+	skills    *stringList
+}
+
+func addRequestFlags(flags *flag.FlagSet) *requestFlags {
+	return &requestFlags{
+		title:     flags.String("title", "", "Title of the request"),
+		body:      flags.String("body", "", "Description of the help needed"),
+		requester: flags.String("requester", "", "Id of the user asking for help"),
+		postcode:  flags.String("postcode", "", "Postcode where the help is needed"),
+		category:  flags.String("category", "", "Category of the request"),
+		skills:    listFlag(flags, "skill", "Skill needed, can be repeated"),
+	}
+}
+
+// apply sets the fields of the flags given, the skills are replaced.
+func (f *requestFlags) apply(flags *flag.FlagSet, r *requestspb.Request) {
+	set := given(flags)
+	if set["title"] {
+		r.Title = *f.title
+	}
+	if set["body"] {
+		r.Body = *f.body
+	}
+	if set["requester"] {
+		r.RequesterId = *f.requester
+	}
+	if set["postcode"] {
+		r.Postcode = *f.postcode
+	}
+	if set["category"] {
+		r.Category = *f.category
+	}
+	if set["skill"] {
+		r.Skills = *f.skills
+	}
+}
+
+type newsFlags struct {
+	title    *string
+	body     *string
+	postcode *string
+	region   *string
+	priority *string
+	endDate  *string
+}
+
+func addNewsFlags(flags *flag.FlagSet) *newsFlags {
+	return &newsFlags{
+		title:    flags.String("title", "", "Title of the news"),
+		body:     flags.String("body", "", "Text of the news"),
+		postcode: flags.String("postcode", "", "Postcode concerned"),
+		region:   flags.String("region", "", "Region concerned"),
+		priority: flags.String("priority", "normal", "Priority: normal, high or urgent"),
+		endDate:  flags.String("end-date", "", "RFC 3339 date after which the news is outdated"),
+	}
+}
+
+// apply sets the fields of the flags given.
+func (f *newsFlags) apply(flags *flag.FlagSet, n *newspb.News) error {
+	set := given(flags)
+	if set["title"] {
+		n.Title = *f.title
+	}
+	if set["body"] {
+		n.Body = *f.body
+	}
+	if set["postcode"] {
+		n.Postcode = *f.postcode
+	}
+	if set["region"] {
+		n.Region = *f.region
+	}
+	if set["priority"] {
+		v, err := parseEnum(newspb.News_Priority_value, *f.priority, "priority")
+		if err != nil {
+			return err
+		}
+		n.Priority = newspb.News_Priority(v)
+	}
+	if set["end-date"] {
+		n.EndDate = *f.endDate
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatUint(u uint64) string {
+	return strconv.FormatUint(u, 10)
+}
+
+func userRow(id string, u *userspb.User) []string {
+	if u == nil {
+		u = &userspb.User{}
+	}
+	contacts := make([]string, len(u.ContactDetails))
+	for i, c := range u.ContactDetails {
+		contacts[i] = strings.ToLower(c.Platform.String()) + ":" + c.Identifier
+	}
+	r := u.Reputation
+	if r == nil {
+		r = &userspb.User_Reputation{}
+	}
+	return []string{
+		id, u.Name, u.GetAddress().GetPostcode(), strings.Join(u.Skills, ","), strings.Join(contacts, ","),
+		formatFloat(r.Score), formatUint(uint64(r.Ratings)),
+	}
+}
+
+func requestRow(id string, r *requestspb.Request) []string {
+	if r == nil {
+		r = &requestspb.Request{}
+	}
+	return []string{id, r.Title, r.State.String(), r.RequesterId, r.VolunteerId, r.Postcode, r.Category, strings.Join(r.Skills, ",")}
+}
+
+func newsRow(id string, n *newspb.News) []string {
+	if n == nil {
+		n = &newspb.News{}
+	}
+	return []string{id, n.Title, n.Priority.String(), n.Postcode, n.Region, n.CreationDate}
+}
+
+// consentColumns returns the consent of each purpose of consentHeader: granted, with the version
+// of the privacy policy accepted, revoked or - if never asked.
+func consentColumns(consents []*userspb.User_Consent) []string {
+	columns := []string{"-", "-", "-"}
+	for _, c := range consents {
+		if int(c.Purpose) >= len(columns) {
+			continue
+		}
+		switch {
+		case !c.Granted:
+			columns[c.Purpose] = "revoked"
+		case c.Purpose == userspb.User_Consent_PRIVACY_POLICY && c.PolicyVersion != "":
+			columns[c.Purpose] = "granted " + c.PolicyVersion
+		default:
+			columns[c.Purpose] = "granted"
+		}
+	}
+	return columns
+}
+
+func parsePurposes(names []string) ([]userspb.User_Consent_Purpose, error) {
+	if len(names) == 0 {
+		return nil, errUsage
+	}
+	purposes := make([]userspb.User_Consent_Purpose, len(names))
+	for i, name := range names {
+		v, err := parseEnum(userspb.User_Consent_Purpose_value, name, "purpose")
+		if err != nil {
+			return nil, err
+		}
+		purposes[i] = userspb.User_Consent_Purpose(v)
+	}
+	return purposes, nil
+}
+
+func preferencesRow(userID string, p *notificationspb.Preferences) []string {
+	if p == nil {
+		p = &notificationspb.Preferences{}
+	}
+	channels := make([]string, len(p.DisabledChannels))
+	for i, c := range p.DisabledChannels {
+		channels[i] = c.String()
+	}
+	events := make([]string, len(p.MutedEvents))
+	for i, e := range p.MutedEvents {
+		events[i] = e.String()
+	}
+	return []string{userID, strconv.FormatBool(p.Disabled), strings.Join(channels, ","), strings.Join(events, ","), p.WebhookUrl}
+}
+
+func deadLetterRow(id string, d *notificationspb.DeadLetter) []string {
+	if d == nil {
+		d = &notificationspb.DeadLetter{}
+	}
+	n := d.Notification
+	if n == nil {
+		n = &notificationspb.Notification{}
+	}
+	return []string{id, n.UserId, n.Event.String(), n.Channel.String(), n.Recipient, formatUint(uint64(d.Attempts)), d.FailureDate, d.Error}
+}
+
+func webhookRow(id string, w *webhookspb.Webhook) []string {
+	if w == nil {
+		w = &webhookspb.Webhook{}
+	}
+	return []string{id, w.Url, strings.Join(w.EventTypes, ","), formatUint(w.LastSequence), formatUint(w.FailedDeliveries), w.LastError}
+}
+
+func backupRow(b *backupspb.Backup) []string {
+	if b == nil {
+		b = &backupspb.Backup{}
+	}
+	return []string{
+		b.Name,
+		b.CreationDate,
+		formatUint(uint64(b.SizeBytes)),
+		formatUint(uint64(b.SchemaVersion)),
+		formatUint(uint64(b.Users)),
+		formatUint(uint64(b.Requests)),
+		formatUint(uint64(b.News)),
+	}
+}
+
+func auditRow(e *auditpb.Entry) []string {
+	if e == nil {
+		e = &auditpb.Entry{}
+	}
+	fields := make([]string, len(e.Changes))
+	for i, c := range e.Changes {
+		fields[i] = c.Field
+	}
+	return []string{formatUint(e.Sequence), e.Date, e.Actor, e.ClientAddress, e.Method, e.EntityType, e.EntityId, e.Code, strings.Join(fields, ",")}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// call runs the client against the service, it returns the output.
+func call(t *testing.T, addr string, wantCode int, args ...string) string {
+	var stdout, stderr bytes.Buffer
+	code := clientMain(context.Background(), &stdout, &stderr, append([]string{"--addr", addr, "--timeout", "5s"}, args...))
+	if code != wantCode {
+		t.Fatalf("client %s exited with %d, want %d: %s", strings.Join(args, " "), code, wantCode, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	adminAddr := freeAddr(t)
+	conn, exited := start(ctx, t, dir, "--admin-grpc-addr", adminAddr)
+	defer conn.Close()
+	addr := conn.Target()
+
+	var added struct {
+		UserID string `json:"userId"`
+	}
+	out := call(t, addr, 0, "--output", "json", "users", "add", "--name", "Ada", "--postcode", "12345", "--skill", "plumbing", "--contact", "email:ada@example.org")
+	if err := json.Unmarshal([]byte(out), &added); err != nil || added.UserID == "" {
+		t.Fatalf("expected the id of the user, got %q", out)
+	}
+
+	// the contact details are only shared once consented to
+	out = call(t, addr, 0, "users", "search", "--postcode", "12345")
+	if strings.Contains(out, "ada@example.org") {
+		t.Errorf("expected the contact details to be hidden, got:\n%s", out)
+	}
+	out = call(t, addr, 0, "users", "grant-consent", "--purpose", "privacy_policy", "--policy-version", "1", "--purpose", "contact_sharing", added.UserID)
+	if !strings.Contains(out, "granted") {
+		t.Errorf("unexpected consent output:\n%s", out)
+	}
+	out = call(t, addr, 0, "users", "search", "--postcode", "12345")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "email:ada@example.org") {
+		t.Errorf("unexpected search output:\n%s", out)
+	}
+	out = call(t, addr, 0, "--output", "json", "users", "consent", added.UserID)
+	if !strings.Contains(out, `"purpose":"CONTACT_SHARING","granted":true`) || strings.Contains(out, "reconsentRequired") {
+		t.Errorf("unexpected consent output: %s", out)
+	}
+	call(t, addr, 2, "users", "revoke-consent", added.UserID)
+
+	call(t, addr, 0, "users", "update", "--city", "Stockholm", added.UserID)
+	out = call(t, addr, 0, "--output", "json", "users", "get", added.UserID)
+	if !strings.Contains(out, `"city":"Stockholm"`) || !strings.Contains(out, `"name":"Ada"`) {
+		t.Errorf("expected the update to only change the city, got %s", out)
+	}
+	call(t, addr, 2, "users", "update", "--clear-contacts", "--contact", "email:ada@example.org", added.UserID)
+	call(t, addr, 0, "users", "update", "--clear-contacts", added.UserID)
+	out = call(t, addr, 0, "--output", "json", "users", "get", added.UserID)
+	if strings.Contains(out, "ada@example.org") {
+		t.Errorf("expected the contact details to be cleared, got %s", out)
+	}
+
+	requestID := strings.Fields(strings.Split(call(t, addr, 0, "requests", "add", "--title", "groceries", "--requester", added.UserID), "\n")[1])[0]
+	call(t, addr, 0, "requests", "answer", requestID, "volunteer")
+	call(t, addr, 0, "--actor", "support", "requests", "accept", requestID, "volunteer")
+	out = call(t, addr, 0, "requests", "get", requestID)
+	if !strings.Contains(out, "ACCEPTED") {
+		t.Errorf("expected the request to be accepted, got:\n%s", out)
+	}
+	call(t, addr, 1, "audit", "query", "--entity", requestID)
+	out = call(t, adminAddr, 0, "audit", "query", "--entity", requestID, "--actor", "support")
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "/requestspb.RequestsRPC/AcceptHelp") || !strings.Contains(lines[1], "state,volunteer_id") {
+		t.Errorf("unexpected audit output:\n%s", out)
+	}
+
+	// the watch prints the events until interrupted
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	var stdout, stderr bytes.Buffer
+	watched := make(chan int, 1)
+	go func() {
+		watched <- clientMain(watchCtx, &stdout, &stderr, []string{"--addr", addr, "--output", "json", "news", "watch", "--replay", "10"})
+	}()
+	call(t, addr, 0, "news", "add", "--title", "opening hours", "--priority", "high")
+	time.Sleep(200 * time.Millisecond)
+	stopWatch()
+	if code := <-watched; code != 0 {
+		t.Errorf("watch exited with %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "opening hours") {
+		t.Errorf("expected the watch to print the news, got %q", stdout.String())
+	}
+
+	call(t, addr, 1, "users", "get", "missing")
+	call(t, addr, 2, "users", "frobnicate")
+	call(t, addr, 2, "users", "get")
+
+	cancel()
+	wait(t, exited, 10*time.Second)
+}
+
+func TestClientCompletion(t *testing.T) {
+	var stdout bytes.Buffer
+	if code := clientMain(context.Background(), &stdout, ioutil.Discard, []string{"completion", "bash"}); code != 0 {
+		t.Fatalf("completion exited with %d", code)
+	}
+	for _, want := range []string{
+		`["backend"]="backup client export import migrate purge rekey restore seed serve verify"`,
+		`["backend client requests accept"]=""`,
+		`["backend client users add"]="--address --city --contact --country --name --postcode --skill"`,
+		"complete -F _backend backend",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("expected the script to contain %s", want)
+		}
+	}
+}
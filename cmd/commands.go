@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, args []string) int
+}
+
+var commands = map[string]command{
+	"serve":   {usage: "Run the service, the default", run: run},
+	"backup":  {usage: "Archive the users, requests and news while the service is stopped", run: runBackup},
+	"export":  {usage: "Write the users, requests and news as JSON Lines or CSV", run: runExport},
+	"import":  {usage: "Add the users, requests and news of JSON Lines files", run: runImport},
+	"migrate": {usage: "Upgrade the data files to the schema version of this binary", run: runMigrate},
+	"purge":   {usage: "Apply the retention rules to the requests and news", run: runPurge},
+	"rekey":   {usage: "Encrypt the data files with the first encryption key", run: runRekey},
+	"restore": {usage: "Replace the users, requests and news with a backup", run: runRestore},
+	"seed":    {usage: "Add fixtures like the ones of data/requests", run: runSeed},
+	"verify":  {usage: "Check that the ids referenced by the requests exist", run: runVerify},
+}
+
+func init() {
+	// registered apart, the completion of the client lists the commands
+	commands["client"] = command{usage: "Call the service, backend client --help lists the actions", run: runClient}
+}
+
+// dispatch runs the command given as first argument, the service is run when there's none.
+func dispatch(ctx context.Context, args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return run(ctx, args)
+	}
+	if args[0] == "help" {
+		printCommands()
+		return 0
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Printf("unknown command %q\n", args[0])
+		printCommands()
+		return 2
+	}
+	return cmd.run(ctx, args[1:])
+}
+
+func printCommands() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println("Usage: backend [command] [flags]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	for _, name := range names {
+		fmt.Printf("  %-8s %s\n", name, commands[name].usage)
+	}
+}
+
+// dataPaths are the flags of the data files read by the admin commands.
+type dataPaths struct {
+	dir      *string
+	users    *string
+	requests *string
+	news     *string
+	auditLog *string
+	keys     *string
+	keysFile *string
+}
+
+func addDataFlags(flags *flag.FlagSet) *dataPaths {
+	return &dataPaths{
+		dir:      flags.String("data-dir", "", "Directory of the data files, data files not given are stored in it"),
+		users:    flags.String("users-file", defaultUsersFile, "File to store user information"),
+		requests: flags.String("requests-file", defaultRequestsFile, "File to store request information"),
+		news:     flags.String("news-file", defaultNewsFile, "File to store news information"),
+		auditLog: flags.String("audit-log-file", defaultAuditLogFile, "File the calls changing the users, requests and news are appended to"),
+		keys:     flags.String("encryption-keys", "", encryptionKeysUsage),
+		keysFile: flags.String("encryption-keys-file", "", encryptionKeysFileUsage),
+	}
+}
+
+// errUnlocked is returned by the admin commands changing the data files outside a data directory,
+// only the data directory is locked: a running service would overwrite the changes.
+var errUnlocked = errors.New("--data-dir is required to change the data files, they aren't locked otherwise")
+
+// adminData are the stores opened by an admin command, the data directory is locked so the
+// service can't change them meanwhile.
+type adminData struct {
+	dir      *datadir.Dir
+	files    []*os.File
+	keys     *crypt.Keyring
+	codec    storage.Codec
+	users    *storage.UsersStorage
+	requests *storage.RequestsStorage
+	news     *storage.NewsStorage
+}
+
+// openData opens the stores, like the service does.
+func openData(flags *flag.FlagSet, paths *dataPaths) (*adminData, error) {
+	keys, err := encryptionKeys(*paths.keys, *paths.keysFile)
+	if err != nil {
+		return nil, err
+	}
+	d := &adminData{keys: keys, codec: dataCodec(keys)}
+	inDataDir := *paths.dir != ""
+	if inDataDir {
+		dir, err := datadir.Open(*paths.dir)
+		if err != nil {
+			return nil, err
+		}
+		d.dir = dir
+		if err := dir.Current(); err != nil {
+			d.Close()
+			return nil, err
+		}
+		resolveDataFiles(flags, *paths.dir)
+	}
+
+	open := func(path string) (io.ReadWriteSeeker, error) {
+		f, err := openDataFile(path, inDataDir)
+		if err != nil {
+			return nil, err
+		}
+		d.files = append(d.files, f)
+		return encrypted(f, keys), nil
+	}
+	f, err := open(*paths.users)
+	if err == nil {
+		d.users, err = getUserData(f)
+	}
+	if err == nil {
+		f, err = open(*paths.requests)
+	}
+	if err == nil {
+		d.requests, err = getRequestData(f)
+	}
+	if err == nil {
+		f, err = open(*paths.news)
+	}
+	if err == nil {
+		d.news, err = getNewsData(f)
+	}
+	if err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// Close syncs the stores and releases the data directory.
+func (d *adminData) Close() error {
+	var firstErr error
+	var stores []interface{ Sync() error }
+	if d.users != nil {
+		stores = append(stores, d.users)
+	}
+	if d.requests != nil {
+		stores = append(stores, d.requests)
+	}
+	if d.news != nil {
+		stores = append(stores, d.news)
+	}
+	for _, st := range stores {
+		if err := st.Sync(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, f := range d.files {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if d.dir != nil {
+		if err := d.dir.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
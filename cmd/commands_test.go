@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func readUsers(t *testing.T, path string) map[string]*userspb.User {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := make(map[string]*userspb.User)
+	if err := storage.Decode(b, &users); err != nil {
+		t.Fatal(err)
+	}
+	return users
+}
+
+func TestExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	from, to := filepath.Join(dir, "from"), filepath.Join(dir, "to")
+	export := filepath.Join(dir, "export.jsonl")
+	ctx := context.Background()
+
+	if code := dispatch(ctx, []string{"seed", "--data-dir", from, filepath.Join("..", "data", "requests")}); code != 0 {
+		t.Fatalf("seed exited with %d", code)
+	}
+	seeded := readUsers(t, filepath.Join(from, "users.json"))
+	if len(seeded) != 2 {
+		t.Fatalf("expected the 2 users of the fixtures, got %d", len(seeded))
+	}
+	if code := dispatch(ctx, []string{"export", "--data-dir", from, "--output", export}); code != 0 {
+		t.Fatalf("export exited with %d", code)
+	}
+
+	if code := dispatch(ctx, []string{"import", "--data-dir", to, "--dry-run", export}); code != 0 {
+		t.Fatalf("dry run exited with %d", code)
+	}
+	if users := readUsers(t, filepath.Join(to, "users.json")); len(users) != 0 {
+		t.Errorf("expected the dry run not to add anything, got %d users", len(users))
+	}
+	if code := dispatch(ctx, []string{"import", "--data-dir", to, export}); code != 0 {
+		t.Fatalf("import exited with %d", code)
+	}
+	if diff := cmp.Diff(seeded, readUsers(t, filepath.Join(to, "users.json"))); diff != "" {
+		t.Errorf("imported users differ (-want +got):\n%s", diff)
+	}
+	// importing twice would duplicate the ids
+	if code := dispatch(ctx, []string{"import", "--data-dir", to, export}); code != 1 {
+		t.Errorf("expected importing the same ids again to exit with 1, got %d", code)
+	}
+	// the files given alone aren't locked against a running service
+	usersFile := filepath.Join(to, "users.json")
+	if code := dispatch(ctx, []string{"import", "--users-file", usersFile, "--requests-file", filepath.Join(to, "requests.json"), "--news-file", filepath.Join(to, "news.json"), export}); code != 2 {
+		t.Errorf("expected importing without --data-dir to exit with 2, got %d", code)
+	}
+
+	csvFile := filepath.Join(dir, "users.csv")
+	if code := dispatch(ctx, []string{"export", "--data-dir", to, "--data", "users", "--format", "csv", "--output", csvFile}); code != 0 {
+		t.Fatalf("csv export exited with %d", code)
+	}
+	f, err := os.Open(csvFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][0] != "id" {
+		t.Errorf("expected a header and 2 rows, got %v", rows)
+	}
+}
+
+func TestImportValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		code  int
+		users int
+	}{
+		{
+			name: "valid",
+			lines: []string{
+				`{"id":"u1","user":{"name":"Ada"}}`,
+				`{"id":"r1","request":{"title":"groceries","requesterId":"u1"}}`,
+				`{"news":{"title":"opening hours"}}`,
+			},
+			users: 1,
+		},
+		{
+			name:  "dangling requester",
+			lines: []string{`{"id":"r1","request":{"title":"groceries","requesterId":"u1"}}`},
+			code:  1,
+		},
+		{
+			name: "duplicate id",
+			lines: []string{
+				`{"id":"u1","user":{"name":"Ada"}}`,
+				`{"id":"u1","user":{"name":"Grace"}}`,
+			},
+			code: 1,
+		},
+		{
+			name: "invalid record",
+			lines: []string{
+				`{"id":"u1","user":{"name":"Ada"}}`,
+				`{"id":"u2","user":{"name":"Grace"},"news":{"title":"both"}}`,
+			},
+			code: 1,
+		},
+		{
+			name:  "missing name",
+			lines: []string{`{"user":{}}`},
+			code:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "backend")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "import.jsonl")
+			if err := ioutil.WriteFile(file, []byte(strings.Join(tt.lines, "\n")), 0644); err != nil {
+				t.Fatal(err)
+			}
+			dataDir := filepath.Join(dir, "data")
+			if code := dispatch(context.Background(), []string{"import", "--data-dir", dataDir, file}); code != tt.code {
+				t.Errorf("expected exit code %d, got %d", tt.code, code)
+			}
+			if users := readUsers(t, filepath.Join(dataDir, "users.json")); len(users) != tt.users {
+				t.Errorf("expected %d users, got %d", tt.users, len(users))
+			}
+		})
+	}
+}
+
+func TestDanglingReferences(t *testing.T) {
+	users := map[string]*userspb.User{"u1": {Name: "Ada"}}
+	requests := map[string]*requestspb.Request{
+		"r1": {RequesterId: "u1", VolunteerId: "u1"},
+		"r2": {
+			RequesterId: "u2",
+			Answers:     []*requestspb.Request_Answer{{VolunteerId: "u1"}, {VolunteerId: "u3"}},
+			Ratings:     []*requestspb.Request_Rating{{RaterId: "u1", RateeId: "u4"}},
+		},
+	}
+	want := []string{
+		`request r2: requester_id "u2" isn't a user`,
+		`request r2: answers[1].volunteer_id "u3" isn't a user`,
+		`request r2: ratings[0].ratee_id "u4" isn't a user`,
+	}
+	if diff := cmp.Diff(want, danglingReferences(users, requests)); diff != "" {
+		t.Errorf("danglingReferences() differs (-want +got):\n%s", diff)
+	}
+}
+
+func TestVerifyAndMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+
+	if code := dispatch(ctx, []string{"migrate", "--data-dir", dir}); code != 0 {
+		t.Errorf("migrate exited with %d", code)
+	}
+	if code := dispatch(ctx, []string{"verify", "--data-dir", dir}); code != 0 {
+		t.Errorf("verify of empty data exited with %d", code)
+	}
+	requests := `{"r1":{"title":"groceries","requester_id":"u1"}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "requests.json"), []byte(requests), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if code := dispatch(ctx, []string{"verify", "--data-dir", dir}); code != 1 {
+		t.Errorf("expected verify of a dangling requester to exit with 1, got %d", code)
+	}
+	if code := dispatch(ctx, []string{"frobnicate"}); code != 2 {
+		t.Errorf("expected an unknown command to exit with 2, got %d", code)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+
+	if code := dispatch(ctx, []string{"migrate", "--data-dir", dir}); code != 0 {
+		t.Fatalf("migrate exited with %d", code)
+	}
+	requests, err := storage.Encode(map[string]*requestspb.Request{
+		"r1": {Title: "groceries", State: requestspb.Request_CANCELLED, CreationDate: "2020-04-25T10:00:00Z"},
+		"r2": {Title: "plumbing", State: requestspb.Request_WAITING, CreationDate: "2020-04-25T10:00:00Z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "requests.json")
+	if err := ioutil.WriteFile(path, requests, 0640); err != nil {
+		t.Fatal(err)
+	}
+	rules := []string{"--data-dir", dir, "--retention-rules", "requests.CANCELLED=purge:30d"}
+
+	if code := dispatch(ctx, append([]string{"purge", "--dry-run"}, rules...)); code != 0 {
+		t.Fatalf("purge --dry-run exited with %d", code)
+	}
+	if b, _ := ioutil.ReadFile(path); string(b) != string(requests) {
+		t.Errorf("expected the dry run to leave the requests, got %s", b)
+	}
+	if code := dispatch(ctx, append([]string{"purge"}, rules...)); code != 0 {
+		t.Fatalf("purge exited with %d", code)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	left := make(map[string]*requestspb.Request)
+	if err := storage.Decode(b, &left); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := left["r2"]; !ok || len(left) != 1 {
+		t.Errorf("expected only r2 to be left, got %v", left)
+	}
+
+	if code := dispatch(ctx, []string{"purge", "--data-dir", dir}); code != 2 {
+		t.Errorf("expected purge without rules to exit with 2, got %d", code)
+	}
+	if code := dispatch(ctx, []string{"purge", "--data-dir", dir, "--retention-rules", "users=purge:1d"}); code != 2 {
+		t.Errorf("expected purge with an invalid rule to exit with 2, got %d", code)
+	}
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/config"
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/internal/egress"
+	"github.com/euvsvirus-banan/backend/internal/retention"
+	"github.com/euvsvirus-banan/backend/internal/tlsconfig"
+	"github.com/sirupsen/logrus"
+)
+
+// reloadable are the settings applied on SIGHUP, the others require a restart.
+var reloadable = map[string]bool{
+	"debug":     true,
+	"log-level": true,
+}
+
+var dataFileFlags = []string{
+	"users-file",
+	"requests-file",
+	"news-file",
+	"preferences-file",
+	"dead-letters-file",
+	"webhooks-file",
+	"outbox-file",
+	"notifier-cursor-file",
+	"audit-log-file",
+}
+
+// resolveDataFiles moves the data files of the flag set into the data directory: the files not
+// given are named after the default of their flag and relative paths are in the directory.
+func resolveDataFiles(flags *flag.FlagSet, dir string) {
+	given := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+	})
+	for _, name := range dataFileFlags {
+		f := flags.Lookup(name)
+		if f == nil {
+			continue
+		}
+		path := f.Value.String()
+		if !given[name] {
+			path = filepath.Base(f.DefValue)
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		// the flag isn't marked as given, reloading doesn't see it as a change
+		_ = f.Value.Set(path)
+	}
+}
+
+// secrets are the settings masked when printed.
+var secrets = []string{"smtp-password", "sms-gateway-token", "encryption-keys"}
+
+func flagValues(flags *flag.FlagSet) map[string]string {
+	values := make(map[string]string)
+	flags.VisitAll(func(f *flag.Flag) {
+		values[f.Name] = f.Value.String()
+	})
+	return values
+}
+
+// validate checks the settings, returning all the problems found.
+func validate(values map[string]string) error {
+	var problems []string
+	check := func(name string, err error) {
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+
+	check("addr", validateAddr(values["addr"], false))
+	check("http-addr", validateAddr(values["http-addr"], true))
+	check("grpc-web-addr", validateAddr(values["grpc-web-addr"], true))
+	check("admin-addr", validateAddr(values["admin-addr"], true))
+	check("admin-grpc-addr", validateAddr(values["admin-grpc-addr"], true))
+	_, err := logLevel(values)
+	check("log-level", err)
+	for _, name := range []string{"shutdown-timeout", "tls-reload-interval", "health-check-interval", "retention-interval"} {
+		if d, err := time.ParseDuration(values[name]); err == nil && d <= 0 {
+			check(name, errors.New("must be positive"))
+		}
+	}
+	check("tls", tlsconfig.Config{
+		CertFile:     values["tls-cert"],
+		KeyFile:      values["tls-key"],
+		MinVersion:   values["tls-min-version"],
+		CipherPolicy: values["tls-cipher-policy"],
+		ClientCAFile: values["tls-client-ca"],
+		ClientAuth:   values["tls-client-auth"],
+	}.Validate())
+	for _, name := range dataFileFlags {
+		if values[name] == "" {
+			check(name, errors.New("a file is required"))
+		}
+	}
+	switch values["trace-exporter"] {
+	case "", "stdout", "otlp":
+	case "file":
+		if values["trace-file"] == "" {
+			check("trace-file", errors.New("a file is required by the file exporter"))
+		}
+	default:
+		check("trace-exporter", fmt.Errorf("unknown exporter %q, expected stdout, file or otlp", values["trace-exporter"]))
+	}
+	if values["trace-exporter"] == "otlp" {
+		check("otlp-endpoint", validateURL(values["otlp-endpoint"]))
+	}
+	if values["sms-gateway-url"] != "" {
+		check("sms-gateway-url", validateURL(values["sms-gateway-url"]))
+	}
+	if values["smtp-addr"] != "" {
+		check("smtp-addr", validateAddr(values["smtp-addr"], false))
+	}
+	if d, err := time.ParseDuration(values["backup-interval"]); err == nil && d < 0 {
+		check("backup-interval", errors.New("can't be negative"))
+	} else if d > 0 && values["backup-dir"] == "" && values["data-dir"] == "" {
+		check("backup-interval", errors.New("scheduled backups need --backup-dir or --data-dir"))
+	}
+	if keep, err := strconv.Atoi(values["backup-keep"]); err == nil && keep < 0 {
+		check("backup-keep", errors.New("can't be negative"))
+	}
+	if values["encryption-keys"] != "" {
+		if values["encryption-keys-file"] != "" {
+			check("encryption-keys", errors.New("can't be given with --encryption-keys-file"))
+		} else if _, err := crypt.ParseKeys(values["encryption-keys"]); err != nil {
+			check("encryption-keys", err)
+		}
+	}
+	if _, err := retention.ParseRules(values["retention-rules"]); err != nil {
+		check("retention-rules", err)
+	}
+	_, err = egress.ParseNetworks(values["webhook-allowed-networks"])
+	check("webhook-allowed-networks", err)
+	if values["migrate"] == "true" && values["data-dir"] == "" {
+		check("migrate", errors.New("the schema version is recorded in the data directory, --data-dir is required"))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid settings:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// origins returns the comma separated origins, none if empty.
+func origins(s string) []string {
+	var list []string
+	for _, o := range strings.Split(s, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			list = append(list, o)
+		}
+	}
+	return list
+}
+
+func validateAddr(addr string, optional bool) error {
+	if addr == "" {
+		if optional {
+			return nil
+		}
+		return errors.New("an address is required")
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("expected an http or https URL, got %q", s)
+	}
+	return nil
+}
+
+// logLevel returns the log level, --debug overrides --log-level.
+func logLevel(values map[string]string) (logrus.Level, error) {
+	if debug, _ := strconv.ParseBool(values["debug"]); debug {
+		return logrus.DebugLevel, nil
+	}
+	return logrus.ParseLevel(values["log-level"])
+}
+
+// reload applies the reloadable settings changed in the environment or the configuration file.
+func reload(logger *logrus.Entry, loader *config.Loader, current map[string]string) error {
+	values, err := loader.Reload()
+	if err != nil {
+		return err
+	}
+	if err := validate(values); err != nil {
+		return err
+	}
+
+	var changed, ignored []string
+	for name, v := range values {
+		if current[name] == v {
+			continue
+		}
+		if reloadable[name] {
+			changed = append(changed, name)
+		} else {
+			ignored = append(ignored, name)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(ignored)
+
+	level, _ := logLevel(values)
+	logger.Logger.SetLevel(level)
+
+	masked := loader.Mask(values)
+	for _, name := range changed {
+		current[name] = values[name]
+		logger.WithFields(logrus.Fields{
+			"setting": name,
+			"value":   masked[name],
+		}).Info("setting reloaded")
+	}
+	if len(ignored) > 0 {
+		logger.WithField("settings", ignored).Warn("settings changed that require a restart")
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/euvsvirus-banan/backend/internal/audit"
+	"github.com/euvsvirus-banan/backend/internal/backup"
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+)
+
+const (
+	encryptionKeysUsage     = "Comma separated id:base64 AES-256 keys encrypting the data files, the first one encrypts and the others only decrypt"
+	encryptionKeysFileUsage = "File with the id:base64 AES-256 keys encrypting the data files, one per line, the first one encrypts and the others only decrypt"
+)
+
+// encryptionKeys returns the keyring of the flags, nil when the data files aren't encrypted.
+func encryptionKeys(keys, keysFile string) (*crypt.Keyring, error) {
+	switch {
+	case keys != "" && keysFile != "":
+		return nil, errors.New("--encryption-keys and --encryption-keys-file can't both be given")
+	case keys != "":
+		return crypt.ParseKeys(keys)
+	case keysFile != "":
+		return crypt.LoadKeys(keysFile)
+	}
+	return nil, nil
+}
+
+// dataCodec returns the codec of the data files, encrypting them when there are keys.
+func dataCodec(keys *crypt.Keyring) storage.Codec {
+	if keys == nil {
+		return storage.Plain
+	}
+	return storage.Encrypted(keys)
+}
+
+// encrypted returns the file used by the store, encrypting the data when there are keys.
+func encrypted(f io.ReadWriteSeeker, keys *crypt.Keyring) io.ReadWriteSeeker {
+	if keys == nil {
+		return f
+	}
+	return storage.Encrypt(f, keys)
+}
+
+func runRekey(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("rekey", flag.ContinueOnError)
+	dataDir := flags.String("data-dir", "", "Directory of the data files")
+	keys := flags.String("encryption-keys", "", encryptionKeysUsage)
+	keysFile := flags.String("encryption-keys-file", "", encryptionKeysFileUsage)
+	auditLogFile := flags.String("audit-log-file", "", "Audit log encrypted too, the audit log of --data-dir if empty")
+	backupDir := flags.String("backup-dir", "", "Directory of the backup archives, the backups directory of --data-dir if empty")
+	retire := flags.String("retire", "", "Comma separated ids of the keys to check no file uses anymore, the command fails if one does")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: backend rekey --data-dir dir --encryption-keys-file file [--retire id]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	if *dataDir == "" {
+		fmt.Println("--data-dir is required")
+		return 2
+	}
+	keyring, err := encryptionKeys(*keys, *keysFile)
+	if err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	if keyring == nil {
+		fmt.Println("--encryption-keys or --encryption-keys-file is required")
+		return 2
+	}
+	var retired []string
+	if *retire != "" {
+		retired = strings.Split(*retire, ",")
+	}
+	for _, id := range retired {
+		if id == keyring.Primary() {
+			fmt.Printf("key %s encrypts the files, it can't be retired\n", id)
+			return 2
+		}
+	}
+	auditLogPath := *auditLogFile
+	if auditLogPath == "" {
+		auditLogPath = filepath.Join(*dataDir, filepath.Base(defaultAuditLogFile))
+	}
+
+	dir, err := datadir.Open(*dataDir)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer dir.Close()
+	if err := dir.Current(); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	// the log is checked and rewritten first, the data files are left as they were when it fails
+	entries, err := audit.Rekey(auditLogPath, keyring)
+	if err != nil {
+		fmt.Printf("%s: %s\n", auditLogPath, err)
+		return 1
+	}
+	if err := storage.Reencrypt(dir, keyring); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	fmt.Printf("data files of %s and %d audit entries encrypted with key %s\n", *dataDir, entries, keyring.Primary())
+
+	used, err := keyUsers(dir, auditLogPath, backupDirectory(*dataDir, *backupDir))
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	code := 0
+	for _, id := range retired {
+		if files := used[id]; len(files) > 0 {
+			fmt.Printf("key %s is still used by %s, it can't be retired\n", id, strings.Join(files, ", "))
+			code = 1
+			continue
+		}
+		fmt.Printf("key %s isn't used anymore, it can be removed from the keys\n", id)
+	}
+	return code
+}
+
+// keyUsers returns the files of the data directory, the audit log and the backups by the id of the
+// key they're encrypted with. The backups aren't encrypted again, they use the key they were made
+// with until they're rotated out.
+func keyUsers(dir *datadir.Dir, auditLogPath, backupDir string) (map[string][]string, error) {
+	used, err := storage.KeyIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := audit.KeyIDs(auditLogPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", auditLogPath, err)
+	}
+	for _, id := range ids {
+		used[id] = append(used[id], auditLogPath)
+	}
+	if backupDir == "" {
+		return used, nil
+	}
+	backups, err := backup.List(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range backups {
+		f, err := os.Open(b.Path)
+		if err != nil {
+			return nil, err
+		}
+		ids, err := backup.KeyIDs(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", b.Path, err)
+		}
+		for _, id := range ids {
+			used[id] = append(used[id], b.Path)
+		}
+	}
+	return used, nil
+}
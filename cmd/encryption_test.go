@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/internal/audit"
+	"github.com/euvsvirus-banan/backend/internal/backup"
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+)
+
+func TestEncryption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dataDir := filepath.Join(dir, "data")
+	ctx := context.Background()
+	key1 := "k1:" + strings.Repeat("A", 43) + "="
+	key2 := "k2:" + strings.Repeat("B", 43) + "="
+
+	if code := dispatch(ctx, []string{"seed", "--data-dir", dataDir, "--encryption-keys", key1, filepath.Join("..", "data", "requests")}); code != 0 {
+		t.Fatalf("seed exited with %d", code)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dataDir, "users.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "name") || !strings.Contains(string(b), `"keyId":"k1"`) {
+		t.Errorf("expected the users encrypted with k1, got %s", b)
+	}
+	if code := dispatch(ctx, []string{"verify", "--data-dir", dataDir}); code != 1 {
+		t.Errorf("expected verify without the key to exit with 1, got %d", code)
+	}
+	if code := dispatch(ctx, []string{"backup", "--data-dir", dataDir, "--encryption-keys", key1}); code != 0 {
+		t.Fatalf("backup exited with %d", code)
+	}
+	keys1, err := crypt.ParseKeys(key1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditLog, err := audit.Open(filepath.Join(dataDir, "audit.jsonl"), keys1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b"} {
+		if err := auditLog.Append(&auditpb.Entry{Method: "/newspb.NewsRPC/AddNew", EntityType: audit.News, EntityId: id, Code: "OK"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	auditLog.Close()
+
+	keysFile := filepath.Join(dir, "keys")
+	if err := ioutil.WriteFile(keysFile, []byte(key2+"\n"+key1+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// the backup still uses the previous key
+	if code := dispatch(ctx, []string{"rekey", "--data-dir", dataDir, "--encryption-keys-file", keysFile, "--retire", "k1"}); code != 1 {
+		t.Fatalf("expected rekey retiring the key of the backup to exit with 1, got %d", code)
+	}
+	ids, err := audit.KeyIDs(filepath.Join(dataDir, "audit.jsonl"))
+	if err != nil || len(ids) != 1 || ids[0] != "k2" {
+		t.Errorf("expected the audit log encrypted with k2, got %v, %v", ids, err)
+	}
+	backups, err := backup.List(filepath.Join(dataDir, backupsDir))
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("expected a backup, got %v, %v", backups, err)
+	}
+	if err := os.Remove(backups[0].Path); err != nil {
+		t.Fatal(err)
+	}
+	if code := dispatch(ctx, []string{"rekey", "--data-dir", dataDir, "--encryption-keys-file", keysFile, "--retire", "k1"}); code != 0 {
+		t.Fatalf("rekey exited with %d", code)
+	}
+	if code := dispatch(ctx, []string{"rekey", "--data-dir", dataDir, "--encryption-keys-file", keysFile, "--retire", "k2"}); code != 2 {
+		t.Errorf("expected retiring the primary key to exit with 2, got %d", code)
+	}
+	for _, name := range []string{"users.json", "requests.json", "news.json"} {
+		b, err := ioutil.ReadFile(filepath.Join(dataDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), `"keyId":"k2"`) {
+			t.Errorf("expected %s encrypted with k2, got %s", name, b)
+		}
+	}
+	// the previous key isn't needed anymore
+	if code := dispatch(ctx, []string{"verify", "--data-dir", dataDir, "--encryption-keys", key2}); code != 0 {
+		t.Errorf("verify with the new key exited with %d", code)
+	}
+
+	if code := dispatch(ctx, []string{"rekey", "--data-dir", dataDir}); code != 2 {
+		t.Errorf("expected rekey without keys to exit with 2, got %d", code)
+	}
+	if code := dispatch(ctx, []string{"verify", "--data-dir", dataDir, "--encryption-keys", key2, "--encryption-keys-file", keysFile}); code != 1 {
+		t.Errorf("expected verify with both keys flags to exit with 1, got %d", code)
+	}
+}
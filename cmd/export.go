@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// record is a line of the JSON Lines exports and imports, with one of the data.
+type record struct {
+	ID      string          `json:"id,omitempty"`
+	User    json.RawMessage `json:"user,omitempty"`
+	Request json.RawMessage `json:"request,omitempty"`
+	News    json.RawMessage `json:"news,omitempty"`
+}
+
+var dataKinds = []string{"users", "requests", "news"}
+
+func parseKinds(s string) ([]string, error) {
+	var kinds []string
+	for _, kind := range strings.Split(s, ",") {
+		kind = strings.TrimSpace(kind)
+		switch kind {
+		case "users", "requests", "news":
+			kinds = append(kinds, kind)
+		default:
+			return nil, fmt.Errorf("unknown data %q, expected users, requests or news", kind)
+		}
+	}
+	return kinds, nil
+}
+
+func runExport(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	paths := addDataFlags(flags)
+	format := flags.String("format", "jsonl", "Format of the export: jsonl or csv")
+	kindsFlag := flags.String("data", strings.Join(dataKinds, ","), "Comma separated data exported: users, requests or news, only one in csv")
+	output := flags.String("output", "", "File to write the export to, the standard output if empty")
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	kinds, err := parseKinds(*kindsFlag)
+	if err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	switch {
+	case *format == "csv" && len(kinds) != 1:
+		fmt.Println("the csv format exports one of users, requests or news")
+		return 2
+	case *format != "csv" && *format != "jsonl":
+		fmt.Printf("unknown format %q, expected jsonl or csv\n", *format)
+		return 2
+	}
+
+	d, err := openData(flags, paths)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer d.Close()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	if *format == "csv" {
+		err = exportCSV(bw, d, kinds[0])
+	} else {
+		err = exportJSONLines(bw, d, kinds)
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	return 0
+}
+
+func userIDs(users map[string]*userspb.User) []string {
+	ids := make([]string, 0, len(users))
+	for id := range users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func requestIDs(requests map[string]*requestspb.Request) []string {
+	ids := make([]string, 0, len(requests))
+	for id := range requests {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func newsIDs(news map[string]*newspb.News) []string {
+	ids := make([]string, 0, len(news))
+	for id := range news {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func exportJSONLines(w io.Writer, d *adminData, kinds []string) error {
+	m := jsonpb.Marshaler{}
+	enc := json.NewEncoder(w)
+	write := func(id string, msg proto.Message, set func(r *record, b json.RawMessage)) error {
+		s, err := m.MarshalToString(msg)
+		if err != nil {
+			return fmt.Errorf("problem marshalling %s: %w", id, err)
+		}
+		r := record{ID: id}
+		set(&r, json.RawMessage(s))
+		return enc.Encode(r)
+	}
+
+	for _, kind := range kinds {
+		switch kind {
+		case "users":
+			users := d.users.All()
+			for _, id := range userIDs(users) {
+				if err := write(id, users[id], func(r *record, b json.RawMessage) { r.User = b }); err != nil {
+					return err
+				}
+			}
+		case "requests":
+			requests := d.requests.All()
+			for _, id := range requestIDs(requests) {
+				if err := write(id, requests[id], func(r *record, b json.RawMessage) { r.Request = b }); err != nil {
+					return err
+				}
+			}
+		case "news":
+			news := d.news.All()
+			for _, id := range newsIDs(news) {
+				if err := write(id, news[id], func(r *record, b json.RawMessage) { r.News = b }); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// exportCSV writes one row per user, request or news, lists are joined with semicolons.
+func exportCSV(w io.Writer, d *adminData, kind string) error {
+	cw := csv.NewWriter(w)
+	switch kind {
+	case "users":
+		_ = cw.Write([]string{"id", "name", "address", "city", "postcode", "country", "contact_details", "skills", "reputation_score", "ratings"})
+		users := d.users.All()
+		for _, id := range userIDs(users) {
+			u := users[id]
+			address := u.Address
+			if address == nil {
+				address = &userspb.User_Address{}
+			}
+			contacts := make([]string, len(u.ContactDetails))
+			for i, c := range u.ContactDetails {
+				contacts[i] = c.Platform.String() + ":" + c.Identifier
+			}
+			reputation := u.Reputation
+			if reputation == nil {
+				reputation = &userspb.User_Reputation{}
+			}
+			_ = cw.Write([]string{
+				id, u.Name, address.Address, address.City, address.Postcode, address.Country,
+				strings.Join(contacts, ";"), strings.Join(u.Skills, ";"),
+				strconv.FormatFloat(reputation.Score, 'f', -1, 64), strconv.FormatUint(uint64(reputation.Ratings), 10),
+			})
+		}
+	case "requests":
+		_ = cw.Write([]string{"id", "title", "body", "requester_id", "volunteer_id", "postcode", "category", "state", "skills", "creation_date"})
+		requests := d.requests.All()
+		for _, id := range requestIDs(requests) {
+			r := requests[id]
+			_ = cw.Write([]string{
+				id, r.Title, r.Body, r.RequesterId, r.VolunteerId, r.Postcode, r.Category,
+				r.State.String(), strings.Join(r.Skills, ";"), r.CreationDate,
+			})
+		}
+	case "news":
+		_ = cw.Write([]string{"id", "title", "body", "postcode", "region", "priority", "creation_date"})
+		news := d.news.All()
+		for _, id := range newsIDs(news) {
+			n := news[id]
+			_ = cw.Write([]string{id, n.Title, n.Body, n.Postcode, n.Region, n.Priority.String(), n.CreationDate})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/google/uuid"
+)
+
+// entry is a user, request or news read from a record.
+type entry struct {
+	source  string
+	id      string
+	user    *userspb.User
+	request *requestspb.Request
+	news    *newspb.News
+}
+
+// errNoData is returned for the records without user, request or news.
+var errNoData = errors.New("no user, request or news")
+
+func parseRecord(r record) (entry, error) {
+	e := entry{id: r.ID}
+	u := jsonpb.Unmarshaler{}
+	given := 0
+	if len(r.User) > 0 {
+		given++
+		e.user = &userspb.User{}
+		if err := u.Unmarshal(bytes.NewReader(r.User), e.user); err != nil {
+			return e, fmt.Errorf("invalid user: %w", err)
+		}
+	}
+	if len(r.Request) > 0 {
+		given++
+		e.request = &requestspb.Request{}
+		if err := u.Unmarshal(bytes.NewReader(r.Request), e.request); err != nil {
+			return e, fmt.Errorf("invalid request: %w", err)
+		}
+	}
+	if len(r.News) > 0 {
+		given++
+		e.news = &newspb.News{}
+		if err := u.Unmarshal(bytes.NewReader(r.News), e.news); err != nil {
+			return e, fmt.Errorf("invalid news: %w", err)
+		}
+	}
+	switch given {
+	case 0:
+		return e, errNoData
+	case 1:
+		return e, nil
+	default:
+		return e, errors.New("only one of user, request or news can be given")
+	}
+}
+
+// readEntries reads the records of a JSON Lines file, or of files with one JSON object like the
+// fixtures. The records without data are skipped when skipEmpty is true.
+func readEntries(name string, r io.Reader, skipEmpty bool) ([]entry, []string) {
+	var entries []entry
+	var problems []string
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var rec record
+		err := dec.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		source := fmt.Sprintf("%s:%d", name, n)
+		if err != nil {
+			// the rest of the file can't be read after a syntax error
+			problems = append(problems, fmt.Sprintf("%s: %s", source, err))
+			break
+		}
+		e, err := parseRecord(rec)
+		if err == errNoData && skipEmpty {
+			continue
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", source, err))
+			continue
+		}
+		e.source = source
+		entries = append(entries, e)
+	}
+	return entries, problems
+}
+
+// validateEntries checks the entries before adding them, ids are generated for the ones without.
+// The users referenced by the requests have to exist or be added too.
+func validateEntries(d *adminData, entries []entry) []string {
+	var problems []string
+	users, requests, news := d.users.All(), d.requests.All(), d.news.All()
+	added := make(map[string]string)
+	for i := range entries {
+		e := &entries[i]
+		if e.id == "" {
+			e.id = uuid.New().String()
+		}
+		exists := false
+		switch {
+		case e.user != nil:
+			_, exists = users[e.id]
+			if e.user.Name == "" {
+				problems = append(problems, fmt.Sprintf("%s: the user has no name", e.source))
+			}
+		case e.request != nil:
+			_, exists = requests[e.id]
+			if e.request.Title == "" {
+				problems = append(problems, fmt.Sprintf("%s: the request has no title", e.source))
+			}
+		case e.news != nil:
+			_, exists = news[e.id]
+			if e.news.Title == "" {
+				problems = append(problems, fmt.Sprintf("%s: the news has no title", e.source))
+			}
+		}
+		if exists {
+			problems = append(problems, fmt.Sprintf("%s: %s already exists", e.source, e.id))
+		}
+		if other, ok := added[e.id]; ok {
+			problems = append(problems, fmt.Sprintf("%s: %s is already given at %s", e.source, e.id, other))
+		}
+		added[e.id] = e.source
+	}
+
+	for _, e := range entries {
+		if e.user != nil {
+			users[e.id] = e.user
+		}
+	}
+	for _, e := range entries {
+		if e.request == nil {
+			continue
+		}
+		for _, ref := range requestReferences(e.request) {
+			if _, ok := users[ref.id]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: %s %q isn't a user", e.source, ref.field, ref.id))
+			}
+		}
+	}
+	return problems
+}
+
+// addEntries adds the users first, the requests reference them. Each store is written once.
+func addEntries(d *adminData, entries []entry) (int, error) {
+	users := make(map[string]*userspb.User)
+	requests := make(map[string]*requestspb.Request)
+	news := make(map[string]*newspb.News)
+	for _, e := range entries {
+		switch {
+		case e.user != nil:
+			users[e.id] = e.user
+		case e.request != nil:
+			requests[e.id] = e.request
+		case e.news != nil:
+			news[e.id] = e.news
+		}
+	}
+	if err := d.users.AddAll(context.Background(), users); err != nil {
+		return 0, fmt.Errorf("users: %w", err)
+	}
+	if err := d.requests.AddAll(context.Background(), requests); err != nil {
+		return len(users), fmt.Errorf("requests: %w", err)
+	}
+	if err := d.news.AddAll(context.Background(), news); err != nil {
+		return len(users) + len(requests), fmt.Errorf("news: %w", err)
+	}
+	return len(users) + len(requests) + len(news), nil
+}
+
+// load validates the entries of the files and adds them unless it's a dry run, nothing is added
+// when a problem is found.
+func load(flags *flag.FlagSet, paths *dataPaths, files []string, skipEmpty, dryRun bool) int {
+	if !dryRun && *paths.dir == "" {
+		fmt.Println(errUnlocked)
+		return 2
+	}
+	d, err := openData(flags, paths)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer d.Close()
+
+	var entries []entry
+	var problems []string
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		e, p := readEntries(name, f, skipEmpty)
+		f.Close()
+		entries = append(entries, e...)
+		problems = append(problems, p...)
+	}
+	problems = append(problems, validateEntries(d, entries)...)
+	if len(problems) > 0 {
+		fmt.Printf("nothing added, %d problems found:\n  %s\n", len(problems), strings.Join(problems, "\n  "))
+		return 1
+	}
+
+	counts := make(map[string]int)
+	for _, e := range entries {
+		switch {
+		case e.user != nil:
+			counts["users"]++
+		case e.request != nil:
+			counts["requests"]++
+		case e.news != nil:
+			counts["news"]++
+		}
+	}
+	summary := fmt.Sprintf("%d users, %d requests and %d news", counts["users"], counts["requests"], counts["news"])
+	if dryRun {
+		fmt.Printf("%s would be added\n", summary)
+		return 0
+	}
+	if added, err := addEntries(d, entries); err != nil {
+		fmt.Printf("%s, only the first %d were added\n", err, added)
+		return 1
+	}
+	fmt.Printf("%s added\n", summary)
+	return 0
+}
+
+func runImport(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	paths := addDataFlags(flags)
+	dryRun := flags.Bool("dry-run", false, "Validate the files without adding anything")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: backend import [flags] file.jsonl...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	return load(flags, paths, flags.Args(), false, *dryRun)
+}
+
+func runSeed(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	paths := addDataFlags(flags)
+	dryRun := flags.Bool("dry-run", false, "Validate the fixtures without adding anything")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: backend seed [flags] [file or directory...], data/requests by default")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	fixtures := flags.Args()
+	if len(fixtures) == 0 {
+		fixtures = []string{filepath.Join("data", "requests")}
+	}
+
+	var files []string
+	for _, name := range fixtures {
+		info, err := os.Stat(name)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		if !info.IsDir() {
+			files = append(files, name)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(name, "*.json"))
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		files = append(files, matches...)
+	}
+	// the fixtures of calls that don't add anything are skipped
+	return load(flags, paths, files, true, *dryRun)
+}
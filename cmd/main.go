@@ -1,89 +1,476 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
 
+	auditService "github.com/euvsvirus-banan/backend/audit/pkg/service"
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	backupsService "github.com/euvsvirus-banan/backend/backups/pkg/service"
+	"github.com/euvsvirus-banan/backend/backups/rpc/backupspb"
+	"github.com/euvsvirus-banan/backend/internal/audit"
+	"github.com/euvsvirus-banan/backend/internal/backup"
+	"github.com/euvsvirus-banan/backend/internal/config"
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/euvsvirus-banan/backend/internal/egress"
+	"github.com/euvsvirus-banan/backend/internal/gateway"
+	"github.com/euvsvirus-banan/backend/internal/grpcweb"
+	"github.com/euvsvirus-banan/backend/internal/health"
+	"github.com/euvsvirus-banan/backend/internal/metrics"
+	"github.com/euvsvirus-banan/backend/internal/openapi"
+	"github.com/euvsvirus-banan/backend/internal/retention"
 	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/tlsconfig"
+	"github.com/euvsvirus-banan/backend/internal/tracing"
 	newsService "github.com/euvsvirus-banan/backend/news/pkg/service"
 	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/notifications/pkg/notifier"
+	notificationsService "github.com/euvsvirus-banan/backend/notifications/pkg/service"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
 	requestsService "github.com/euvsvirus-banan/backend/requests/pkg/service"
 	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
 	usersService "github.com/euvsvirus-banan/backend/users/pkg/service"
 	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/pkg/dispatcher"
+	webhooksService "github.com/euvsvirus-banan/backend/webhooks/pkg/service"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 )
 
-func getLogger(debug bool) *logrus.Entry { // nolint: unparam
+// gatewayNetwork is the network of the in-memory connection of the HTTP gateway, whose calls are
+// identified by the x-forwarded-for metadata.
+const gatewayNetwork = "bufconn"
+
+// the data files read by the admin commands too
+const (
+	defaultUsersFile    = "/euvsvirus-backend/users.json"
+	defaultRequestsFile = "/euvsvirus-backend/requests.json"
+	defaultNewsFile     = "/euvsvirus-backend/news.json"
+	defaultAuditLogFile = "/euvsvirus-backend/audit.jsonl"
+)
+
+// readHeaderTimeout is the time given to the HTTP clients to send the headers of their requests, the
+// connections of the slow clients aren't kept open.
+const readHeaderTimeout = 10 * time.Second
+
+// backupsDir is the directory of the backups in the data directory.
+const backupsDir = "backups"
+
+func getLogger(level logrus.Level) *logrus.Entry {
 	l := logrus.New()
-	if debug {
-		l.SetLevel(logrus.DebugLevel)
-	} else {
-		l.SetLevel(logrus.InfoLevel)
-	}
+	l.SetLevel(level)
 	return logrus.NewEntry(l)
 }
 
-func startService(logger *logrus.Entry, addr string, userData *storage.UsersStorage, requestData *storage.RequestsStorage, newsData *storage.NewsStorage) error {
+// serverConfig is how the services are served, built from the validated settings.
+type serverConfig struct {
+	addr            string
+	httpAddr        string
+	grpcWebAddr     string
+	adminAddr       string
+	adminGRPCAddr   string
+	shutdownTimeout time.Duration
+	allowedOrigins  []string
+	grpcTLS         *tls.Config
+	httpTLS         *tls.Config
+	policyVersion   string
+}
+
+// serverDeps are the stores and the components the services are built on.
+type serverDeps struct {
+	checker           *health.Checker
+	metrics           *metrics.Metrics
+	tracer            *tracing.Tracer
+	users             *storage.UsersStorage
+	requests          *storage.RequestsStorage
+	news              *storage.NewsStorage
+	preferences       *storage.PreferencesStorage
+	deadLetters       *storage.DeadLettersStorage
+	webhooks          *storage.WebhooksStorage
+	outbox            *storage.Outbox
+	webhookDispatcher *dispatcher.Dispatcher
+	guard             *egress.Guard
+	backups           *backup.Backups
+	auditLog          *audit.Log
+}
+
+// startService serves the RPC services until the context is cancelled, then stops accepting
+// connections and waits for the calls in progress up to the shutdown timeout.
+func startService(ctx context.Context, logger *logrus.Entry, cfg serverConfig, deps *serverDeps) error {
 	logger.WithFields(
 		logrus.Fields{
-			"addr": addr,
+			"addr": cfg.addr,
 		},
 	).Info("starting server")
 
-	lis, err := net.Listen("tcp", addr)
+	lis, err := net.Listen("tcp", cfg.addr)
 	if err != nil {
 		return fmt.Errorf("%w: problem listening on given address", err)
 	}
+	if cfg.grpcTLS != nil {
+		// TLS is terminated by the listener, the in-memory connection of the gateway stays plain
+		lis = tls.NewListener(lis, cfg.grpcTLS)
+	}
 
 	grpc_logrus.ReplaceGrpcLogger(logger)
 
-	grpcServer := grpc.NewServer(
-		grpc_middleware.WithUnaryServerChain(
+	draining := make(chan struct{})
+	auditor := audit.NewAuditor(logger, deps.auditLog, gatewayNetwork)
+	usersSvc := usersService.New(logger, deps.users, deps.requests, deps.preferences, deps.deadLetters, cfg.policyVersion)
+	requestsSvc := requestsService.New(logger, deps.requests, deps.users)
+	newsSvc := newsService.New(logger, deps.news)
+	notificationsSvc := notificationsService.New(logger, deps.preferences, deps.deadLetters, deps.guard)
+	webhooksSvc := webhooksService.New(logger, deps.webhooks, deps.outbox, deps.webhookDispatcher, deps.guard)
+	backupsSvc := backupsService.New(logger, deps.backups)
+	auditSvc := auditService.New(logger, deps.auditLog)
+
+	// the admin server serves the same services, the public one refuses the admin methods
+	newServer := func(public bool) *grpc.Server {
+		unary := []grpc.UnaryServerInterceptor{
+			deps.metrics.UnaryServerInterceptor(),
 			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			deps.tracer.UnaryServerInterceptor(),
 			grpc_logrus.UnaryServerInterceptor(logger),
-		),
-		grpc_middleware.WithStreamServerChain(
+		}
+		stream := []grpc.StreamServerInterceptor{
+			deps.metrics.StreamServerInterceptor(),
 			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			deps.tracer.StreamServerInterceptor(),
 			grpc_logrus.StreamServerInterceptor(logger),
-		),
-	)
+		}
+		if public {
+			unary = append(unary, refuseAdminUnary())
+			stream = append(stream, refuseAdminStream())
+		}
+		srv := grpc.NewServer(
+			grpc_middleware.WithUnaryServerChain(append(unary, auditor.UnaryServerInterceptor())...),
+			grpc_middleware.WithStreamServerChain(append(stream, drainStreams(draining))...),
+		)
+		userspb.RegisterUsersRPCServer(srv, usersSvc)
+		requestspb.RegisterRequestsRPCServer(srv, requestsSvc)
+		newspb.RegisterNewsRPCServer(srv, newsSvc)
+		notificationspb.RegisterNotificationsRPCServer(srv, notificationsSvc)
+		webhookspb.RegisterWebhooksRPCServer(srv, webhooksSvc)
+		backupspb.RegisterBackupsRPCServer(srv, backupsSvc)
+		auditpb.RegisterAuditRPCServer(srv, auditSvc)
+		healthpb.RegisterHealthServer(srv, deps.checker.Server())
+		reflection.Register(srv)
+		deps.metrics.InitializeMetrics(srv)
+		return srv
+	}
+	grpcServer := newServer(true)
+	grpcServers := []*grpc.Server{grpcServer}
+
+	errCh := make(chan error, 6)
+	var httpServers []*http.Server
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			errCh <- fmt.Errorf("%w: problem serving service", err)
+		}
+	}()
+
+	if cfg.adminGRPCAddr != "" {
+		logger.WithFields(
+			logrus.Fields{
+				"addr": cfg.adminGRPCAddr,
+			},
+		).Info("starting admin gRPC server")
+		adminLis, err := net.Listen("tcp", cfg.adminGRPCAddr)
+		if err != nil {
+			return fmt.Errorf("%w: problem listening on given admin address", err)
+		}
+		if cfg.grpcTLS != nil {
+			adminLis = tls.NewListener(adminLis, cfg.grpcTLS)
+		}
+		adminGRPCServer := newServer(false)
+		grpcServers = append(grpcServers, adminGRPCServer)
+		go func() {
+			if err := adminGRPCServer.Serve(adminLis); err != nil {
+				errCh <- fmt.Errorf("%w: problem serving admin service", err)
+			}
+		}()
+	}
+
+	if cfg.httpAddr != "" {
+		// the gateway calls the gRPC server through an in-memory connection
+		gatewayLis := bufconn.Listen(1024 * 1024)
+		go func() {
+			if err := grpcServer.Serve(gatewayLis); err != nil {
+				errCh <- fmt.Errorf("%w: problem serving gateway connection", err)
+			}
+		}()
+		conn, err := grpc.DialContext(
+			context.Background(),
+			"bufconn",
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return gatewayLis.Dial()
+			}),
+			grpc.WithInsecure(),
+		)
+		if err != nil {
+			return fmt.Errorf("%w: problem connecting gateway", err)
+		}
+		defer conn.Close()
+
+		logger.WithFields(
+			logrus.Fields{
+				"addr": cfg.httpAddr,
+			},
+		).Info("starting HTTP gateway")
+		gw := gateway.New(conn)
+		spec, err := openapi.Generate(gw.Routes())
+		if err != nil {
+			return fmt.Errorf("%w: problem generating OpenAPI document", err)
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(spec)
+		})
+		mux.Handle("/", gw)
+		httpServer := &http.Server{
+			Addr:              cfg.httpAddr,
+			Handler:           mux,
+			TLSConfig:         cfg.httpTLS,
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+		httpServers = append(httpServers, httpServer)
+		go func() {
+			if err := listenAndServe(httpServer); err != http.ErrServerClosed {
+				errCh <- fmt.Errorf("%w: problem serving HTTP gateway", err)
+			}
+		}()
+	}
+
+	if cfg.grpcWebAddr != "" {
+		logger.WithFields(
+			logrus.Fields{
+				"addr":            cfg.grpcWebAddr,
+				"allowed_origins": cfg.allowedOrigins,
+			},
+		).Info("starting gRPC-Web server")
+		grpcWebServer := &http.Server{
+			Addr:              cfg.grpcWebAddr,
+			Handler:           grpcweb.New(grpcServer, grpcWebServices, cfg.allowedOrigins),
+			TLSConfig:         cfg.httpTLS,
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+		httpServers = append(httpServers, grpcWebServer)
+		go func() {
+			if err := listenAndServe(grpcWebServer); err != http.ErrServerClosed {
+				errCh <- fmt.Errorf("%w: problem serving gRPC-Web", err)
+			}
+		}()
+	}
 
-	usersSvc := usersService.New(logger, userData)
-	userspb.RegisterUsersRPCServer(grpcServer, usersSvc)
+	if cfg.adminAddr != "" {
+		logger.WithFields(
+			logrus.Fields{
+				"addr": cfg.adminAddr,
+			},
+		).Info("starting admin server")
+		// the probes and the scrapes are answered on their own listener, kept off the network of
+		// the clients
+		mux := http.NewServeMux()
+		mux.HandleFunc("/healthz", deps.checker.Live)
+		mux.HandleFunc("/readyz", deps.checker.Ready)
+		mux.Handle("/metrics", deps.metrics.Handler())
+		adminServer := &http.Server{
+			Addr:              cfg.adminAddr,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+		httpServers = append(httpServers, adminServer)
+		go func() {
+			if err := listenAndServe(adminServer); err != http.ErrServerClosed {
+				errCh <- fmt.Errorf("%w: problem serving admin endpoints", err)
+			}
+		}()
+	}
 
-	requestsSvc := requestsService.New(logger, requestData)
-	requestspb.RegisterRequestsRPCServer(grpcServer, requestsSvc)
+	var serveErr error
+	select {
+	case serveErr = <-errCh:
+	case <-ctx.Done():
+	}
+	logger.Info("shutting down")
 
-	newsSvc := newsService.New(logger, newsData)
-	newspb.RegisterNewsRPCServer(grpcServer, newsSvc)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout)
+	defer cancel()
+	deps.checker.Shutdown()
+	close(draining)
 
-	reflection.Register(grpcServer)
+	// the HTTP servers go first as the gateway relies on the gRPC server
+	var wg sync.WaitGroup
+	for _, srv := range httpServers {
+		wg.Add(1)
+		go func(srv *http.Server) {
+			defer wg.Done()
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				logger.WithError(err).Warn("HTTP requests didn't finish in time")
+				_ = srv.Close()
+			}
+		}(srv)
+	}
+	wg.Wait()
 
-	if err := grpcServer.Serve(lis); err != nil {
-		return fmt.Errorf("%w: problem serving service", err)
+	stopped := make(chan struct{})
+	go func() {
+		for _, srv := range grpcServers {
+			srv.GracefulStop()
+		}
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		logger.Warn("RPCs didn't finish in time, closing connections")
+		for _, srv := range grpcServers {
+			srv.Stop()
+		}
+	}
+	return serveErr
+}
+
+// grpcWebServices are the services the browsers call through gRPC-Web, the others are used by the
+// operators and the backend services.
+var grpcWebServices = []string{
+	"userspb.UsersRPC",
+	"requestspb.RequestsRPC",
+	"newspb.NewsRPC",
+}
+
+// adminMethods are the RPCs only served on the admin gRPC listener, they act on or read the data of
+// any user without knowing who calls them.
+var adminMethods = []string{
+	"/userspb.UsersRPC/ExportMyData",
+	"/userspb.UsersRPC/EraseUser",
+	"/auditpb.AuditRPC/QueryAuditLog",
+}
+
+// refuseAdmin returns PermissionDenied for the admin methods.
+func refuseAdmin(method string) error {
+	for _, m := range adminMethods {
+		if method == m {
+			return status.Error(codes.PermissionDenied, "only served on the admin listener")
+		}
 	}
 	return nil
 }
 
+func refuseAdminUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := refuseAdmin(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func refuseAdminStream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := refuseAdmin(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// drainStreams ends the server streams once draining is closed, GracefulStop would otherwise wait
+// for the streams following the changes until their clients leave. The streams ended this way
+// return Unavailable so the clients reconnect.
+func drainStreams(draining <-chan struct{}) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		// the stream can already be wrapped, in which case the wrapper is reused
+		parent := ss.Context()
+		ctx, cancel := context.WithCancel(parent)
+		defer cancel()
+		drained := make(chan struct{})
+		go func() {
+			select {
+			case <-draining:
+				close(drained)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		err := handler(srv, wrapped)
+		select {
+		case <-drained:
+			if err == nil && parent.Err() == nil {
+				return status.Error(codes.Unavailable, "server shutting down")
+			}
+		default:
+		}
+		return err
+	}
+}
+
+// listenAndServe serves over TLS when the server has a TLS configuration.
+func listenAndServe(srv *http.Server) error {
+	if srv.TLSConfig != nil {
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServe()
+}
+
+func getTLSConfigs(cfg tlsconfig.Config, reloader *tlsconfig.Reloader) (*tls.Config, *tls.Config, error) {
+	if !cfg.Enabled() {
+		return nil, nil, nil
+	}
+	grpcTLS, err := cfg.Server(reloader, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the HTTP listeners are used by browsers, which don't have client certificates, unless they're
+	// required: the gateway and gRPC-Web calls reach the same RPCs, they'd bypass the requirement
+	httpTLS, err := cfg.Server(reloader, cfg.ClientAuth == tlsconfig.RequireClientCert)
+	if err != nil {
+		return nil, nil, err
+	}
+	return grpcTLS, httpTLS, nil
+}
+
+// openDataFile opens a data file, it's created with no data when it's in a data directory.
+func openDataFile(path string, inDataDir bool) (*os.File, error) {
+	if inDataDir {
+		return datadir.OpenFile(path)
+	}
+	return os.OpenFile(path, os.O_RDWR, 0644)
+}
+
 func getUserData(file io.ReadWriteSeeker) (*storage.UsersStorage, error) {
 	data := make(map[string]*userspb.User)
 	b, err := ioutil.ReadAll(file)
 	if err != nil {
 		return nil, fmt.Errorf("problem trying to read user data file: %w", err)
 	}
-	if err := json.Unmarshal(b, &data); err != nil {
+	if err := storage.CodecOf(file).Decode(b, &data); err != nil {
 		return nil, fmt.Errorf("problem unmarshalling user data: %w", err)
 	}
 	st := storage.NewUsersStorage(file, data)
@@ -96,7 +483,7 @@ func getRequestData(file io.ReadWriteSeeker) (*storage.RequestsStorage, error) {
 	if err != nil {
 		return nil, fmt.Errorf("problem trying to read request data file: %w", err)
 	}
-	if err := json.Unmarshal(b, &data); err != nil {
+	if err := storage.CodecOf(file).Decode(b, &data); err != nil {
 		return nil, fmt.Errorf("problem unmarshalling request data: %w", err)
 	}
 	st := storage.NewRequestsStorage(file, data)
@@ -109,68 +496,526 @@ func getNewsData(file io.ReadWriteSeeker) (*storage.NewsStorage, error) {
 	if err != nil {
 		return nil, fmt.Errorf("problem trying to read new data file: %w", err)
 	}
-	if err := json.Unmarshal(b, &data); err != nil {
+	if err := storage.CodecOf(file).Decode(b, &data); err != nil {
 		return nil, fmt.Errorf("problem unmarshalling new data: %w", err)
 	}
 	st := storage.NewNewsStorage(file, data)
 	return st, nil
 }
 
+func getPreferencesData(file io.ReadWriteSeeker) (*storage.PreferencesStorage, error) {
+	data := make(map[string]*notificationspb.Preferences)
+	b, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("problem trying to read preferences data file: %w", err)
+	}
+	if err := storage.CodecOf(file).Decode(b, &data); err != nil {
+		return nil, fmt.Errorf("problem unmarshalling preferences data: %w", err)
+	}
+	st := storage.NewPreferencesStorage(file, data)
+	return st, nil
+}
+
+func getDeadLettersData(file io.ReadWriteSeeker) (*storage.DeadLettersStorage, error) {
+	data := make(map[string]*notificationspb.DeadLetter)
+	b, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("problem trying to read dead letters data file: %w", err)
+	}
+	if err := storage.CodecOf(file).Decode(b, &data); err != nil {
+		return nil, fmt.Errorf("problem unmarshalling dead letters data: %w", err)
+	}
+	st := storage.NewDeadLettersStorage(file, data)
+	return st, nil
+}
+
+func getWebhooksData(file io.ReadWriteSeeker) (*storage.WebhooksStorage, error) {
+	data := make(map[string]*webhookspb.Webhook)
+	b, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("problem trying to read webhooks data file: %w", err)
+	}
+	if err := storage.CodecOf(file).Decode(b, &data); err != nil {
+		return nil, fmt.Errorf("problem unmarshalling webhooks data: %w", err)
+	}
+	st := storage.NewWebhooksStorage(file, data)
+	return st, nil
+}
+
+func getOutbox(file io.ReadWriteSeeker) (*storage.Outbox, error) {
+	data := make(map[string]*webhookspb.Event)
+	b, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("problem trying to read outbox file: %w", err)
+	}
+	if err := storage.CodecOf(file).Decode(b, &data); err != nil {
+		return nil, fmt.Errorf("problem unmarshalling outbox: %w", err)
+	}
+	return storage.NewOutbox(file, data), nil
+}
+
+// getSenders returns the senders of the notifications, the webhooks of the users are called with
+// the client of the guard.
+func getSenders(guard *egress.Guard, smtpAddr, smtpFrom, smtpUsername, smtpPassword, smsGatewayURL, smsGatewayToken string) map[notificationspb.Channel]notifier.Sender {
+	client := &http.Client{}
+	senders := map[notificationspb.Channel]notifier.Sender{
+		notificationspb.Channel_WEBHOOK: notifier.NewWebhookSender(guard.Client()),
+	}
+	if smtpAddr != "" {
+		senders[notificationspb.Channel_EMAIL] = notifier.NewEmailSender(smtpAddr, smtpFrom, smtpUsername, smtpPassword)
+	}
+	if smsGatewayURL != "" {
+		senders[notificationspb.Channel_SMS] = notifier.NewSMSSender(client, smsGatewayURL, smsGatewayToken)
+	}
+	return senders
+}
+
 func main() {
-	debug := flag.Bool("debug", false, "Enable debug mode")
-	addr := flag.String("addr", "127.0.0.1:65010", "Address to bind the service to")
-	usersFilePath := flag.String("users-file", "/euvsvirus-backend/users.json", "File to store user information")
-	requestsFilePath := flag.String("requests-file", "/euvsvirus-backend/requests.json", "File to store request information")
-	newsFilePath := flag.String("news-file", "/euvsvirus-backend/news.json", "File to store news information")
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+		// a second signal doesn't wait for the shutdown
+		<-signals
+		os.Exit(1)
+	}()
+	os.Exit(dispatch(ctx, os.Args[1:]))
+}
 
-	flag.Parse()
+// run starts the service and blocks until the context is cancelled, it returns the exit code.
+func run(ctx context.Context, args []string) int { // nolint: funlen, gocyclo
+	flags := flag.NewFlagSet("backend", flag.ContinueOnError)
 
-	logger := getLogger(*debug)
+	flags.String("config", "", "YAML file with the settings, named after the flags")
+	printConfig := flags.Bool("print-config", false, "Print the settings with the secrets masked and exit")
+	flags.Bool("debug", false, "Enable debug mode, same as --log-level debug")
+	flags.String("log-level", "info", "Minimum level of the logs: debug, info, warning or error")
+	addr := flags.String("addr", "127.0.0.1:65010", "Address to bind the service to")
+	shutdownTimeout := flags.Duration("shutdown-timeout", 5*time.Second, "Time given to the RPCs in progress to finish when shutting down")
+	httpAddr := flags.String("http-addr", "127.0.0.1:65011", "Address to bind the HTTP/JSON gateway to, disabled if empty")
+	grpcWebAddr := flags.String("grpc-web-addr", "127.0.0.1:65012", "Address to bind the gRPC-Web server to, disabled if empty")
+	adminAddr := flags.String("admin-addr", "127.0.0.1:65013", "Address to bind the health and metrics endpoints to, without TLS, disabled if empty")
+	adminGRPCAddr := flags.String("admin-grpc-addr", "127.0.0.1:65014", "Address to serve every RPC on, the admin methods like the erasure of the users included, disabled if empty")
+	allowedOrigins := flags.String("allowed-origins", "", "Comma separated origins allowed to make cross-origin gRPC-Web calls, none by default, * allows any origin")
+	tlsCert := flags.String("tls-cert", "", "Certificate file of the listeners, TLS is disabled if empty")
+	tlsKey := flags.String("tls-key", "", "Private key file of the certificate")
+	tlsMinVersion := flags.String("tls-min-version", "1.2", "Minimum TLS version accepted, 1.2 or 1.3")
+	tlsCipherPolicy := flags.String("tls-cipher-policy", tlsconfig.ModernCiphers, "TLS 1.2 cipher suites accepted: modern, intermediate or default")
+	tlsClientCA := flags.String("tls-client-ca", "", "Certificates of the authorities signing the client certificates of the gRPC callers")
+	tlsClientAuth := flags.String("tls-client-auth", tlsconfig.VerifyClientCertIfGiven, "Client certificate verification on the gRPC listener: none, verify-if-given or require")
+	tlsReloadInterval := flags.Duration("tls-reload-interval", time.Minute, "Interval between checks of the certificate files for rotated certificates")
+	traceExporter := flags.String("trace-exporter", "", "Exporter of the spans: stdout, file or otlp, spans are only propagated if empty")
+	traceFile := flags.String("trace-file", "traces.json", "File the spans are appended to with the file exporter")
+	otlpEndpoint := flags.String("otlp-endpoint", "http://localhost:4318/v1/traces", "Traces endpoint of the OpenTelemetry collector used by the otlp exporter")
+	healthCheckInterval := flags.Duration("health-check-interval", 10*time.Second, "Interval between health checks of the data files and background jobs")
+	dataDir := flags.String("data-dir", "", "Directory of the data files, created with empty files if missing, data files not given are stored in it")
+	migrate := flags.Bool("migrate", false, "Apply the pending schema migrations of --data-dir before starting")
+	usersFilePath := flags.String("users-file", defaultUsersFile, "File to store user information")
+	requestsFilePath := flags.String("requests-file", defaultRequestsFile, "File to store request information")
+	newsFilePath := flags.String("news-file", defaultNewsFile, "File to store news information")
+	preferencesFilePath := flags.String("preferences-file", "/euvsvirus-backend/preferences.json", "File to store notification preferences")
+	deadLettersFilePath := flags.String("dead-letters-file", "/euvsvirus-backend/dead_letters.json", "File to store notifications that couldn't be delivered")
+	webhooksFilePath := flags.String("webhooks-file", "/euvsvirus-backend/webhooks.json", "File to store webhook registrations")
+	outboxFilePath := flags.String("outbox-file", "/euvsvirus-backend/outbox.json", "File to store the domain events pending delivery")
+	notifierCursorFilePath := flags.String("notifier-cursor-file", "/euvsvirus-backend/notifier_cursor", "File to store the sequence of the last event of the outbox the users were notified about")
+	auditLogFilePath := flags.String("audit-log-file", defaultAuditLogFile, "File the calls changing the users, requests and news are appended to")
+	encryptionKeysFlag := flags.String("encryption-keys", "", encryptionKeysUsage)
+	encryptionKeysFile := flags.String("encryption-keys-file", "", encryptionKeysFileUsage)
+	backupDir := flags.String("backup-dir", "", "Directory of the backup archives, the backups directory of --data-dir if empty, backups are disabled without either")
+	backupInterval := flags.Duration("backup-interval", 0, "Interval between scheduled backups, backups are only made on demand if 0")
+	backupKeep := flags.Int("backup-keep", 7, "Number of backups kept, the oldest are deleted after each backup, all are kept if 0")
+	retentionRules := flags.String("retention-rules", "", retentionRulesUsage)
+	retentionInterval := flags.Duration("retention-interval", time.Hour, "Interval between applications of the retention rules")
+	retentionDryRun := flags.Bool("retention-dry-run", false, "Log the records the retention rules apply to without changing them")
+	privacyPolicyVersion := flags.String("privacy-policy-version", "", "Version of the privacy policy the users accept, they're asked to accept it again when it changes")
+	templatesDir := flags.String("notification-templates", "", "Directory with templates overriding the default notification templates")
+	smtpAddr := flags.String("smtp-addr", "", "SMTP server used to send email notifications, disabled if empty")
+	smtpFrom := flags.String("smtp-from", "noreply@euvsvirus-banan.org", "Sender address of email notifications")
+	smtpUsername := flags.String("smtp-username", "", "SMTP username")
+	smtpPassword := flags.String("smtp-password", "", "SMTP password")
+	smsGatewayURL := flags.String("sms-gateway-url", "", "HTTP gateway used to send SMS notifications, disabled if empty")
+	smsGatewayToken := flags.String("sms-gateway-token", "", "Bearer token of the SMS gateway")
+	webhookAllowedNetworks := flags.String("webhook-allowed-networks", "", "Comma separated private networks the webhooks can be called on, like 10.0.0.0/8, none by default")
 
-	usersFile, err := os.OpenFile(*usersFilePath, os.O_RDWR, 0644)
+	loader := config.New(flags, "config")
+	loader.Secret(secrets...)
+	loader.CommandLineOnly("print-config")
+	if err := loader.Load(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	settings := flagValues(flags)
+	if err := validate(settings); err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	if *printConfig {
+		if err := loader.Print(os.Stdout); err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		return 0
+	}
+
+	level, _ := logLevel(settings)
+	logger := getLogger(level)
+	allowedNetworks, _ := egress.ParseNetworks(*webhookAllowedNetworks)
+	guard := egress.New(allowedNetworks)
+	m := metrics.New()
+
+	if *dataDir != "" {
+		dir, err := datadir.Open(*dataDir)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		defer dir.Close()
+		if *migrate {
+			applied, err := dir.Migrate(storage.Migrations)
+			if err != nil {
+				fmt.Println(err)
+				return 1
+			}
+			for _, m := range applied {
+				logger.WithFields(
+					logrus.Fields{
+						"version":   m.Version,
+						"migration": m.Description,
+					},
+				).Info("data migrated")
+			}
+		}
+		if err := dir.Current(); err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		resolveDataFiles(flags, *dataDir)
+		if *backupDir == "" {
+			*backupDir = filepath.Join(*dataDir, backupsDir)
+		}
+	}
+
+	keys, err := encryptionKeys(*encryptionKeysFlag, *encryptionKeysFile)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		return 1
+	}
+
+	usersFile, err := openDataFile(*usersFilePath, *dataDir != "")
+	if err != nil {
+		fmt.Println(err)
+		return 1
 	}
 	defer usersFile.Close()
-	userData, err := getUserData(usersFile)
+	userData, err := getUserData(encrypted(m.File("users", usersFile), keys))
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		return 1
 	}
 
-	requestsFile, err := os.OpenFile(*requestsFilePath, os.O_RDWR, 0644)
+	requestsFile, err := openDataFile(*requestsFilePath, *dataDir != "")
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		return 1
 	}
 	defer requestsFile.Close()
-	requestData, err := getRequestData(requestsFile)
+	requestData, err := getRequestData(encrypted(m.File("requests", requestsFile), keys))
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		return 1
 	}
 
-	newsFile, err := os.OpenFile(*newsFilePath, os.O_RDWR, 0644)
+	newsFile, err := openDataFile(*newsFilePath, *dataDir != "")
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		return 1
 	}
 	defer newsFile.Close()
-	newsData, err := getNewsData(newsFile)
+	newsData, err := getNewsData(encrypted(m.File("news", newsFile), keys))
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		return 1
+	}
+
+	preferencesFile, err := openDataFile(*preferencesFilePath, *dataDir != "")
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer preferencesFile.Close()
+	preferenceData, err := getPreferencesData(encrypted(m.File("preferences", preferencesFile), keys))
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+
+	deadLettersFile, err := openDataFile(*deadLettersFilePath, *dataDir != "")
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer deadLettersFile.Close()
+	deadLetterData, err := getDeadLettersData(encrypted(m.File("dead_letters", deadLettersFile), keys))
+	if err != nil {
+		fmt.Println(err)
+		return 1
 	}
 
-	if err := startService(
+	webhooksFile, err := openDataFile(*webhooksFilePath, *dataDir != "")
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer webhooksFile.Close()
+	webhookData, err := getWebhooksData(encrypted(m.File("webhooks", webhooksFile), keys))
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+
+	outboxFile, err := openDataFile(*outboxFilePath, *dataDir != "")
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer outboxFile.Close()
+	outbox, err := getOutbox(encrypted(m.File("outbox", outboxFile), keys))
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	// the events are kept in the outbox until the users are notified about them
+	notifierCursor, err := storage.OpenCursor(*notifierCursorFilePath, outbox.Last())
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	outbox.AddConsumer(notifierCursor)
+	userData.SetOutbox(outbox)
+	requestData.SetOutbox(outbox)
+	newsData.SetOutbox(outbox)
+	m.RegisterData(userData, requestData, newsData)
+
+	// the service doesn't start when the chain of the entries is broken
+	auditLog, err := audit.Open(*auditLogFilePath, keys)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer auditLog.Close()
+
+	templates, err := notifier.LoadTemplates(*templatesDir)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+
+	// the background jobs are stopped once the RPCs in progress are done
+	bgCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+
+	tlsCfg := tlsconfig.Config{
+		CertFile:     *tlsCert,
+		KeyFile:      *tlsKey,
+		MinVersion:   *tlsMinVersion,
+		CipherPolicy: *tlsCipherPolicy,
+		ClientCAFile: *tlsClientCA,
+		ClientAuth:   *tlsClientAuth,
+	}
+	var tlsReloader *tlsconfig.Reloader
+	if tlsCfg.Enabled() {
+		if tlsReloader, err = tlsconfig.NewReloader(logger, *tlsCert, *tlsKey, *tlsClientCA); err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		go tlsReloader.Run(bgCtx, *tlsReloadInterval)
+	}
+	grpcTLS, httpTLS, err := getTLSConfigs(tlsCfg, tlsReloader)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	var exporter tracing.Exporter
+	switch *traceExporter {
+	case "":
+	case "stdout":
+		exporter = tracing.NewJSONExporter(logger, os.Stdout)
+	case "file":
+		f, err := os.OpenFile(*traceFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		defer f.Close()
+		exporter = tracing.NewJSONExporter(logger, f)
+	case "otlp":
+		otlp := tracing.NewOTLPExporter(logger, &http.Client{}, *otlpEndpoint, "euvsvirus-backend")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			otlp.Run(bgCtx, 5*time.Second)
+		}()
+		exporter = otlp
+	default:
+		fmt.Printf("unknown trace exporter %q\n", *traceExporter)
+		return 2
+	}
+	tracer := tracing.New(exporter)
+
+	reloads := make(chan os.Signal, 1)
+	signal.Notify(reloads, syscall.SIGHUP)
+	defer signal.Stop(reloads)
+	go func() {
+		for {
+			select {
+			case <-bgCtx.Done():
+				return
+			case <-reloads:
+				if err := reload(logger, loader, settings); err != nil {
+					logger.WithError(err).Error("problem reloading settings")
+				}
+			}
+		}
+	}()
+
+	n := notifier.New(
 		logger,
-		*addr,
+		outbox,
+		notifierCursor,
+		userData,
+		preferenceData,
+		deadLetterData,
+		getSenders(guard, *smtpAddr, *smtpFrom, *smtpUsername, *smtpPassword, *smsGatewayURL, *smsGatewayToken),
+		templates,
+		notifier.DefaultConfig(),
+	)
+	notifierLoop := health.NewLoop("notifier")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := notifierLoop.Run(bgCtx, n.Run); err != nil {
+			logger.WithError(err).Error("notifier stopped")
+		}
+	}()
+
+	webhookDispatcher := dispatcher.New(logger, outbox, webhookData, guard.Client(), dispatcher.DefaultConfig())
+	dispatcherLoop := health.NewLoop("webhook dispatcher")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := dispatcherLoop.Run(bgCtx, webhookDispatcher.Run); err != nil {
+			logger.WithError(err).Error("webhook dispatcher stopped")
+		}
+	}()
+
+	var backups *backup.Backups
+	if *backupDir != "" {
+		backups = backup.New(logger, *backupDir, *backupKeep, dataCodec(keys), func() *backup.Data {
+			users, requests, news := storage.Snapshot(userData, requestData, newsData)
+			return &backup.Data{Users: users, Requests: requests, News: news}
+		})
+		if *backupInterval > 0 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = backups.Run(bgCtx, *backupInterval)
+			}()
+		}
+	}
+
+	if *retentionRules != "" {
+		rules, _ := retention.ParseRules(*retentionRules)
+		r := retention.New(logger, rules, requestData, newsData, m)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = r.Run(bgCtx, *retentionInterval, *retentionDryRun)
+		}()
+	}
+
+	const (
+		usersRPC         = "userspb.UsersRPC"
+		requestsRPC      = "requestspb.RequestsRPC"
+		newsRPC          = "newspb.NewsRPC"
+		notificationsRPC = "notificationspb.NotificationsRPC"
+		webhooksRPC      = "webhookspb.WebhooksRPC"
+		auditRPC         = "auditpb.AuditRPC"
+	)
+	checker := health.New(
+		logger,
+		// requests are accepted and rated by users
+		health.Check{Name: "users-file", Func: health.Writable(*usersFilePath), Services: []string{usersRPC, requestsRPC}},
+		health.Check{Name: "requests-file", Func: health.Writable(*requestsFilePath), Services: []string{requestsRPC}},
+		health.Check{Name: "news-file", Func: health.Writable(*newsFilePath), Services: []string{newsRPC}},
+		health.Check{Name: "preferences-file", Func: health.Writable(*preferencesFilePath), Services: []string{notificationsRPC}},
+		health.Check{Name: "dead-letters-file", Func: health.Writable(*deadLettersFilePath), Services: []string{notificationsRPC}},
+		health.Check{Name: "webhooks-file", Func: health.Writable(*webhooksFilePath), Services: []string{webhooksRPC}},
+		// every change is recorded in the outbox
+		health.Check{Name: "outbox-file", Func: health.Writable(*outboxFilePath), Services: []string{usersRPC, requestsRPC, newsRPC, webhooksRPC}},
+		// and in the audit log
+		health.Check{Name: "audit-log-file", Func: health.Writable(*auditLogFilePath), Services: []string{usersRPC, requestsRPC, newsRPC, auditRPC}},
+		health.Check{Name: "notifier-cursor-file", Func: health.Writable(*notifierCursorFilePath), Services: []string{notificationsRPC}},
+		health.Check{Name: "notifier", Func: notifierLoop.Check, Services: []string{notificationsRPC}, Live: true},
+		health.Check{Name: "webhook-dispatcher", Func: dispatcherLoop.Check, Services: []string{webhooksRPC}, Live: true},
+	)
+	go checker.Run(bgCtx, *healthCheckInterval)
+
+	cfg := serverConfig{
+		addr:            *addr,
+		httpAddr:        *httpAddr,
+		grpcWebAddr:     *grpcWebAddr,
+		adminAddr:       *adminAddr,
+		adminGRPCAddr:   *adminGRPCAddr,
+		shutdownTimeout: *shutdownTimeout,
+		allowedOrigins:  origins(*allowedOrigins),
+		grpcTLS:         grpcTLS,
+		httpTLS:         httpTLS,
+		policyVersion:   *privacyPolicyVersion,
+	}
+	deps := &serverDeps{
+		checker:           checker,
+		metrics:           m,
+		tracer:            tracer,
+		users:             userData,
+		requests:          requestData,
+		news:              newsData,
+		preferences:       preferenceData,
+		deadLetters:       deadLetterData,
+		webhooks:          webhookData,
+		outbox:            outbox,
+		webhookDispatcher: webhookDispatcher,
+		guard:             guard,
+		backups:           backups,
+		auditLog:          auditLog,
+	}
+	code := 0
+	if err := startService(ctx, logger, cfg, deps); err != nil {
+		logger.Error(err)
+		code = 1
+	}
+
+	cancel()
+	wg.Wait()
+	for _, st := range []interface{ Sync() error }{
 		userData,
 		requestData,
 		newsData,
-	); err != nil {
-		logger.Error(err)
-		os.Exit(1)
+		preferenceData,
+		deadLetterData,
+		webhookData,
+		outbox,
+		auditLog,
+	} {
+		if err := st.Sync(); err != nil {
+			logger.WithError(err).Error("problem syncing data")
+			code = 1
+		}
 	}
+	logger.Info("stopped")
+	return code
 }
@@ -0,0 +1,548 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/config"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var dataFiles = []string{"users", "requests", "news", "preferences", "dead-letters", "webhooks", "outbox"}
+
+// start runs the service on a free port with empty data files in dir.
+func start(ctx context.Context, t *testing.T, dir string, extraArgs ...string) (*grpc.ClientConn, <-chan int) {
+	return startWith(ctx, t, append(dataArgs(t, dir), extraArgs...)...)
+}
+
+// dataArgs writes empty data files in dir and returns the flags giving them.
+func dataArgs(t *testing.T, dir string) []string {
+	var args []string
+	for _, f := range dataFiles {
+		path := filepath.Join(dir, f+".json")
+		if err := ioutil.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, "--"+f+"-file", path)
+	}
+	return append(args,
+		"--notifier-cursor-file", filepath.Join(dir, "notifier_cursor"),
+		"--audit-log-file", filepath.Join(dir, "audit.jsonl"))
+}
+
+// freeAddr returns a local address nothing listens on.
+func freeAddr(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+// startWith runs the service on a free port with the arguments.
+func startWith(ctx context.Context, t *testing.T, extraArgs ...string) (*grpc.ClientConn, <-chan int) {
+	addr := freeAddr(t)
+	args := []string{"--addr", addr, "--http-addr", "", "--grpc-web-addr", "", "--admin-addr", "", "--admin-grpc-addr", ""}
+	exited := make(chan int, 1)
+	go func() {
+		exited <- run(ctx, append(args, extraArgs...))
+	}()
+
+	dialCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(dialCtx, addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn, exited
+}
+
+func wait(t *testing.T, exited <-chan int, timeout time.Duration) {
+	select {
+	case code := <-exited:
+		if code != 0 {
+			t.Errorf("unexpected exit code %d", code)
+		}
+	case <-time.After(timeout):
+		t.Fatal("service didn't stop")
+	}
+}
+
+func TestShutdownKeepsData(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, exited := start(ctx, t, dir)
+	defer conn.Close()
+	client := userspb.NewUsersRPCClient(conn)
+
+	var (
+		mu    sync.Mutex
+		added []string
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; ; j++ {
+				resp, err := client.AddUser(context.Background(), &userspb.AddUserRequest{
+					User: &userspb.User{Name: fmt.Sprintf("user %d-%d", i, j)},
+				})
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				added = append(added, resp.UserId)
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	// shut down while the users are being added
+	for {
+		mu.Lock()
+		n := len(added)
+		mu.Unlock()
+		if n >= 200 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	wait(t, exited, 10*time.Second)
+	wg.Wait()
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "users.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := make(map[string]*userspb.User)
+	if err := storage.Decode(b, &users); err != nil {
+		t.Fatalf("users file is corrupted: %s", err)
+	}
+	for _, id := range added {
+		if _, ok := users[id]; !ok {
+			t.Errorf("user %s was added but not saved", id)
+		}
+	}
+
+	b, err = ioutil.ReadFile(filepath.Join(dir, "outbox.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := make(map[string]*webhookspb.Event)
+	// the events are pruned as there's no webhook
+	if err := storage.Decode(b, &events); err != nil {
+		t.Fatalf("outbox file is corrupted: %s", err)
+	}
+}
+
+func TestShutdownEndsStreams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, exited := start(ctx, t, dir, "--shutdown-timeout", "1m")
+	defer conn.Close()
+
+	stream, err := requestspb.NewRequestsRPCClient(conn).WatchRequests(context.Background(), &requestspb.WatchRequestsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// make sure the stream is being served before shutting down, the requests added before the
+	// stream subscribed to the changes are missed
+	received := make(chan struct{})
+	go func() {
+		for {
+			_, _ = requestspb.NewRequestsRPCClient(conn).AddRequest(context.Background(), &requestspb.AddRequestRequest{
+				Request: &requestspb.Request{Title: "help with groceries"},
+			})
+			select {
+			case <-received:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+	_, err = stream.Recv()
+	close(received)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	wait(t, exited, 10*time.Second)
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected the stream to end with %s, got %v", codes.Unavailable, err)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, exited := start(ctx, t, dir, "--health-check-interval", "10ms")
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Status
+	}
+	for _, svc := range []string{"", "userspb.UsersRPC", "requestspb.RequestsRPC", "newspb.NewsRPC"} {
+		if got := check(svc); got != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("expected %q to be serving, got %s", svc, got)
+		}
+	}
+
+	if err := os.Remove(filepath.Join(dir, "news.json")); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for check("newspb.NewsRPC") != healthpb.HealthCheckResponse_NOT_SERVING {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the news service to stop serving")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := check("userspb.UsersRPC"); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("expected the users service to be serving, got %s", got)
+	}
+
+	cancel()
+	wait(t, exited, 10*time.Second)
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(configFile, []byte("log-level: info\naddr: 127.0.0.1:65010\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	flags := flag.NewFlagSet("backend", flag.ContinueOnError)
+	flags.String("config", "", "")
+	flags.Bool("debug", false, "")
+	flags.String("log-level", "info", "")
+	flags.String("addr", "127.0.0.1:65010", "")
+	for _, name := range dataFileFlags {
+		flags.String(name, filepath.Join(dir, name), "")
+	}
+	loader := config.New(flags, "config")
+	if err := loader.Load([]string{"--config", configFile}); err != nil {
+		t.Fatal(err)
+	}
+	settings := flagValues(flags)
+	logger := getLogger(logrus.InfoLevel)
+
+	if err := ioutil.WriteFile(configFile, []byte("log-level: warn\naddr: 127.0.0.1:65020\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := reload(logger, loader, settings); err != nil {
+		t.Fatal(err)
+	}
+	if level := logger.Logger.GetLevel(); level != logrus.WarnLevel {
+		t.Errorf("expected the log level to be reloaded, got %s", level)
+	}
+	// the address requires a restart
+	if settings["log-level"] != "warn" || settings["addr"] != "127.0.0.1:65010" {
+		t.Errorf("unexpected settings after reload: %v", settings)
+	}
+}
+
+func TestDataDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dataDir := filepath.Join(dir, "data")
+	// an empty file is read as no data, the directory has the files of the first schema version
+	if err := os.MkdirAll(dataDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dataDir, "news.json"), nil, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if code := run(ctx, []string{"--data-dir", dataDir}); code != 1 {
+		t.Errorf("expected the service to refuse the unmigrated directory with 1, got %d", code)
+	}
+	conn, exited := startWith(ctx, t, "--data-dir", dataDir, "--migrate")
+	defer conn.Close()
+
+	resp, err := userspb.NewUsersRPCClient(conn).AddUser(context.Background(), &userspb.AddUserRequest{
+		User: &userspb.User{Name: "Ada"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"users.json", "requests.json", "news.json", "preferences.json", "dead_letters.json", "webhooks.json", "outbox.json"} {
+		if _, err := os.Stat(filepath.Join(dataDir, name)); err != nil {
+			t.Errorf("expected %s to be created: %v", name, err)
+		}
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dataDir, "schema_version"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "2\n" {
+		t.Errorf("unexpected schema version %q", b)
+	}
+
+	// a second instance can't use the directory
+	if code := run(context.Background(), []string{"--addr", "127.0.0.1:0", "--http-addr", "", "--grpc-web-addr", "", "--admin-addr", "", "--admin-grpc-addr", "", "--data-dir", dataDir}); code != 1 {
+		t.Errorf("expected the second instance to exit with 1, got %d", code)
+	}
+
+	cancel()
+	wait(t, exited, 10*time.Second)
+
+	var users map[string]*userspb.User
+	b, err = ioutil.ReadFile(filepath.Join(dataDir, "users.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Decode(b, &users); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := users[resp.UserId]; !ok {
+		t.Errorf("expected user %s to be stored, got %v", resp.UserId, users)
+	}
+}
+
+// writeCert writes a certificate for 127.0.0.1 and its key in dir, signed by the CA when given.
+func writeCert(t *testing.T, dir, name string, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if ca == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		ca, caKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func(ext string, block *pem.Block) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name+ext), pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".crt", &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	write(".key", &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestRequiredClientCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca, caKey := writeCert(t, dir, "ca", nil, nil)
+	writeCert(t, dir, "server", ca, caKey)
+	writeCert(t, dir, "client", ca, caKey)
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	httpAddr := freeAddr(t)
+	args := append(dataArgs(t, dir),
+		"--addr", freeAddr(t),
+		"--http-addr", httpAddr,
+		"--grpc-web-addr", "",
+		"--admin-addr", "",
+		"--admin-grpc-addr", "",
+		"--tls-cert", filepath.Join(dir, "server.crt"),
+		"--tls-key", filepath.Join(dir, "server.key"),
+		"--tls-client-ca", filepath.Join(dir, "ca.crt"),
+		"--tls-client-auth", "require",
+	)
+	exited := make(chan int, 1)
+	go func() {
+		exited <- run(ctx, args)
+	}()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	get := func(certs ...tls.Certificate) error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, Certificates: certs}}}
+		resp, err := client.Get("https://" + httpAddr + "/v1/users/missing")
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for err := get(clientCert); err != nil; err = get(clientCert) {
+		if time.Now().After(deadline) {
+			t.Fatalf("gateway not reachable with a client certificate: %s", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err := get(); err == nil {
+		t.Error("expected the gateway to reject the calls without client certificate")
+	}
+
+	cancel()
+	wait(t, exited, 10*time.Second)
+}
+
+func TestAdminEndpoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	httpAddr, adminAddr := freeAddr(t), freeAddr(t)
+	conn, exited := start(ctx, t, dir, "--http-addr", httpAddr, "--admin-addr", adminAddr)
+	defer conn.Close()
+
+	get := func(url string) int {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			resp, err := http.Get(url)
+			if err == nil {
+				resp.Body.Close()
+				return resp.StatusCode
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("%s not reachable: %s", url, err)
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+	for _, path := range []string{"/healthz", "/readyz", "/metrics"} {
+		if code := get("http://" + adminAddr + path); code != http.StatusOK {
+			t.Errorf("expected %s to answer 200 on the admin listener, got %d", path, code)
+		}
+		// the gateway only serves the API
+		if code := get("http://" + httpAddr + path); code != http.StatusNotFound {
+			t.Errorf("expected %s to be missing from the gateway, got %d", path, code)
+		}
+	}
+
+	cancel()
+	wait(t, exited, 10*time.Second)
+}
+
+func TestAdminMethods(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	adminAddr := freeAddr(t)
+	conn, exited := start(ctx, t, dir, "--admin-grpc-addr", adminAddr)
+	defer conn.Close()
+
+	users := userspb.NewUsersRPCClient(conn)
+	added, err := users.AddUser(context.Background(), &userspb.AddUserRequest{User: &userspb.User{Name: "Ada"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = users.ExportMyData(context.Background(), &userspb.ExportMyDataRequest{UserId: added.UserId})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected the export to be refused on the public listener, got %v", err)
+	}
+	_, err = users.EraseUser(context.Background(), &userspb.EraseUserRequest{UserId: added.UserId})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected the erasure to be refused on the public listener, got %v", err)
+	}
+
+	dialCtx, dialCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer dialCancel()
+	adminConn, err := grpc.DialContext(dialCtx, adminAddr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminConn.Close()
+	admin := userspb.NewUsersRPCClient(adminConn)
+	if _, err := admin.ExportMyData(context.Background(), &userspb.ExportMyDataRequest{UserId: added.UserId}); err != nil {
+		t.Errorf("expected the export to be served on the admin listener, got %v", err)
+	}
+	if _, err := admin.EraseUser(context.Background(), &userspb.EraseUserRequest{UserId: added.UserId}); err != nil {
+		t.Errorf("expected the erasure to be served on the admin listener, got %v", err)
+	}
+
+	cancel()
+	wait(t, exited, 10*time.Second)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+)
+
+func runMigrate(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dataDir := flags.String("data-dir", "", "Directory of the data files")
+	dryRun := flags.Bool("dry-run", false, "Print the migrations without applying them")
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	if *dataDir == "" {
+		fmt.Println("the schema version is recorded in the data directory, --data-dir is required")
+		return 2
+	}
+
+	dir, err := datadir.Open(*dataDir)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer dir.Close()
+
+	pending, err := dir.Pending(storage.Migrations)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	if len(pending) == 0 {
+		fmt.Printf("schema version %d is up to date\n", datadir.SchemaVersion)
+		return 0
+	}
+	for _, m := range pending {
+		fmt.Printf("version %d: %s\n", m.Version, m.Description)
+	}
+	if *dryRun {
+		return 0
+	}
+	applied, err := dir.Migrate(storage.Migrations)
+	if err != nil {
+		fmt.Printf("%s, %d migrations applied\n", err, len(applied))
+		return 1
+	}
+	fmt.Printf("migrated to schema version %d\n", applied[len(applied)-1].Version)
+	return 0
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/euvsvirus-banan/backend/internal/gateway"
+	"github.com/euvsvirus-banan/backend/internal/openapi"
+)
+
+func main() {
+	output := flag.String("o", "api/openapi.json", "File to write the OpenAPI document to, - for stdout")
+
+	flag.Parse()
+
+	b, err := openapi.Generate(gateway.New(nil).Routes())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if *output == "-" {
+		_, _ = os.Stdout.Write(b)
+		return
+	}
+	if err := ioutil.WriteFile(*output, b, 0644); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/retention"
+	"github.com/sirupsen/logrus"
+)
+
+const retentionRulesUsage = "Comma separated retention rules like requests.CANCELLED=purge:30d, nothing is purged if empty"
+
+func runPurge(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("purge", flag.ContinueOnError)
+	paths := addDataFlags(flags)
+	rulesFlag := flags.String("retention-rules", "", retentionRulesUsage)
+	dryRun := flags.Bool("dry-run", false, "Print the records the rules apply to without changing them")
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	rules, err := retention.ParseRules(*rulesFlag)
+	if err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	if len(rules) == 0 {
+		fmt.Println("no retention rule given, --retention-rules is required")
+		return 2
+	}
+	if !*dryRun && *paths.dir == "" {
+		fmt.Println(errUnlocked)
+		return 2
+	}
+
+	d, err := openData(flags, paths)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer d.Close()
+
+	logger := getLogger(logrus.WarnLevel)
+	items, err := retention.New(logger, rules, d.requests, d.news, nil).Apply(ctx, time.Now(), *dryRun)
+	for _, item := range items {
+		fmt.Printf("%s %s %s %s\n", item.Action, item.Collection, item.ID, item.State)
+	}
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	verb := "changed"
+	if *dryRun {
+		verb = "would be changed"
+	}
+	fmt.Printf("%d records %s\n", len(items), verb)
+	return 0
+}
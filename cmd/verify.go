@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/euvsvirus-banan/backend/internal/audit"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+// reference is a user id in a request.
+type reference struct {
+	field string
+	id    string
+}
+
+// requestReferences returns the user ids of the request, the pseudonyms of the erased users aren't
+// references.
+func requestReferences(r *requestspb.Request) []reference {
+	refs := []reference{{field: "requester_id", id: r.RequesterId}}
+	if r.VolunteerId != "" {
+		refs = append(refs, reference{field: "volunteer_id", id: r.VolunteerId})
+	}
+	for i, a := range r.Answers {
+		refs = append(refs, reference{field: fmt.Sprintf("answers[%d].volunteer_id", i), id: a.VolunteerId})
+	}
+	for i, rating := range r.Ratings {
+		refs = append(refs,
+			reference{field: fmt.Sprintf("ratings[%d].rater_id", i), id: rating.RaterId},
+			reference{field: fmt.Sprintf("ratings[%d].ratee_id", i), id: rating.RateeId},
+		)
+	}
+	kept := refs[:0]
+	for _, ref := range refs {
+		if !storage.IsPseudonym(ref.id) {
+			kept = append(kept, ref)
+		}
+	}
+	return kept
+}
+
+// danglingReferences returns the user ids of the requests that aren't users.
+func danglingReferences(users map[string]*userspb.User, requests map[string]*requestspb.Request) []string {
+	var problems []string
+	for _, id := range requestIDs(requests) {
+		for _, ref := range requestReferences(requests[id]) {
+			if _, ok := users[ref.id]; !ok {
+				problems = append(problems, fmt.Sprintf("request %s: %s %q isn't a user", id, ref.field, ref.id))
+			}
+		}
+	}
+	return problems
+}
+
+func runVerify(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	paths := addDataFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Println(err)
+		}
+		return 2
+	}
+	// the files are read by openData, it fails if they aren't valid
+	d, err := openData(flags, paths)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer d.Close()
+
+	users, requests := d.users.All(), d.requests.All()
+	problems := danglingReferences(users, requests)
+	entries, err := audit.Verify(*paths.auditLog, d.keys)
+	if err != nil && !os.IsNotExist(err) {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		fmt.Printf("%d problems found:\n  %s\n", len(problems), strings.Join(problems, "\n  "))
+		return 1
+	}
+	fmt.Printf("%d users, %d requests, %d news and %d audit entries verified\n", len(users), len(requests), len(d.news.All()), entries)
+	return 0
+}
@@ -3,10 +3,18 @@ module github.com/euvsvirus-banan/backend
 go 1.14
 
 require (
+	github.com/desertbit/timer v0.0.0-20180107155436-c41aec40b27f // indirect
 	github.com/golang/protobuf v1.3.3
 	github.com/google/go-cmp v0.4.0
 	github.com/google/uuid v1.1.1
+	github.com/gorilla/websocket v1.4.2 // indirect
 	github.com/grpc-ecosystem/go-grpc-middleware v1.2.0
+	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
+	github.com/improbable-eng/grpc-web v0.13.0
+	github.com/prometheus/client_golang v1.5.1
+	github.com/rs/cors v1.7.0 // indirect
 	github.com/sirupsen/logrus v1.5.0
+	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55
 	google.golang.org/grpc v1.29.1
+	gopkg.in/yaml.v2 v2.2.5
 )
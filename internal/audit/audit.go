@@ -0,0 +1,521 @@
+// Package audit keeps an append-only log of the calls changing the users, requests and news. Each
+// entry holds the hash of the previous one, so an entry changed or removed breaks the chain, and the
+// head of the log, its last entry, is kept in another file so the entries removed from its end are
+// noticed. With keys the hashes and the head are HMACs, they can't be computed again by someone
+// changing the files.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+)
+
+// ErrBroken is returned when the chain of the entries doesn't hold, an entry was changed, removed
+// or added out of the log.
+var ErrBroken = errors.New("audit log chain is broken")
+
+// Log is the audit log, a file with an entry per line, encrypted and authenticated when it has keys.
+type Log struct {
+	path string
+	keys *crypt.Keyring
+
+	mu       sync.Mutex
+	file     *os.File
+	sequence uint64
+	last     string
+}
+
+// Open opens the log after verifying its chain and head, it's created with its directory if missing.
+// The entries appended are encrypted and authenticated with the primary key when keys isn't nil, the
+// log then can't hold entries that aren't.
+func Open(path string, keys *crypt.Keyring) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("problem creating directory of %s: %w", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return nil, err
+	}
+	last, err := read(f, keys, func(*auditpb.Entry) error { return nil })
+	if err == nil {
+		err = checkHead(path, keys, last)
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	l := &Log{path: path, keys: keys, file: f}
+	if last != nil {
+		l.sequence, l.last = last.Sequence, last.Hash
+	}
+	return l, nil
+}
+
+// Append chains the entry to the last one and writes it with the head of the log, its sequence,
+// hashes and key are set.
+func (l *Log) Append(e *auditpb.Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e.Sequence = l.sequence + 1
+	e.PreviousHash = l.last
+	e.Hash = ""
+	e.KeyId = ""
+	if l.keys != nil {
+		e.KeyId = l.keys.Primary()
+	}
+	hash, err := hashOf(e, l.keys)
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+	line, err := l.encode(e)
+	if err != nil {
+		return err
+	}
+	// a single write, the line isn't mixed with another one
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("problem writing audit entry: %w", err)
+	}
+	l.sequence, l.last = e.Sequence, e.Hash
+	if err := writeHead(l.path, l.keys, e); err != nil {
+		return fmt.Errorf("problem writing head of the audit log: %w", err)
+	}
+	return nil
+}
+
+// Query returns the entries matching the filter, the oldest first. The whole chain is verified,
+// ErrBroken is returned when it doesn't hold or doesn't end with the last entry appended.
+func (l *Log) Query(filter Filter) ([]*auditpb.Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []*auditpb.Entry
+	last, err := read(f, l.keys, func(e *auditpb.Entry) error {
+		if !filter.match(e) {
+			return nil
+		}
+		entries = append(entries, e)
+		if filter.Limit > 0 && len(entries) > filter.Limit {
+			entries = entries[1:]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if last == nil && l.sequence > 0 || last != nil && (last.Sequence != l.sequence || last.Hash != l.last) {
+		return nil, fmt.Errorf("%w: the log ends before entry %d", ErrBroken, l.sequence)
+	}
+	if err := checkHead(l.path, l.keys, last); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ErasedUsers returns the ids of the users erased by EraseUser, the oldest erasure first. The log
+// isn't part of the backups, the users erased after a backup was taken can be erased again once
+// it's restored.
+func (l *Log) ErasedUsers() ([]string, error) {
+	entries, err := l.Query(Filter{EntityType: User})
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, e := range entries {
+		if e.Method == eraseMethod && e.Code == codes.OK.String() {
+			ids = append(ids, e.EntityId)
+		}
+	}
+	return ids, nil
+}
+
+// Sync commits the entries written to the disk.
+func (l *Log) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Sync()
+}
+
+// Close closes the file of the log.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// Verify reads the log of the path and returns its number of entries, it fails with ErrBroken
+// when the chain doesn't hold or doesn't end with the head of the log. A missing log is empty.
+func Verify(path string, keys *crypt.Keyring) (int, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, checkHead(path, keys, nil)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	last, err := read(f, keys, func(*auditpb.Entry) error { return nil })
+	if err != nil {
+		return 0, err
+	}
+	if err := checkHead(path, keys, last); err != nil {
+		return 0, err
+	}
+	if last == nil {
+		return 0, nil
+	}
+	return int(last.Sequence), nil
+}
+
+// Rekey rewrites the log of the path with its entries encrypted and authenticated with the primary
+// key, after verifying its chain like Verify. The entries are chained again with the new hashes, and
+// the log is replaced before its head: a crash in between is reported like a crash between writing
+// an entry and the head. It returns the number of entries, a missing log is left missing.
+func Rekey(path string, keys *crypt.Keyring) (int, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, checkHead(path, keys, nil)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	var entries []*auditpb.Entry
+	last, err := read(f, keys, func(e *auditpb.Entry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := checkHead(path, keys, last); err != nil {
+		return 0, err
+	}
+	if last == nil {
+		return 0, nil
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	l := &Log{path: path, keys: keys}
+	var b bytes.Buffer
+	previous := ""
+	for _, e := range entries {
+		e.PreviousHash = previous
+		e.Hash = ""
+		e.KeyId = keys.Primary()
+		if e.Hash, err = hashOf(e, keys); err != nil {
+			return 0, err
+		}
+		line, err := l.encode(e)
+		if err != nil {
+			return 0, err
+		}
+		b.Write(append(line, '\n'))
+		previous = e.Hash
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b.Bytes(), info.Mode().Perm()); err != nil {
+		return 0, fmt.Errorf("problem writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return 0, fmt.Errorf("problem replacing %s: %w", path, err)
+	}
+	if err := writeHead(path, keys, entries[len(entries)-1]); err != nil {
+		return 0, fmt.Errorf("problem writing head of the audit log: %w", err)
+	}
+	return len(entries), nil
+}
+
+// KeyIDs returns the ids of the keys the entries and the head of the log of the path are encrypted
+// and authenticated with, sorted. The entries aren't decrypted, no key is needed.
+func KeyIDs(path string) ([]string, error) {
+	ids := make(map[string]bool)
+	b, err := ioutil.ReadFile(path + ".head")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		h := &head{}
+		if err := json.Unmarshal(b, h); err != nil {
+			return nil, fmt.Errorf("problem reading head of the audit log: %w", err)
+		}
+		ids[h.KeyID] = true
+	}
+	f, err := os.Open(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, 1<<24)
+		for scanner.Scan() {
+			var sealed crypt.Sealed
+			if err := json.Unmarshal(scanner.Bytes(), &sealed); err != nil {
+				return nil, fmt.Errorf("problem reading audit entry: %w", err)
+			}
+			ids[sealed.KeyID] = true
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	delete(ids, "")
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// Filter selects the entries of a query, the empty fields match every entry.
+type Filter struct {
+	EntityType string
+	EntityID   string
+	Actor      string
+	// Since is inclusive and Until exclusive.
+	Since time.Time
+	Until time.Time
+	// Limit keeps the latest entries matching, all are kept if 0.
+	Limit int
+}
+
+func (f Filter) match(e *auditpb.Entry) bool {
+	if f.EntityType != "" && e.EntityType != f.EntityType {
+		return false
+	}
+	if f.EntityID != "" && e.EntityId != f.EntityID {
+		return false
+	}
+	if f.Actor != "" && e.Actor != f.Actor {
+		return false
+	}
+	if f.Since.IsZero() && f.Until.IsZero() {
+		return true
+	}
+	date, err := time.Parse(time.RFC3339Nano, e.Date)
+	if err != nil {
+		return false
+	}
+	return !date.Before(f.Since) && (f.Until.IsZero() || date.Before(f.Until))
+}
+
+// read calls fn with each entry of the log after checking it's chained to the previous one, and
+// returns the last entry, nil if the log is empty.
+func read(r io.Reader, keys *crypt.Keyring, fn func(*auditpb.Entry) error) (*auditpb.Entry, error) {
+	br := bufio.NewReader(r)
+	var last *auditpb.Entry
+	for {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			return last, nil
+		}
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: entry %d is incomplete", ErrBroken, next(last))
+		}
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(bytes.TrimSpace(line), keys)
+		if err != nil {
+			return nil, fmt.Errorf("problem reading audit entry %d: %w", next(last), err)
+		}
+		if err := chained(last, e, keys); err != nil {
+			return nil, err
+		}
+		if err := fn(e); err != nil {
+			return nil, err
+		}
+		last = e
+	}
+}
+
+func next(last *auditpb.Entry) uint64 {
+	if last == nil {
+		return 1
+	}
+	return last.Sequence + 1
+}
+
+// chained checks that the entry follows the previous one, nil for the first entry. With keys, the
+// entry has to be authenticated: one written without them could have been written by anyone.
+func chained(previous, e *auditpb.Entry, keys *crypt.Keyring) error {
+	if keys != nil && e.KeyId == "" {
+		return fmt.Errorf("%w: entry %d isn't authenticated", ErrBroken, e.Sequence)
+	}
+	hash, err := hashOf(e, keys)
+	if err != nil {
+		return fmt.Errorf("problem hashing audit entry %d: %w", e.Sequence, err)
+	}
+	switch {
+	case e.Sequence != next(previous):
+		return fmt.Errorf("%w: entry %d follows entry %d", ErrBroken, e.Sequence, next(previous)-1)
+	case previous != nil && e.PreviousHash != previous.Hash, previous == nil && e.PreviousHash != "":
+		return fmt.Errorf("%w: entry %d isn't chained to the previous entry", ErrBroken, e.Sequence)
+	case !hmac.Equal([]byte(e.Hash), []byte(hash)):
+		return fmt.Errorf("%w: entry %d doesn't match its hash", ErrBroken, e.Sequence)
+	}
+	return nil
+}
+
+// hashOf returns the hex SHA-256 of the entry without its hash, or its HMAC-SHA256 with the key of
+// the entry when it has one.
+func hashOf(e *auditpb.Entry, keys *crypt.Keyring) (string, error) {
+	c := proto.Clone(e).(*auditpb.Entry)
+	c.Hash = ""
+	b, err := proto.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	if e.KeyId == "" {
+		sum := sha256.Sum256(b)
+		return hex.EncodeToString(sum[:]), nil
+	}
+	if keys == nil {
+		return "", errors.New("the entry is authenticated and no encryption key is given")
+	}
+	mac, err := keys.MAC(e.KeyId, b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mac), nil
+}
+
+// head is the last entry of the log, written next to it in path.head.
+type head struct {
+	Sequence uint64 `json:"sequence"`
+	Hash     string `json:"hash"`
+	// KeyID is the id of the key of the MAC, empty when the log has no keys.
+	KeyID string `json:"keyId,omitempty"`
+	MAC   []byte `json:"mac,omitempty"`
+}
+
+func (h *head) data() []byte {
+	return []byte(fmt.Sprintf("%d:%s", h.Sequence, h.Hash))
+}
+
+// writeHead replaces the head of the log with the entry.
+func writeHead(path string, keys *crypt.Keyring, last *auditpb.Entry) error {
+	h := &head{Sequence: last.Sequence, Hash: last.Hash}
+	if keys != nil {
+		h.KeyID = keys.Primary()
+		var err error
+		if h.MAC, err = keys.MAC(h.KeyID, h.data()); err != nil {
+			return err
+		}
+	}
+	b, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".head.tmp"
+	if err := ioutil.WriteFile(tmp, append(b, '\n'), 0640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path+".head")
+}
+
+// checkHead checks that the log ends with the entry of its head, last is nil for an empty log.
+func checkHead(path string, keys *crypt.Keyring, last *auditpb.Entry) error {
+	b, err := ioutil.ReadFile(path + ".head")
+	if os.IsNotExist(err) {
+		if last != nil {
+			return fmt.Errorf("%w: the head of the log is missing", ErrBroken)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("problem reading head of the audit log: %w", err)
+	}
+	h := &head{}
+	if err := json.Unmarshal(b, h); err != nil {
+		return fmt.Errorf("problem reading head of the audit log: %w", err)
+	}
+	switch {
+	case keys != nil && h.KeyID == "":
+		return fmt.Errorf("%w: the head of the log isn't authenticated", ErrBroken)
+	case keys == nil && h.KeyID != "":
+		return errors.New("the head of the audit log is authenticated and no encryption key is given")
+	case keys != nil:
+		mac, err := keys.MAC(h.KeyID, h.data())
+		if err != nil {
+			return fmt.Errorf("problem reading head of the audit log: %w", err)
+		}
+		if !hmac.Equal(mac, h.MAC) {
+			return fmt.Errorf("%w: the head of the log doesn't match its MAC", ErrBroken)
+		}
+	}
+	var sequence uint64
+	var hash string
+	if last != nil {
+		sequence, hash = last.Sequence, last.Hash
+	}
+	if h.Sequence != sequence || h.Hash != hash {
+		return fmt.Errorf("%w: the log ends with entry %d instead of entry %d", ErrBroken, sequence, h.Sequence)
+	}
+	return nil
+}
+
+func (l *Log) encode(e *auditpb.Entry) ([]byte, error) {
+	s, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(e)
+	if err != nil {
+		return nil, err
+	}
+	if l.keys == nil {
+		return []byte(s), nil
+	}
+	sealed, err := l.keys.Seal([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(sealed)
+}
+
+func decode(line []byte, keys *crypt.Keyring) (*auditpb.Entry, error) {
+	var sealed crypt.Sealed
+	if err := json.Unmarshal(line, &sealed); err != nil {
+		return nil, err
+	}
+	if sealed.KeyID != "" {
+		if keys == nil {
+			return nil, errors.New("the entry is encrypted and no encryption key is given")
+		}
+		var err error
+		if line, err = keys.Open(&sealed); err != nil {
+			return nil, err
+		}
+	}
+	e := &auditpb.Entry{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(line), e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
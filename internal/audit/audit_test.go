@@ -0,0 +1,606 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// tempLog opens a log in a new directory, removed with the log by the function returned.
+func tempLog(t *testing.T, keys *crypt.Keyring) (*Log, string, func()) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "logs", "audit.jsonl")
+	l, err := Open(path, keys)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return l, path, func() {
+		l.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func appendEntries(t *testing.T, l *Log) {
+	for _, e := range []*auditpb.Entry{
+		{Date: "2026-10-01T10:00:00Z", Actor: "ops", EntityType: User, EntityId: "Blue", Method: "/userspb.UsersRPC/AddUser"},
+		{Date: "2026-10-02T10:00:00Z", Actor: "ops", EntityType: Request, EntityId: "r1", Method: "/requestspb.RequestsRPC/AddRequest"},
+		{Date: "2026-10-03T10:00:00Z", Actor: "support", EntityType: Request, EntityId: "r1", Method: "/requestspb.RequestsRPC/DeleteRequest"},
+	} {
+		if err := l.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func sequences(entries []*auditpb.Entry) []uint64 {
+	s := make([]uint64, len(entries))
+	for i, e := range entries {
+		s[i] = e.Sequence
+	}
+	return s
+}
+
+func TestQuery(t *testing.T) {
+	l, path, cleanup := tempLog(t, nil)
+	defer cleanup()
+	appendEntries(t, l)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []uint64
+	}{
+		{name: "all", want: []uint64{1, 2, 3}},
+		{name: "entity", filter: Filter{EntityType: Request, EntityID: "r1"}, want: []uint64{2, 3}},
+		{name: "actor", filter: Filter{Actor: "support"}, want: []uint64{3}},
+		{
+			name: "time range",
+			filter: Filter{
+				Since: time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC),
+				Until: time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC),
+			},
+			want: []uint64{2},
+		},
+		{name: "limit", filter: Filter{Actor: "ops", Limit: 1}, want: []uint64{2}},
+		{name: "none", filter: Filter{EntityID: "r2"}, want: []uint64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.Query(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, sequences(got)); diff != "" {
+				t.Errorf("Query() differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// the chain goes on after reopening the log
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	l, err := Open(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	e := &auditpb.Entry{Date: "2026-10-04T10:00:00Z", Method: "/newspb.NewsRPC/AddNew"}
+	if err := l.Append(e); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := l.Query(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Sequence != 4 || e.PreviousHash != entries[2].Hash {
+		t.Errorf("expected entry 4 chained to entry 3, got %d after %s", e.Sequence, e.PreviousHash)
+	}
+	if n, err := Verify(path, nil); n != 4 || err != nil {
+		t.Errorf("Verify() = %d, %v", n, err)
+	}
+}
+
+func TestTampered(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+		want   string
+	}{
+		{
+			name: "changed",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"ops"`, `"someone"`, 1)
+				return lines
+			},
+			want: "entry 2 doesn't match its hash",
+		},
+		{
+			name: "removed",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+			want: "entry 3 follows entry 1",
+		},
+		{
+			name: "incomplete",
+			tamper: func(lines []string) []string {
+				lines[2] = lines[2][:10]
+				return lines
+			},
+			want: "entry 3 is incomplete",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, path, cleanup := tempLog(t, nil)
+			defer cleanup()
+			appendEntries(t, l)
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := tt.tamper(strings.SplitAfter(string(b), "\n"))
+			if err := ioutil.WriteFile(path, []byte(strings.Join(lines, "")), 0640); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := l.Query(Filter{}); !errors.Is(err, ErrBroken) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Query() error = %v, want %q", err, tt.want)
+			}
+			if _, err := Verify(path, nil); !errors.Is(err, ErrBroken) {
+				t.Errorf("Verify() error = %v, want ErrBroken", err)
+			}
+			if _, err := Open(path, nil); !errors.Is(err, ErrBroken) {
+				t.Errorf("Open() error = %v, want ErrBroken", err)
+			}
+		})
+	}
+
+	// the entries removed from the end are noticed with the head of the log, after a restart too
+	l, path, cleanup := tempLog(t, nil)
+	defer cleanup()
+	appendEntries(t, l)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, b[:bytes.IndexByte(b, '\n')+1], 0640); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Query(Filter{}); !errors.Is(err, ErrBroken) {
+		t.Errorf("Query() error = %v, want ErrBroken", err)
+	}
+	want := "the log ends with entry 1 instead of entry 3"
+	if _, err := Verify(path, nil); !errors.Is(err, ErrBroken) || !strings.Contains(err.Error(), want) {
+		t.Errorf("Verify() error = %v, want %q", err, want)
+	}
+	if _, err := Open(path, nil); !errors.Is(err, ErrBroken) || !strings.Contains(err.Error(), want) {
+		t.Errorf("Open() error = %v, want %q", err, want)
+	}
+	if err := os.Remove(path + ".head"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(path, nil); !errors.Is(err, ErrBroken) || !strings.Contains(err.Error(), "head of the log is missing") {
+		t.Errorf("Verify() error = %v, want the head missing", err)
+	}
+}
+
+func TestEncrypted(t *testing.T) {
+	keys, err := crypt.ParseKeys("k1:" + strings.Repeat("A", 43) + "=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, path, cleanup := tempLog(t, keys)
+	defer cleanup()
+	appendEntries(t, l)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte("Blue")) || !bytes.Contains(b, []byte(`"keyId":"k1"`)) {
+		t.Errorf("expected the entries to be encrypted, got %s", b)
+	}
+	if n, err := Verify(path, keys); n != 3 || err != nil {
+		t.Errorf("Verify() = %d, %v", n, err)
+	}
+	if _, err := Verify(path, nil); err == nil || !strings.Contains(err.Error(), "no encryption key") {
+		t.Errorf("expected the log not to be read without key, got %v", err)
+	}
+}
+
+func TestRekey(t *testing.T) {
+	keys1, err := crypt.ParseKeys("k1:" + strings.Repeat("A", 43) + "=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys2, err := crypt.ParseKeys("k2:" + strings.Repeat("B", 43) + "=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	both, err := crypt.ParseKeys("k2:" + strings.Repeat("B", 43) + "=,k1:" + strings.Repeat("A", 43) + "=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, path, cleanup := tempLog(t, keys1)
+	defer cleanup()
+	appendEntries(t, l)
+	l.Close()
+
+	if _, err := Rekey(path, keys2); err == nil {
+		t.Error("expected the log not to be rekeyed without its key")
+	}
+	if n, err := Rekey(path, both); n != 3 || err != nil {
+		t.Fatalf("Rekey() = %d, %v", n, err)
+	}
+	if ids, err := KeyIDs(path); err != nil || strings.Join(ids, ",") != "k2" {
+		t.Errorf("KeyIDs() = %v, %v", ids, err)
+	}
+	// the previous key isn't needed anymore
+	if n, err := Verify(path, keys2); n != 3 || err != nil {
+		t.Errorf("Verify() = %d, %v", n, err)
+	}
+	l, err = Open(path, keys2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	entries, err := l.Query(Filter{Actor: "support"})
+	if err != nil || len(entries) != 1 || entries[0].Method != "/requestspb.RequestsRPC/DeleteRequest" {
+		t.Errorf("unexpected entries %v, %v", entries, err)
+	}
+
+	// a broken log isn't rewritten
+	if err := ioutil.WriteFile(path+".head", []byte(`{"sequence":2}`), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Rekey(path, keys2); err == nil {
+		t.Error("expected a broken log not to be rekeyed")
+	}
+}
+
+func TestAuthenticated(t *testing.T) {
+	keys, err := crypt.ParseKeys("k1:" + strings.Repeat("A", 43) + "=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the entries are rewritten in clear with their hashes computed again, like someone without the
+	// keys would
+	rewrite := func(t *testing.T, path string, fn func(entries []*auditpb.Entry) []*auditpb.Entry) {
+		t.Helper()
+		var entries []*auditpb.Entry
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = read(f, keys, func(e *auditpb.Entry) error {
+			entries = append(entries, e)
+			return nil
+		})
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = fn(entries)
+		var lines []byte
+		previous := ""
+		for _, e := range entries {
+			e.KeyId, e.PreviousHash = "", previous
+			if e.Hash, err = hashOf(e, nil); err != nil {
+				t.Fatal(err)
+			}
+			line, err := (&Log{}).encode(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines = append(append(lines, line...), '\n')
+			previous = e.Hash
+		}
+		if err := ioutil.WriteFile(path, lines, 0640); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeHead(path, nil, entries[len(entries)-1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name   string
+		tamper func(t *testing.T, path string)
+		want   string
+	}{
+		{
+			name: "changed",
+			tamper: func(t *testing.T, path string) {
+				rewrite(t, path, func(entries []*auditpb.Entry) []*auditpb.Entry {
+					entries[2].Actor = "someone"
+					return entries
+				})
+			},
+			want: "entry 1 isn't authenticated",
+		},
+		{
+			name: "truncated",
+			tamper: func(t *testing.T, path string) {
+				b, err := ioutil.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(path, b[:bytes.IndexByte(b, '\n')+1], 0640); err != nil {
+					t.Fatal(err)
+				}
+				if err := writeHead(path, nil, &auditpb.Entry{Sequence: 1}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "the head of the log isn't authenticated",
+		},
+		{
+			name: "head forged",
+			tamper: func(t *testing.T, path string) {
+				if err := ioutil.WriteFile(path+".head", []byte(`{"sequence":1,"hash":"9f2a","keyId":"k1","mac":"AAAA"}`), 0640); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "the head of the log doesn't match its MAC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, path, cleanup := tempLog(t, keys)
+			defer cleanup()
+			appendEntries(t, l)
+			tt.tamper(t, path)
+
+			if _, err := Verify(path, keys); !errors.Is(err, ErrBroken) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Verify() error = %v, want %q", err, tt.want)
+			}
+			if _, err := Open(path, keys); !errors.Is(err, ErrBroken) {
+				t.Errorf("Open() error = %v, want ErrBroken", err)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	l, _, cleanup := tempLog(t, nil)
+	defer cleanup()
+	users := storagetest.Users(map[string]*userspb.User{
+		"Blue": {Name: "Blue", Address: &userspb.User_Address{Postcode: "12345"}, Skills: []string{"plumbing"}},
+	})
+	requests := storagetest.Requests(nil)
+	a := NewAuditor(logrus.NewEntry(logrus.New()), l, "bufconn")
+	interceptor := a.UnaryServerInterceptor()
+
+	call := func(ctx context.Context, method string, req interface{}, handler grpc.UnaryHandler) {
+		t.Helper()
+		_, _ = interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+	tcp := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 4242}})
+	gateway := peer.NewContext(
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "198.51.100.7", "x-actor", "Blue")),
+		&peer.Peer{Addr: fakeAddr("bufconn")},
+	)
+	certified := peer.NewContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-actor", "someone")), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 4242},
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "ops"}}}},
+		}},
+	})
+
+	call(gateway, "/userspb.UsersRPC/UpdateUser", &userspb.UpdateUserRequest{UserId: "Blue"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &userspb.UpdateUserResponse{}, users.Update(ctx, "Blue", &userspb.User{
+			Name:    "Blue Sky",
+			Address: &userspb.User_Address{Postcode: "12345", City: "Stockholm"},
+			Skills:  []string{"plumbing", "gardening"},
+		})
+	})
+	call(tcp, "/requestspb.RequestsRPC/AddRequest", &requestspb.AddRequestRequest{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &requestspb.AddRequestResponse{RequestId: "r1"}, requests.Add(ctx, "r1", &requestspb.Request{Title: "groceries", State: requestspb.Request_ACCEPTED})
+	})
+	// a change made meanwhile by another call isn't recorded with this one
+	call(tcp, "/requestspb.RequestsRPC/AnswerRequest", &requestspb.AnswerRequestRequest{RequestId: "r1"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		if err := requests.Update(context.Background(), "r1", &requestspb.Request{Title: "groceries", Body: "eggs", State: requestspb.Request_ACCEPTED}); err != nil {
+			return nil, err
+		}
+		r := &requestspb.Request{Title: "groceries", Body: "eggs", State: requestspb.Request_ACCEPTED}
+		r.Answers = []*requestspb.Request_Answer{{VolunteerId: "Green", Comment: "I live next door"}}
+		return &requestspb.AnswerRequestResponse{}, requests.Update(ctx, "r1", r)
+	})
+	// the comments of the answers aren't recorded, only that they changed
+	call(tcp, "/requestspb.RequestsRPC/UpdateRequest", &requestspb.UpdateRequestRequest{RequestId: "r1"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		r := &requestspb.Request{Title: "groceries", Body: "eggs", State: requestspb.Request_ACCEPTED}
+		r.Answers = []*requestspb.Request_Answer{{VolunteerId: "Green", Comment: "I live across the street"}}
+		return &requestspb.UpdateRequestResponse{}, requests.Update(ctx, "r1", r)
+	})
+	call(certified, "/requestspb.RequestsRPC/DeleteRequest", &requestspb.DeleteRequestRequest{RequestId: "r1"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &requestspb.DeleteRequestResponse{}, requests.Delete(ctx, "r1")
+	})
+	call(tcp, "/newspb.NewsRPC/DeleteNew", &newspb.DeleteNewRequest{NewId: "n1"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "new not found")
+	})
+	call(tcp, "/userspb.UsersRPC/EraseUser", &userspb.EraseUserRequest{UserId: "Blue"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &userspb.EraseUserResponse{}, users.Erase(ctx, "Blue")
+	})
+	// the calls reading the entities aren't recorded
+	call(tcp, "/userspb.UsersRPC/GetUserByID", &userspb.GetUserByIDRequest{UserId: "Blue"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &userspb.GetUserByIDResponse{}, nil
+	})
+
+	got, err := l.Query(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range got {
+		if e.Date == "" || e.Hash == "" {
+			t.Errorf("expected entry %d to be dated and hashed", e.Sequence)
+		}
+		e.Date, e.Hash, e.PreviousHash = "", "", ""
+	}
+	want := []*auditpb.Entry{
+		{
+			Sequence:      1,
+			Actor:         "Blue",
+			ClientAddress: "198.51.100.7",
+			Method:        "/userspb.UsersRPC/UpdateUser",
+			EntityType:    User,
+			EntityId:      "Blue",
+			Code:          "OK",
+			Changes: []*auditpb.Entry_Change{
+				{Field: "address", Redacted: true},
+				{Field: "name", Redacted: true},
+				{Field: "skills", Before: `["plumbing"]`, After: `["plumbing","gardening"]`},
+			},
+		},
+		{
+			Sequence:      2,
+			ClientAddress: "192.0.2.1",
+			Method:        "/requestspb.RequestsRPC/AddRequest",
+			EntityType:    Request,
+			EntityId:      "r1",
+			Code:          "OK",
+			Changes: []*auditpb.Entry_Change{
+				{Field: "state", After: `"ACCEPTED"`},
+				{Field: "title", Redacted: true},
+			},
+		},
+		{
+			Sequence:      3,
+			ClientAddress: "192.0.2.1",
+			Method:        "/requestspb.RequestsRPC/AnswerRequest",
+			EntityType:    Request,
+			EntityId:      "r1",
+			Code:          "OK",
+			Changes: []*auditpb.Entry_Change{
+				{Field: "answers", After: `[{"volunteer_id":"Green"}]`},
+			},
+		},
+		{
+			Sequence:      4,
+			ClientAddress: "192.0.2.1",
+			Method:        "/requestspb.RequestsRPC/UpdateRequest",
+			EntityType:    Request,
+			EntityId:      "r1",
+			Code:          "OK",
+			Changes: []*auditpb.Entry_Change{
+				{Field: "answers", Redacted: true},
+			},
+		},
+		{
+			Sequence:      5,
+			Actor:         "ops",
+			ClientAddress: "192.0.2.2",
+			Method:        "/requestspb.RequestsRPC/DeleteRequest",
+			EntityType:    Request,
+			EntityId:      "r1",
+			Code:          "OK",
+			Changes: []*auditpb.Entry_Change{
+				{Field: "answers", Before: `[{"volunteer_id":"Green"}]`},
+				{Field: "body", Redacted: true},
+				{Field: "state", Before: `"ACCEPTED"`},
+				{Field: "title", Redacted: true},
+			},
+		},
+		{
+			Sequence:      6,
+			ClientAddress: "192.0.2.1",
+			Method:        "/newspb.NewsRPC/DeleteNew",
+			EntityType:    News,
+			EntityId:      "n1",
+			Code:          "NotFound",
+		},
+		{
+			Sequence:      7,
+			ClientAddress: "192.0.2.1",
+			Method:        "/userspb.UsersRPC/EraseUser",
+			EntityType:    User,
+			EntityId:      "Blue",
+			Code:          "OK",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("entries differ (-want +got):\n%s", diff)
+	}
+}
+
+func TestClientAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		addr net.Addr
+		md   metadata.MD
+		want string
+	}{
+		{
+			name: "tcp",
+			addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234},
+			want: "192.0.2.1",
+		},
+		{
+			name: "untrusted forwarded for",
+			addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1234},
+			md:   metadata.Pairs("x-forwarded-for", "198.51.100.1"),
+			want: "192.0.2.1",
+		},
+		{
+			name: "trusted forwarded for",
+			addr: fakeAddr("bufconn"),
+			md:   metadata.Pairs("x-forwarded-for", "198.51.100.1"),
+			want: "198.51.100.1",
+		},
+		{
+			name: "trusted forwarded for appended",
+			addr: fakeAddr("bufconn"),
+			md:   metadata.Pairs("x-forwarded-for", "203.0.113.9, 192.0.2.7", "x-forwarded-for", "198.51.100.1"),
+			want: "198.51.100.1",
+		},
+		{
+			name: "trusted without forwarded for",
+			addr: fakeAddr("bufconn"),
+			want: "bufconn",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tt.addr})
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			if got := clientAddress(ctx, "bufconn"); got != tt.want {
+				t.Errorf("clientAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeAddr string
+
+func (a fakeAddr) Network() string { return string(a) }
+func (a fakeAddr) String() string  { return "bufconn" }
@@ -0,0 +1,291 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/audit/rpc/auditpb"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/tracing"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// the types of the entities changed
+const (
+	User    = "user"
+	Request = "request"
+	News    = "news"
+)
+
+// eraseMethod is the RPC erasing the personal data of a user.
+const eraseMethod = "/userspb.UsersRPC/EraseUser"
+
+type method struct {
+	entity string
+	// redacted calls are recorded without their changes, like the erasure of the personal data
+	redacted bool
+}
+
+// methods are the RPCs changing the entities, by full name.
+var methods = map[string]method{
+	"/userspb.UsersRPC/AddUser":       {entity: User},
+	"/userspb.UsersRPC/UpdateUser":    {entity: User},
+	"/userspb.UsersRPC/DeleteUser":    {entity: User},
+	eraseMethod:                       {entity: User, redacted: true},
+	"/userspb.UsersRPC/GrantConsent":  {entity: User},
+	"/userspb.UsersRPC/RevokeConsent": {entity: User},
+
+	"/requestspb.RequestsRPC/AddRequest":    {entity: Request},
+	"/requestspb.RequestsRPC/UpdateRequest": {entity: Request},
+	"/requestspb.RequestsRPC/DeleteRequest": {entity: Request},
+	"/requestspb.RequestsRPC/AnswerRequest": {entity: Request},
+	"/requestspb.RequestsRPC/AcceptHelp":    {entity: Request},
+	"/requestspb.RequestsRPC/CompleteHelp":  {entity: Request},
+	"/requestspb.RequestsRPC/CancelHelp":    {entity: Request},
+	"/requestspb.RequestsRPC/RateHelp":      {entity: Request},
+
+	"/newspb.NewsRPC/AddNew":    {entity: News},
+	"/newspb.NewsRPC/UpdateNew": {entity: News},
+	"/newspb.NewsRPC/DeleteNew": {entity: News},
+}
+
+// personal are the fields of the entities whose values aren't recorded, only that they changed: the
+// log can't be changed once written, the data would outlive the erasure of the user.
+var personal = map[string]map[string]bool{
+	User: {"name": true, "contact_details": true, "address": true},
+	// the texts of the requests are cleared when their requester is erased
+	Request: {"title": true, "body": true},
+}
+
+// redactNested clears the personal fields nested in the fields of the entities, the comments of the
+// answers and ratings of the requests: the values of these fields are recorded without them.
+var redactNested = map[string]func(m proto.Message){
+	Request: func(m proto.Message) {
+		r := m.(*requestspb.Request)
+		for _, a := range r.Answers {
+			if a != nil {
+				a.Comment = ""
+			}
+		}
+		for _, rating := range r.Ratings {
+			if rating != nil {
+				rating.Comment = ""
+			}
+		}
+	},
+}
+
+// Auditor records the calls changing the users, requests and news in the log.
+type Auditor struct {
+	logger *logrus.Entry
+	log    *Log
+	// trustedNetwork is the network of the connections whose x-forwarded-for metadata is trusted
+	trustedNetwork string
+}
+
+// NewAuditor returns the auditor recording in the log, the x-forwarded-for metadata is only trusted
+// on connections of the trusted network, e.g. the in-memory connection of the gateway.
+func NewAuditor(logger *logrus.Entry, log *Log, trustedNetwork string) *Auditor {
+	return &Auditor{
+		logger:         logger,
+		log:            log,
+		trustedNetwork: trustedNetwork,
+	}
+}
+
+// UnaryServerInterceptor records the calls of the methods changing an entity, failed or not, with
+// the fields of the entity changed. The changes are the ones the storages recorded under their
+// locks, a call made meanwhile doesn't show in them. The calls are answered even if they can't be
+// recorded.
+func (a *Auditor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		m, ok := methods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		id := entityID(m.entity, req)
+		ctx, changes := storage.WithChanges(ctx)
+		resp, err := handler(ctx, req)
+		if id == "" && err == nil {
+			id = entityID(m.entity, resp)
+		}
+
+		e := &auditpb.Entry{
+			Date:          time.Now().UTC().Format(time.RFC3339Nano),
+			Actor:         actor(ctx),
+			ClientAddress: clientAddress(ctx, a.trustedNetwork),
+			Method:        info.FullMethod,
+			EntityType:    m.entity,
+			EntityId:      id,
+			Code:          status.Code(err).String(),
+		}
+		if err == nil && !m.redacted {
+			before, after := changed(changes(), m.entity, id)
+			changes, diffErr := diff(m.entity, before, after)
+			if diffErr != nil {
+				a.logger.WithError(diffErr).WithField("method", info.FullMethod).Error("problem comparing audited entity")
+			}
+			e.Changes = changes
+		}
+		if appendErr := tracing.Trace(ctx, "AuditLog.Append", func() error { return a.log.Append(e) }); appendErr != nil {
+			a.logger.WithError(appendErr).WithField("method", info.FullMethod).Error("problem recording audit entry")
+		}
+		return resp, err
+	}
+}
+
+// changed returns the entity before the first change of the call and after the last one.
+func changed(changes []storage.Change, entity, id string) (before, after proto.Message) {
+	first := true
+	for _, c := range changes {
+		if c.Entity != entity || c.ID != id {
+			continue
+		}
+		if first {
+			before, first = c.Before, false
+		}
+		after = c.After
+	}
+	return before, after
+}
+
+// entityID returns the id of the entity in the request or response, empty if there's none.
+func entityID(entity string, msg interface{}) string {
+	switch entity {
+	case User:
+		if m, ok := msg.(interface{ GetUserId() string }); ok {
+			return m.GetUserId()
+		}
+	case Request:
+		if m, ok := msg.(interface{ GetRequestId() string }); ok {
+			return m.GetRequestId()
+		}
+	case News:
+		if m, ok := msg.(interface{ GetNewId() string }); ok {
+			return m.GetNewId()
+		}
+	}
+	return ""
+}
+
+// actor returns the common name of the verified client certificate of the caller, or the x-actor
+// metadata the caller sets, which isn't verified.
+func actor(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if chains := info.State.VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+				return chains[0][0].Subject.CommonName
+			}
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("x-actor"); len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+// clientAddress returns the address of the caller, the x-forwarded-for metadata is used on the
+// connections of the trusted network. Its last address is the one the gateway appended, the ones
+// before could be sent by the client.
+func clientAddress(ctx context.Context, trustedNetwork string) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if p.Addr.Network() == trustedNetwork {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get("x-forwarded-for"); len(v) > 0 {
+				addresses := strings.Split(v[len(v)-1], ",")
+				return strings.TrimSpace(addresses[len(addresses)-1])
+			}
+		}
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+// diff returns the top-level fields differing between the entities as JSON, either can be nil. The
+// values of the personal fields are left out, and so are the personal fields nested in the others:
+// a field whose only change is one of them is recorded as redacted.
+func diff(entity string, before, after proto.Message) ([]*auditpb.Entry_Change, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+	rb, ra := b, a
+	if redact, ok := redactNested[entity]; ok {
+		if rb, err = fields(redacted(before, redact)); err != nil {
+			return nil, err
+		}
+		if ra, err = fields(redacted(after, redact)); err != nil {
+			return nil, err
+		}
+	}
+	names := make([]string, 0, len(b)+len(a))
+	for name := range b {
+		names = append(names, name)
+	}
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var changes []*auditpb.Entry_Change
+	for _, name := range names {
+		switch {
+		case bytes.Equal(b[name], a[name]):
+		case personal[entity][name], bytes.Equal(rb[name], ra[name]):
+			changes = append(changes, &auditpb.Entry_Change{Field: name, Redacted: true})
+		default:
+			changes = append(changes, &auditpb.Entry_Change{Field: name, Before: string(rb[name]), After: string(ra[name])})
+		}
+	}
+	return changes, nil
+}
+
+// redacted returns a copy of the entity with redact applied, nil if the entity is.
+func redacted(m proto.Message, redact func(proto.Message)) proto.Message {
+	if m == nil {
+		return nil
+	}
+	m = proto.Clone(m)
+	redact(m)
+	return m
+}
+
+func fields(m proto.Message) (map[string]json.RawMessage, error) {
+	if m == nil {
+		return nil, nil
+	}
+	s, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(m)
+	if err != nil {
+		return nil, err
+	}
+	var f map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(s), &f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
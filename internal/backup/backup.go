@@ -0,0 +1,269 @@
+// Package backup archives the users, requests and news and restores them. An archive is a
+// gzipped tar of the data files, preceded by their metadata with the checksum of each of them.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/version"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+// Format is the version of the archive layout.
+const Format = 1
+
+// Names of the files in the archives, the data files are named like in the data directory.
+const (
+	MetadataFile = "metadata.json"
+	UsersFile    = "users.json"
+	RequestsFile = "requests.json"
+	NewsFile     = "news.json"
+)
+
+// maxMetadataSize limits the metadata read before the checksums can be trusted.
+const maxMetadataSize = 1 << 20
+
+var dataFiles = []string{UsersFile, RequestsFile, NewsFile}
+
+type Metadata struct {
+	Format        int             `json:"format"`
+	SchemaVersion int             `json:"schema_version"`
+	CreationDate  string          `json:"creation_date"`
+	Version       string          `json:"version"`
+	Files         map[string]File `json:"files"`
+}
+
+// File describes a data file of the archive.
+type File struct {
+	Size    int64  `json:"size"`
+	SHA256  string `json:"sha256"`
+	Entries int    `json:"entries"`
+}
+
+// Data is the content of the data files.
+type Data struct {
+	Users    map[string]*userspb.User
+	Requests map[string]*requestspb.Request
+	News     map[string]*newspb.News
+}
+
+// Write writes the archive of the data, created at the given time, with the data files encoded with
+// the codec.
+func Write(w io.Writer, d *Data, created time.Time, c storage.Codec) (*Metadata, error) {
+	meta := &Metadata{
+		Format:        Format,
+		SchemaVersion: datadir.SchemaVersion,
+		CreationDate:  created.UTC().Format(time.RFC3339Nano),
+		Version:       version.Version,
+		Files:         make(map[string]File, len(dataFiles)),
+	}
+	contents := make(map[string][]byte, len(dataFiles))
+	for _, name := range dataFiles {
+		var data interface{}
+		entries := 0
+		switch name {
+		case UsersFile:
+			data, entries = d.Users, len(d.Users)
+		case RequestsFile:
+			data, entries = d.Requests, len(d.Requests)
+		case NewsFile:
+			data, entries = d.News, len(d.News)
+		}
+		// encoded like the stores dump them
+		b, err := c.Encode(data)
+		if err != nil {
+			return nil, fmt.Errorf("problem encoding %s: %w", name, err)
+		}
+		sum := sha256.Sum256(b)
+		contents[name] = b
+		meta.Files[name] = File{Size: int64(len(b)), SHA256: hex.EncodeToString(sum[:]), Entries: entries}
+	}
+	metaBytes, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("problem marshaling metadata: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	add := func(name string, b []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0640, Size: int64(len(b)), ModTime: created}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("problem writing %s: %w", name, err)
+		}
+		if _, err := tw.Write(b); err != nil {
+			return fmt.Errorf("problem writing %s: %w", name, err)
+		}
+		return nil
+	}
+	if err := add(MetadataFile, metaBytes); err != nil {
+		return nil, err
+	}
+	for _, name := range dataFiles {
+		if err := add(name, contents[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("problem closing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("problem closing archive: %w", err)
+	}
+	return meta, nil
+}
+
+// ReadMetadata reads the metadata at the start of an archive, without checking the data files.
+func ReadMetadata(r io.Reader) (*Metadata, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	return readMetadata(tar.NewReader(gz))
+}
+
+func readMetadata(tr *tar.Reader) (*Metadata, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	if hdr.Name != MetadataFile {
+		return nil, fmt.Errorf("not a backup archive: %s is missing", MetadataFile)
+	}
+	meta := &Metadata{}
+	if err := json.NewDecoder(io.LimitReader(tr, maxMetadataSize)).Decode(meta); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+	if meta.Format != Format {
+		return nil, fmt.Errorf("unsupported archive format %d", meta.Format)
+	}
+	return meta, nil
+}
+
+// Read reads an archive, failing unless every data file is there, matches its checksum and is
+// valid. The data files are decoded with the codec, which reads the data files of the older schema
+// versions too: the data of the archives of an older version is returned in the current one.
+func Read(r io.Reader, c storage.Codec) (*Data, *Metadata, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	meta, err := readMetadata(tr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents := make(map[string][]byte, len(dataFiles))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("problem reading archive: %w", err)
+		}
+		f, ok := meta.Files[hdr.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s isn't in the metadata", hdr.Name)
+		}
+		if _, ok := contents[hdr.Name]; ok {
+			return nil, nil, fmt.Errorf("%s is archived twice", hdr.Name)
+		}
+		if hdr.Size != f.Size {
+			return nil, nil, fmt.Errorf("%s has %d bytes, %d expected", hdr.Name, hdr.Size, f.Size)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("problem reading %s: %w", hdr.Name, err)
+		}
+		sum := sha256.Sum256(b)
+		if hex.EncodeToString(sum[:]) != f.SHA256 {
+			return nil, nil, fmt.Errorf("%s doesn't match its checksum", hdr.Name)
+		}
+		contents[hdr.Name] = b
+	}
+	// the checksum of the compressed stream is checked at its end
+	if _, err := io.Copy(ioutil.Discard, gz); err != nil {
+		return nil, nil, fmt.Errorf("problem reading archive: %w", err)
+	}
+
+	d := &Data{
+		Users:    make(map[string]*userspb.User),
+		Requests: make(map[string]*requestspb.Request),
+		News:     make(map[string]*newspb.News),
+	}
+	for _, name := range dataFiles {
+		b, ok := contents[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s is missing", name)
+		}
+		var data interface{}
+		var entries func() int
+		switch name {
+		case UsersFile:
+			data, entries = &d.Users, func() int { return len(d.Users) }
+		case RequestsFile:
+			data, entries = &d.Requests, func() int { return len(d.Requests) }
+		case NewsFile:
+			data, entries = &d.News, func() int { return len(d.News) }
+		}
+		if err := c.Decode(b, data); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		if n := entries(); n != meta.Files[name].Entries {
+			return nil, nil, fmt.Errorf("%s has %d entries, %d expected", name, n, meta.Files[name].Entries)
+		}
+	}
+	return d, meta, nil
+}
+
+// KeyIDs returns the ids of the keys the data files of an archive are encrypted with, sorted. The
+// data files aren't decrypted nor checked, no key is needed.
+func KeyIDs(r io.Reader) ([]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	if _, err := readMetadata(tr); err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	var ids []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("problem reading archive: %w", err)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("problem reading %s: %w", hdr.Name, err)
+		}
+		id, err := storage.KeyID(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", hdr.Name, err)
+		}
+		if id != "" && !found[id] {
+			found[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
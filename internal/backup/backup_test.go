@@ -0,0 +1,237 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func testData() *Data {
+	return &Data{
+		Users:    map[string]*userspb.User{"u1": {Name: "Ada"}},
+		Requests: map[string]*requestspb.Request{"r1": {Title: "groceries", RequesterId: "u1"}},
+		News:     map[string]*newspb.News{},
+	}
+}
+
+// rewrite copies an archive, changing its files with edit.
+func rewrite(t *testing.T, archive []byte, edit func(name string, b []byte) []byte) []byte {
+	gzr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gzr)
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(tr)
+		if b = edit(hdr.Name, b); b == nil {
+			continue
+		}
+		hdr.Size = int64(len(b))
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gzw.Close()
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	var buf bytes.Buffer
+	meta, err := Write(&buf, testData(), time.Now(), storage.Plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Files[UsersFile].Entries != 1 || meta.Files[NewsFile].Entries != 0 {
+		t.Errorf("unexpected entries in %v", meta.Files)
+	}
+	archive := buf.Bytes()
+
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			archive: archive,
+		},
+		{
+			name:    "not gzipped",
+			archive: []byte("users.json"),
+			wantErr: "not a backup archive",
+		},
+		{
+			name:    "truncated",
+			archive: archive[:len(archive)-10],
+			wantErr: "unexpected EOF",
+		},
+		{
+			name: "changed",
+			archive: rewrite(t, archive, func(name string, b []byte) []byte {
+				if name == UsersFile {
+					return bytes.Replace(b, []byte("Ada"), []byte("Eve"), 1)
+				}
+				return b
+			}),
+			wantErr: "users.json doesn't match its checksum",
+		},
+		{
+			name: "missing file",
+			archive: rewrite(t, archive, func(name string, b []byte) []byte {
+				if name == NewsFile {
+					return nil
+				}
+				return b
+			}),
+			wantErr: "news.json is missing",
+		},
+		{
+			name: "missing metadata",
+			archive: rewrite(t, archive, func(name string, b []byte) []byte {
+				if name == MetadataFile {
+					return nil
+				}
+				return b
+			}),
+			wantErr: "metadata.json is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _, err := Read(bytes.NewReader(tt.archive), storage.Plain)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(testData(), d); diff != "" {
+				t.Errorf("data differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadEncrypted(t *testing.T) {
+	keys, err := crypt.ParseKeys("k1:" + strings.Repeat("A", 43) + "=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := Write(&buf, testData(), time.Now(), storage.Encrypted(keys)); err != nil {
+		t.Fatal(err)
+	}
+	rewrite(t, buf.Bytes(), func(name string, b []byte) []byte {
+		if name == UsersFile && bytes.Contains(b, []byte("Ada")) {
+			t.Errorf("expected the users to be encrypted, got %s", b)
+		}
+		return b
+	})
+	if _, _, err := Read(bytes.NewReader(buf.Bytes()), storage.Plain); err == nil || !strings.Contains(err.Error(), "no encryption key") {
+		t.Errorf("expected an error reading without the key, got %v", err)
+	}
+	d, _, err := Read(bytes.NewReader(buf.Bytes()), storage.Encrypted(keys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(testData(), d); diff != "" {
+		t.Errorf("data differs (-want +got):\n%s", diff)
+	}
+}
+
+func TestBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	backups := New(logrus.NewEntry(logrus.New()), dir, 2, storage.Plain, testData)
+	var created []*Backup
+	for i := 0; i < 3; i++ {
+		b, err := backups.Create()
+		if err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, b)
+		time.Sleep(time.Millisecond)
+	}
+	list, err := backups.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(list))
+	for _, b := range list {
+		names = append(names, b.Name)
+	}
+	if diff := cmp.Diff([]string{created[2].Name, created[1].Name}, names); diff != "" {
+		t.Errorf("expected the 2 newest backups, differ (-want +got):\n%s", diff)
+	}
+
+	t1, _ := created[1].Created()
+	if b, err := At(dir, t1.Add(time.Microsecond)); err != nil || b.Name != created[1].Name {
+		t.Errorf("expected %s, got %v, %v", created[1].Name, b, err)
+	}
+	t0, _ := created[0].Created()
+	if _, err := At(dir, t0); err == nil {
+		t.Error("expected no backup before the ones kept")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := []string{filepath.Join(dir, UsersFile), filepath.Join(dir, RequestsFile), filepath.Join(dir, NewsFile)}
+	if err := ioutil.WriteFile(files[0], []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Restore(testData(), storage.Plain, files[0], files[1], files[2]); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"schemaVersion":2,"records":{"u1":{"name":"Ada"}}}` {
+		t.Errorf("unexpected users file %s", b)
+	}
+	if info, err := os.Stat(files[0]); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("expected the mode of the file to be kept, got %v, %v", info.Mode(), err)
+	}
+}
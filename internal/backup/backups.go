@@ -0,0 +1,272 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// the archives are named after their creation time, so they sort from the oldest
+const (
+	namePrefix = "backup-"
+	nameSuffix = ".tar.gz"
+	timeLayout = "20060102T150405.000000Z"
+)
+
+// Backup is an archive of a backup directory.
+type Backup struct {
+	Name     string
+	Path     string
+	Size     int64
+	Metadata *Metadata
+}
+
+// Created returns the creation time of the backup.
+func (b *Backup) Created() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, b.Metadata.CreationDate)
+}
+
+// Backups writes the archives of the data to a directory, the oldest archives are deleted once
+// there are more than keep, none are deleted if keep is 0.
+type Backups struct {
+	logger   *logrus.Entry
+	dir      string
+	keep     int
+	codec    storage.Codec
+	snapshot func() *Data
+
+	// mu serializes the creations and rotations
+	mu sync.Mutex
+}
+
+// New returns the backups of the data returned by snapshot, which must be taken at one point. The
+// data files are archived encoded with the codec, like the stores write them.
+func New(logger *logrus.Entry, dir string, keep int, codec storage.Codec, snapshot func() *Data) *Backups {
+	return &Backups{
+		logger:   logger,
+		dir:      dir,
+		keep:     keep,
+		codec:    codec,
+		snapshot: snapshot,
+	}
+}
+
+// Create archives the data as it is now, then deletes the oldest archives.
+func (b *Backups) Create() (*Backup, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	backup, err := Save(b.dir, b.snapshot(), time.Now(), b.codec)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.rotate(); err != nil {
+		b.logger.WithError(err).Error("problem deleting old backups")
+	}
+	return backup, nil
+}
+
+// List returns the backups, the newest first.
+func (b *Backups) List() ([]*Backup, error) {
+	return List(b.dir)
+}
+
+// Run creates a backup at every interval until the context is cancelled.
+func (b *Backups) Run(ctx context.Context, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			backup, err := b.Create()
+			if err != nil {
+				b.logger.WithError(err).Error("problem creating scheduled backup")
+				continue
+			}
+			b.logger.WithField("backup", backup.Name).Info("created scheduled backup")
+		}
+	}
+}
+
+func (b *Backups) rotate() error {
+	if b.keep <= 0 {
+		return nil
+	}
+	names, err := archives(b.dir)
+	if err != nil {
+		return err
+	}
+	for len(names) > b.keep {
+		if err := os.Remove(filepath.Join(b.dir, names[0])); err != nil {
+			return err
+		}
+		b.logger.WithField("backup", names[0]).Info("deleted old backup")
+		names = names[1:]
+	}
+	return nil
+}
+
+// Save writes the archive of the data to the directory, the archive only appears once complete.
+func Save(dir string, d *Data, created time.Time, c storage.Codec) (*Backup, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("problem creating backup directory: %w", err)
+	}
+	name := namePrefix + created.UTC().Format(timeLayout) + nameSuffix
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("backup %s already exists", name)
+	}
+
+	var meta *Metadata
+	err := writeFile(path, 0640, func(w io.Writer) error {
+		var err error
+		meta, err = Write(w, d, created, c)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("problem writing backup: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Backup{Name: name, Path: path, Size: info.Size(), Metadata: meta}, nil
+}
+
+// List returns the backups of a directory, the newest first.
+func List(dir string) ([]*Backup, error) {
+	names, err := archives(dir)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]*Backup, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		path := filepath.Join(dir, names[i])
+		backup, err := open(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", names[i], err)
+		}
+		backups = append(backups, backup)
+	}
+	return backups, nil
+}
+
+func open(path string) (*Backup, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := ReadMetadata(f)
+	if err != nil {
+		return nil, err
+	}
+	return &Backup{Name: filepath.Base(path), Path: path, Size: info.Size(), Metadata: meta}, nil
+}
+
+// At returns the newest backup of the directory created at or before t.
+func At(dir string, t time.Time) (*Backup, error) {
+	backups, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backups {
+		created, err := backup.Created()
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid creation date: %w", backup.Name, err)
+		}
+		if !created.After(t) {
+			return backup, nil
+		}
+	}
+	return nil, fmt.Errorf("no backup created before %s in %s", t.Format(time.RFC3339), dir)
+}
+
+// archives returns the names of the archives of the directory, the oldest first.
+func archives(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), namePrefix) && strings.HasSuffix(e.Name(), nameSuffix) {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Restore replaces the data files with the data of an archive encoded with the codec, each file is
+// replaced at once.
+func Restore(d *Data, c storage.Codec, usersFile, requestsFile, newsFile string) error {
+	files := []struct {
+		path string
+		data interface{}
+	}{
+		{usersFile, d.Users},
+		{requestsFile, d.Requests},
+		{newsFile, d.News},
+	}
+	for _, f := range files {
+		b, err := c.Encode(f.data)
+		if err != nil {
+			return fmt.Errorf("problem encoding %s: %w", f.path, err)
+		}
+		mode := os.FileMode(0640)
+		if info, err := os.Stat(f.path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		err = writeFile(f.path, mode, func(w io.Writer) error {
+			_, err := w.Write(b)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("problem restoring %s: %w", f.path, err)
+		}
+	}
+	return nil
+}
+
+// writeFile writes a file through a temporary file renamed once complete, so the file is either
+// left as it was or completely written.
+func writeFile(path string, mode os.FileMode, write func(w io.Writer) error) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	err = write(tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
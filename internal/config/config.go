@@ -0,0 +1,242 @@
+// Package config merges the settings given in a configuration file, in environment variables and
+// on the command line into the flags of the service.
+//
+// The settings are named after the flags in every source: --tls-cert is set with tls-cert in the
+// file and with EUVSVIRUS_TLS_CERT in the environment. The command line takes precedence over the
+// environment, which takes precedence over the file.
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix is the prefix of the environment variables setting the flags.
+const EnvPrefix = "EUVSVIRUS_"
+
+// Masked replaces the values of the secrets when printing the settings.
+const Masked = "********"
+
+// EnvName returns the environment variable setting the flag.
+func EnvName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.Replace(flagName, "-", "_", -1))
+}
+
+// Loader sets the flags from the sources.
+type Loader struct {
+	flags   *flag.FlagSet
+	secrets map[string]bool
+	// local are the flags only given on the command line, like the configuration file
+	local      map[string]bool
+	configFlag string
+	// args are the flags given on the command line, they're kept when reloading
+	args map[string]string
+}
+
+// New returns the loader of the flags, the configuration file is given with the configFlag flag.
+func New(flags *flag.FlagSet, configFlag string) *Loader {
+	return &Loader{
+		flags:      flags,
+		secrets:    make(map[string]bool),
+		local:      map[string]bool{configFlag: true},
+		configFlag: configFlag,
+		args:       make(map[string]string),
+	}
+}
+
+// Secret marks the flags whose values are masked when printed.
+func (l *Loader) Secret(names ...string) {
+	for _, name := range names {
+		l.secrets[name] = true
+	}
+}
+
+// CommandLineOnly marks the flags that aren't settings of the service, e.g. asking to print the
+// settings. They're only read from the command line and aren't printed.
+func (l *Loader) CommandLineOnly(names ...string) {
+	for _, name := range names {
+		l.local[name] = true
+	}
+}
+
+// Load parses the command line and sets the flags that weren't given from the environment and
+// the configuration file.
+func (l *Loader) Load(args []string) error {
+	if err := l.flags.Parse(args); err != nil {
+		return err
+	}
+	l.flags.Visit(func(f *flag.Flag) {
+		l.args[f.Name] = f.Value.String()
+	})
+
+	values, err := l.values()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := l.args[name]; ok {
+			continue
+		}
+		if err := l.flags.Set(name, values[name]); err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", values[name], l.source(name), err)
+		}
+	}
+	return nil
+}
+
+// Reload reads the environment and the configuration file again, it returns the value every flag
+// would have if the service was restarted, as printed by the flag.
+func (l *Loader) Reload() (map[string]string, error) {
+	values, err := l.values()
+	if err != nil {
+		return nil, err
+	}
+	var reloadErr error
+	l.flags.VisitAll(func(f *flag.Flag) {
+		if l.local[f.Name] {
+			delete(values, f.Name)
+			return
+		}
+		if v, ok := l.args[f.Name]; ok {
+			values[f.Name] = v
+			return
+		}
+		v, ok := values[f.Name]
+		if !ok {
+			values[f.Name] = f.DefValue
+			return
+		}
+		if values[f.Name], err = canonical(f, v); err != nil && reloadErr == nil {
+			reloadErr = fmt.Errorf("invalid value %q for %s: %w", v, l.source(f.Name), err)
+		}
+	})
+	if reloadErr != nil {
+		return nil, reloadErr
+	}
+	return values, nil
+}
+
+// canonical returns the value as printed by the flag, e.g. 1m0s for 1m, by setting it to a new
+// value of the same type.
+func canonical(f *flag.Flag, v string) (string, error) {
+	t := reflect.TypeOf(f.Value)
+	if t.Kind() != reflect.Ptr {
+		return v, nil
+	}
+	fresh, ok := reflect.New(t.Elem()).Interface().(flag.Value)
+	if !ok {
+		return v, nil
+	}
+	if err := fresh.Set(v); err != nil {
+		return "", err
+	}
+	return fresh.String(), nil
+}
+
+// values returns the values set by the environment and the configuration file.
+func (l *Loader) values() (map[string]string, error) {
+	values := make(map[string]string)
+	path := l.args[l.configFlag]
+	if v, ok := os.LookupEnv(EnvName(l.configFlag)); ok && path == "" {
+		path = v
+	}
+	if path != "" {
+		fileValues, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for name, v := range fileValues {
+			if l.local[name] || l.flags.Lookup(name) == nil {
+				return nil, fmt.Errorf("unknown setting %q in %s", name, path)
+			}
+			values[name] = v
+		}
+	}
+	l.flags.VisitAll(func(f *flag.Flag) {
+		if v, ok := os.LookupEnv(EnvName(f.Name)); ok && !l.local[f.Name] {
+			values[f.Name] = v
+		}
+	})
+	return values, nil
+}
+
+func (l *Loader) source(name string) string {
+	if _, ok := os.LookupEnv(EnvName(name)); ok {
+		return EnvName(name)
+	}
+	return name
+}
+
+// readFile reads the settings of a YAML file, lists are joined with commas.
+func readFile(path string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("problem reading configuration file: %w", err)
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("problem parsing configuration file %s: %w", path, err)
+	}
+	values := make(map[string]string, len(raw))
+	for name, v := range raw {
+		switch v := v.(type) {
+		case nil:
+			values[name] = ""
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[name] = strings.Join(items, ",")
+		case map[interface{}]interface{}:
+			return nil, fmt.Errorf("invalid value for %s in %s: nested settings aren't supported", name, path)
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+	return values, nil
+}
+
+// Print writes the effective settings as a YAML configuration file, with the secrets masked.
+func (l *Loader) Print(w io.Writer) error {
+	var b bytes.Buffer
+	l.flags.VisitAll(func(f *flag.Flag) {
+		if l.local[f.Name] {
+			return
+		}
+		v := f.Value.String()
+		if l.secrets[f.Name] && v != "" {
+			v = Masked
+		}
+		line, _ := yaml.Marshal(map[string]string{f.Name: v})
+		b.Write(line)
+	})
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// Mask returns the settings with the secrets masked, for logging.
+func (l *Loader) Mask(values map[string]string) map[string]string {
+	masked := make(map[string]string, len(values))
+	for name, v := range values {
+		if l.secrets[name] && v != "" {
+			v = Masked
+		}
+		masked[name] = v
+	}
+	return masked
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newFlags() *flag.FlagSet {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	flags.String("config", "", "")
+	flags.Bool("print-config", false, "")
+	flags.String("addr", "localhost:10000", "")
+	flags.String("allowed-origins", "", "")
+	flags.String("log-level", "info", "")
+	flags.Duration("shutdown-timeout", 10*time.Second, "")
+	flags.String("smtp-password", "", "")
+	return flags
+}
+
+func writeConfig(t *testing.T, dir, content string) string {
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func setenv(t *testing.T, env map[string]string) func() {
+	for k, v := range env {
+		if err := os.Setenv(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return func() {
+		for k := range env {
+			os.Unsetenv(k)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		args    []string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: map[string]string{"addr": "localhost:10000", "log-level": "info", "shutdown-timeout": "10s"},
+		},
+		{
+			name: "file",
+			file: "addr: localhost:9000\nallowed-origins:\n  - https://a.example\n  - https://b.example\nshutdown-timeout: 1m\n",
+			want: map[string]string{
+				"addr":             "localhost:9000",
+				"allowed-origins":  "https://a.example,https://b.example",
+				"log-level":        "info",
+				"shutdown-timeout": "1m0s",
+			},
+		},
+		{
+			name: "environment over file",
+			file: "addr: localhost:9000\nlog-level: warn\n",
+			env:  map[string]string{"EUVSVIRUS_ADDR": "localhost:8000"},
+			want: map[string]string{"addr": "localhost:8000", "log-level": "warn", "shutdown-timeout": "10s"},
+		},
+		{
+			name: "command line over environment",
+			file: "log-level: warn\n",
+			env:  map[string]string{"EUVSVIRUS_ADDR": "localhost:8000", "EUVSVIRUS_LOG_LEVEL": "error"},
+			args: []string{"--addr", "localhost:7000"},
+			want: map[string]string{"addr": "localhost:7000", "log-level": "error", "shutdown-timeout": "10s"},
+		},
+		{
+			name:    "unknown setting",
+			file:    "adr: localhost:9000\n",
+			wantErr: `unknown setting "adr"`,
+		},
+		{
+			name:    "command line only setting in file",
+			file:    "print-config: true\n",
+			wantErr: `unknown setting "print-config"`,
+		},
+		{
+			name:    "nested setting",
+			file:    "tls:\n  cert: cert.pem\n",
+			wantErr: "nested settings aren't supported",
+		},
+		{
+			name:    "invalid environment value",
+			env:     map[string]string{"EUVSVIRUS_SHUTDOWN_TIMEOUT": "soon"},
+			wantErr: "EUVSVIRUS_SHUTDOWN_TIMEOUT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "config")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			defer setenv(t, tt.env)()
+
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"--config", writeConfig(t, dir, tt.file)}, args...)
+			}
+			flags := newFlags()
+			l := New(flags, "config")
+			l.CommandLineOnly("print-config")
+			err = l.Load(args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]string)
+			for name := range tt.want {
+				got[name] = flags.Lookup(name).Value.String()
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("settings differ (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnvironment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer setenv(t, map[string]string{"EUVSVIRUS_CONFIG": writeConfig(t, dir, "addr: localhost:9000\n")})()
+
+	flags := newFlags()
+	if err := New(flags, "config").Load(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := flags.Lookup("addr").Value.String(); got != "localhost:9000" {
+		t.Errorf("addr = %q, want localhost:9000", got)
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := writeConfig(t, dir, "log-level: warn\n")
+
+	flags := newFlags()
+	l := New(flags, "config")
+	l.CommandLineOnly("print-config")
+	if err := l.Load([]string{"--config", path, "--addr", "localhost:7000"}); err != nil {
+		t.Fatal(err)
+	}
+
+	writeConfig(t, dir, "log-level: debug\naddr: localhost:9000\nshutdown-timeout: 10s\n")
+	got, err := l.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"addr":             "localhost:7000",
+		"allowed-origins":  "",
+		"log-level":        "debug",
+		"shutdown-timeout": "10s",
+		"smtp-password":    "",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Reload() differs (-want +got):\n%s", diff)
+	}
+
+	writeConfig(t, dir, "shutdown-timeout: soon\n")
+	if _, err := l.Reload(); err == nil {
+		t.Error("Reload() of an invalid file succeeded")
+	}
+}
+
+func TestPrint(t *testing.T) {
+	flags := newFlags()
+	l := New(flags, "config")
+	l.Secret("smtp-password")
+	l.CommandLineOnly("print-config")
+	if err := l.Load([]string{"--smtp-password", "hunter2", "--allowed-origins", "https://a.example"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := l.Print(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `addr: localhost:10000
+allowed-origins: https://a.example
+log-level: info
+shutdown-timeout: 10s
+smtp-password: '********'
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("Print() differs (-want +got):\n%s", diff)
+	}
+}
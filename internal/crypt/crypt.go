@@ -0,0 +1,186 @@
+// Package crypt encrypts the data at rest with AES-256-GCM. Each value is encrypted with a random
+// data key, itself encrypted with a key of the keyring whose id is stored with the value, so the
+// keys can be rotated while the values encrypted with the previous ones are still read. The keys
+// also authenticate values with HMAC-SHA256, under a key derived from them.
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// KeySize is the size of the keys, AES-256.
+const KeySize = 32
+
+// ErrUnknownKey is returned when a value is encrypted with a key that isn't in the keyring.
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Sealed is an encrypted value.
+type Sealed struct {
+	// KeyID is the id of the key encrypting DataKey.
+	KeyID string `json:"keyId"`
+	// DataKey is the nonce and the encrypted key of the value.
+	DataKey []byte `json:"dataKey"`
+	// Ciphertext is the nonce and the encrypted value.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keyring holds the keys by id, the primary key encrypts and every key decrypts.
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+	// macKeys are derived from the keys, a key isn't used for both encrypting and authenticating
+	macKeys map[string][]byte
+}
+
+// ParseKeys reads keys written as id:base64, separated by commas or new lines. The first one is the
+// primary key, the others are the previous keys still decrypting the values not re-encrypted yet.
+// Empty lines and lines starting with # are ignored.
+func ParseKeys(s string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]cipher.AEAD), macKeys: make(map[string][]byte)}
+	for _, line := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.New("keys are written as id:base64")
+		}
+		id := parts[0]
+		if _, ok := k.keys[id]; ok {
+			return nil, fmt.Errorf("key %s is given twice", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("key %s isn't valid base64: %w", id, err)
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("key %s has %d bytes, %d expected", id, len(key), KeySize)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[id] = aead
+		k.macKeys[id] = sum(key, []byte("mac"))
+		if k.primary == "" {
+			k.primary = id
+		}
+	}
+	if k.primary == "" {
+		return nil, errors.New("no key given")
+	}
+	return k, nil
+}
+
+// LoadKeys reads the keys of a file, written like ParseKeys reads them.
+func LoadKeys(path string) (*Keyring, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("problem reading encryption keys: %w", err)
+	}
+	k, err := ParseKeys(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return k, nil
+}
+
+// Primary returns the id of the key encrypting the values.
+func (k *Keyring) Primary() string {
+	return k.primary
+}
+
+// Seal encrypts a value with a new data key, encrypted with the primary key.
+func (k *Keyring) Seal(plaintext []byte) (*Sealed, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, fmt.Errorf("problem generating data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := seal(aead, plaintext, nil)
+	if err != nil {
+		return nil, err
+	}
+	// the key id is authenticated, the data key can't be moved to another key
+	wrapped, err := seal(k.keys[k.primary], dataKey, []byte(k.primary))
+	if err != nil {
+		return nil, err
+	}
+	return &Sealed{KeyID: k.primary, DataKey: wrapped, Ciphertext: ciphertext}, nil
+}
+
+// Open decrypts a value, failing with ErrUnknownKey when its key isn't in the keyring.
+func (k *Keyring) Open(s *Sealed) ([]byte, error) {
+	key, ok := k.keys[s.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownKey, s.KeyID)
+	}
+	dataKey, err := open(key, s.DataKey, []byte(s.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("problem decrypting data key with key %s: %w", s.KeyID, err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(aead, s.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("problem decrypting data: %w", err)
+	}
+	return plaintext, nil
+}
+
+// MAC returns the HMAC-SHA256 of the data with the key of the id, failing with ErrUnknownKey when
+// it isn't in the keyring. The values are authenticated with the primary key.
+func (k *Keyring) MAC(keyID string, data []byte) ([]byte, error) {
+	key, ok := k.macKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownKey, keyID)
+	}
+	return sum(key, data), nil
+}
+
+func sum(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts with a random nonce written before the ciphertext.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("problem generating nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce := ciphertext[:aead.NonceSize()]
+	return aead.Open(nil, nonce, ciphertext[aead.NonceSize():], additionalData)
+}
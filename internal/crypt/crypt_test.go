@@ -0,0 +1,143 @@
+package crypt
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+var (
+	key1 = "k1:" + strings.Repeat("A", 43) + "="
+	key2 = "k2:" + strings.Repeat("B", 43) + "="
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        string
+		wantPrimary string
+		wantErr     string
+	}{
+		{name: "one key", keys: key1, wantPrimary: "k1"},
+		{name: "rotated", keys: key2 + "," + key1, wantPrimary: "k2"},
+		{name: "lines", keys: "# rotated on 2026-10-19\n" + key2 + "\n\n" + key1 + "\n", wantPrimary: "k2"},
+		{name: "none", keys: " \n", wantErr: "no key given"},
+		{name: "no id", keys: ":" + strings.Repeat("A", 43) + "=", wantErr: "id:base64"},
+		{name: "not base64", keys: "k1:not base64", wantErr: "isn't valid base64"},
+		{name: "short", keys: "k1:AAAA", wantErr: "has 3 bytes, 32 expected"},
+		{name: "twice", keys: key1 + "," + key1, wantErr: "k1 is given twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := ParseKeys(tt.keys)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseKeys() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if k.Primary() != tt.wantPrimary {
+				t.Errorf("Primary() = %s, want %s", k.Primary(), tt.wantPrimary)
+			}
+		})
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	f, err := ioutil.TempFile("", "keys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(key1 + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if k, err := LoadKeys(f.Name()); err != nil || k.Primary() != "k1" {
+		t.Errorf("LoadKeys() = %v, %v, want k1", k, err)
+	}
+	if _, err := LoadKeys(f.Name() + ".missing"); err == nil {
+		t.Error("expected an error loading a missing file")
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	old, err := ParseKeys(key1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := ParseKeys(key2 + "," + key1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := []byte(`{"u1":{"name":"Ada"}}`)
+
+	sealed, err := old.Seal(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sealed.KeyID != "k1" || bytes.Contains(sealed.Ciphertext, plaintext) {
+		t.Errorf("unexpected sealed value %+v", sealed)
+	}
+	// the values encrypted with the previous keys are still read
+	if got, err := rotated.Open(sealed); err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("Open() = %s, %v, want %s", got, err, plaintext)
+	}
+	resealed, err := rotated.Seal(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resealed.KeyID != "k2" {
+		t.Errorf("expected the primary key, got %s", resealed.KeyID)
+	}
+	if _, err := old.Open(resealed); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Open() error = %v, want %v", err, ErrUnknownKey)
+	}
+
+	tampered := *sealed
+	tampered.Ciphertext = append([]byte(nil), sealed.Ciphertext...)
+	tampered.Ciphertext[len(tampered.Ciphertext)-1] ^= 1
+	if _, err := old.Open(&tampered); err == nil {
+		t.Error("expected an error opening a tampered value")
+	}
+	moved := *sealed
+	moved.KeyID = "k2"
+	if _, err := rotated.Open(&moved); err == nil {
+		t.Error("expected an error opening a data key with another key")
+	}
+}
+
+func TestMAC(t *testing.T) {
+	old, err := ParseKeys(key1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := ParseKeys(key2 + "," + key1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("42:c41e")
+
+	mac, err := old.MAC("k1", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := rotated.MAC("k1", data); err != nil || !bytes.Equal(got, mac) {
+		t.Errorf("expected the same MAC with the previous key, got %x, %v", got, err)
+	}
+	if got, err := rotated.MAC("k2", data); err != nil || bytes.Equal(got, mac) {
+		t.Errorf("expected another MAC with another key, got %x, %v", got, err)
+	}
+	if got, err := old.MAC("k1", []byte("42:c41f")); err != nil || bytes.Equal(got, mac) {
+		t.Errorf("expected another MAC for other data, got %x, %v", got, err)
+	}
+	if _, err := old.MAC("k2", data); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("MAC() error = %v, want %v", err, ErrUnknownKey)
+	}
+}
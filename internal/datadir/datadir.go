@@ -0,0 +1,205 @@
+// Package datadir prepares the directory holding the data files of the service.
+package datadir
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// SchemaVersion is the version of the format of the data files written by the service.
+const SchemaVersion = 2
+
+// unversioned is the version of the files written before the version was recorded.
+const unversioned = 1
+
+const (
+	lockFile    = ".lock"
+	versionFile = "schema_version"
+	// emptyData is written to the data files created, an empty JSON object
+	emptyData = "{}"
+)
+
+// ErrLocked is returned when the directory is used by another instance of the service.
+var ErrLocked = errors.New("data directory is used by another instance")
+
+// Dir is a data directory locked by the service.
+type Dir struct {
+	path string
+	lock *os.File
+}
+
+// Open creates the directory if it doesn't exist, locks it and checks the version of the schema
+// of its files, recording it in a new directory.
+func Open(path string) (*Dir, error) {
+	if err := os.MkdirAll(path, 0750); err != nil {
+		return nil, fmt.Errorf("problem creating data directory: %w", err)
+	}
+	lock, err := os.OpenFile(filepath.Join(path, lockFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("problem opening lock file: %w", err)
+	}
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		lock.Close()
+		if err == syscall.EWOULDBLOCK {
+			return nil, fmt.Errorf("%s: %w", path, ErrLocked)
+		}
+		return nil, fmt.Errorf("problem locking data directory: %w", err)
+	}
+	// the pid helps finding the other instance
+	_ = lock.Truncate(0)
+	_, _ = lock.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+
+	d := &Dir{path: path, lock: lock}
+	if err := d.checkVersion(); err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// Version returns the schema version recorded in the directory, 0 if none is.
+func (d *Dir) Version() (int, error) {
+	b, err := ioutil.ReadFile(filepath.Join(d.path, versionFile))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("problem reading schema version: %w", err)
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid schema version %q in %s", strings.TrimSpace(string(b)), versionFile)
+	}
+	return v, nil
+}
+
+// SetVersion records the schema version of the files.
+func (d *Dir) SetVersion(v int) error {
+	path := filepath.Join(d.path, versionFile)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strconv.Itoa(v)+"\n"), 0640); err != nil {
+		return fmt.Errorf("problem writing schema version: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("problem writing schema version: %w", err)
+	}
+	return nil
+}
+
+func (d *Dir) checkVersion() error {
+	v, err := d.Version()
+	if err != nil {
+		return err
+	}
+	switch {
+	case v == 0:
+		files, err := filepath.Glob(filepath.Join(d.path, "*.json"))
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			return d.SetVersion(unversioned)
+		}
+		return d.SetVersion(SchemaVersion)
+	case v > SchemaVersion:
+		return fmt.Errorf("data directory has schema version %d, this version of the service supports up to %d", v, SchemaVersion)
+	}
+	return nil
+}
+
+// Current returns an error when the files have to be migrated to the schema version of the service.
+func (d *Dir) Current() error {
+	v, err := d.Version()
+	if err != nil {
+		return err
+	}
+	if v < SchemaVersion {
+		return fmt.Errorf("data directory has schema version %d, run backend migrate to upgrade it to %d", v, SchemaVersion)
+	}
+	return nil
+}
+
+// Migration upgrades the data files from the previous schema version.
+type Migration struct {
+	Version     int
+	Description string
+	Apply       func(d *Dir) error
+}
+
+// Pending returns the migrations not applied to the directory yet.
+func (d *Dir) Pending(migrations []Migration) ([]Migration, error) {
+	v, err := d.Version()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version <= v {
+			continue
+		}
+		if m.Version != v+len(pending)+1 {
+			return nil, fmt.Errorf("no migration to schema version %d", v+len(pending)+1)
+		}
+		pending = append(pending, m)
+	}
+	return pending, nil
+}
+
+// Migrate applies the pending migrations in order, recording the version after each of them. It
+// returns the migrations applied.
+func (d *Dir) Migrate(migrations []Migration) ([]Migration, error) {
+	pending, err := d.Pending(migrations)
+	if err != nil {
+		return nil, err
+	}
+	for i, m := range pending {
+		if err := m.Apply(d); err != nil {
+			return pending[:i], fmt.Errorf("problem migrating to schema version %d: %w", m.Version, err)
+		}
+		if err := d.SetVersion(m.Version); err != nil {
+			return pending[:i], err
+		}
+	}
+	return pending, nil
+}
+
+// Path returns the path of the file in the directory.
+func (d *Dir) Path(name string) string {
+	return filepath.Join(d.path, name)
+}
+
+// OpenFile opens a data file for reading and writing, creating it and its directory with no data
+// if they don't exist.
+func OpenFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("problem creating directory of %s: %w", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640)
+	if os.IsExist(err) {
+		return os.OpenFile(path, os.O_RDWR, 0640)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.WriteString(emptyData); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("problem initializing %s: %w", path, err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// Close releases the lock of the directory.
+func (d *Dir) Close() error {
+	_ = syscall.Flock(int(d.lock.Fd()), syscall.LOCK_UN)
+	return d.lock.Close()
+}
@@ -0,0 +1,164 @@
+package datadir
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "datadir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestOpen(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "data")
+
+	d, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := d.Version(); err != nil || v != SchemaVersion {
+		t.Errorf("Version() = %d, %v, want %d", v, err, SchemaVersion)
+	}
+	if _, err := Open(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("Open() of a locked directory error = %v, want %v", err, ErrLocked)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open() after Close(): %v", err)
+	}
+	d.Close()
+}
+
+func TestOpenVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr string
+	}{
+		{name: "current", version: "2\n"},
+		{name: "older", version: "1\n"},
+		{name: "newer", version: "3\n", wantErr: "supports up to 2"},
+		{name: "invalid", version: "one\n", wantErr: "invalid schema version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, cleanup := tempDir(t)
+			defer cleanup()
+			if err := ioutil.WriteFile(filepath.Join(dir, versionFile), []byte(tt.version), 0640); err != nil {
+				t.Fatal(err)
+			}
+			d, err := Open(dir)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				d.Close()
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Open() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOpenFile(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "sub", "users.json")
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != emptyData {
+		t.Errorf("created file contains %q, want %q", b, emptyData)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"a":{}}`), 0640); err != nil {
+		t.Fatal(err)
+	}
+	f, err = OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = ioutil.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"a":{}}` {
+		t.Errorf("existing file contains %q, want it unchanged", b)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	d, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	var applied []int
+	migration := func(v int, err error) Migration {
+		return Migration{Version: v, Apply: func(d *Dir) error {
+			if err == nil {
+				applied = append(applied, v)
+			}
+			return err
+		}}
+	}
+
+	if _, err := d.Pending([]Migration{migration(SchemaVersion+2, nil)}); err == nil {
+		t.Error("Pending() with a missing version succeeded")
+	}
+
+	failure := errors.New("failure")
+	done, err := d.Migrate([]Migration{migration(SchemaVersion, nil), migration(SchemaVersion+1, nil), migration(SchemaVersion+2, failure)})
+	if !errors.Is(err, failure) {
+		t.Errorf("Migrate() error = %v, want %v", err, failure)
+	}
+	if len(done) != 1 || len(applied) != 1 || applied[0] != SchemaVersion+1 {
+		t.Errorf("Migrate() applied %v, returned %d migrations, want only version %d", applied, len(done), SchemaVersion+1)
+	}
+	if v, _ := d.Version(); v != SchemaVersion+1 {
+		t.Errorf("Version() = %d after the failed migration, want %d", v, SchemaVersion+1)
+	}
+}
+
+func TestOpenUnversioned(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	if err := ioutil.WriteFile(filepath.Join(dir, "users.json"), []byte("{}"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	d, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if v, _ := d.Version(); v != unversioned {
+		t.Errorf("Version() = %d, want %d", v, unversioned)
+	}
+}
@@ -0,0 +1,149 @@
+// Package egress keeps the requests made to the URLs given by the callers, like the webhooks, from
+// reaching the network of the service: the loopback, private, link-local and other special
+// addresses are refused unless their network is allowed.
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbidden is returned when an address isn't allowed.
+var ErrForbidden = errors.New("address not allowed")
+
+// blocked are the networks that aren't reachable on the internet, or shouldn't be reached from it.
+var blocked = mustParseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// ParseNetworks parses networks written in CIDR notation, separated by commas.
+func ParseNetworks(s string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		_, n, err := net.ParseCIDR(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q, expected an address/prefix like 10.0.0.0/8", field)
+		}
+		networks = append(networks, n)
+	}
+	return networks, nil
+}
+
+func mustParseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = n
+	}
+	return networks
+}
+
+// Guard checks the addresses reached.
+type Guard struct {
+	allowed []*net.IPNet
+}
+
+// New returns the guard refusing the special addresses, but the ones of the allowed networks.
+func New(allowed []*net.IPNet) *Guard {
+	return &Guard{allowed: allowed}
+}
+
+// Allowed reports whether the address can be reached.
+func (g *Guard) Allowed(ip net.IP) bool {
+	for _, n := range g.allowed {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	for _, n := range blocked {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL checks that the URL is an http or https URL whose host only resolves to allowed
+// addresses. The host can resolve to other addresses later, the connections made by Client are
+// checked too.
+func (g *Guard) CheckURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("expected an http or https URL")
+	}
+	host := u.Hostname()
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return fmt.Errorf("problem resolving %s: %w", host, err)
+		}
+		for _, a := range addrs {
+			ips = append(ips, a.IP)
+		}
+	}
+	for _, ip := range ips {
+		if !g.Allowed(ip) {
+			return fmt.Errorf("%w: %s", ErrForbidden, ip)
+		}
+	}
+	return nil
+}
+
+// Client returns an HTTP client only connecting to the allowed addresses. The address is checked
+// once resolved, when connecting, and for the redirects too. Proxies aren't used, they'd connect
+// instead of the client.
+func (g *Guard) Client() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   g.control,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport}
+}
+
+func (g *Guard) control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !g.Allowed(ip) {
+		return fmt.Errorf("%w: %s", ErrForbidden, host)
+	}
+	return nil
+}
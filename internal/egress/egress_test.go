@@ -0,0 +1,99 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAllowed(t *testing.T) {
+	allowed, err := ParseNetworks("10.1.0.0/16, fd00:1::/32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := New(allowed)
+	cases := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1::1", want: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "::ffff:127.0.0.1"},
+		{ip: "0.0.0.0"},
+		{ip: "10.0.0.1"},
+		{ip: "172.16.5.4"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "fd12::1"},
+		{ip: "100.64.0.1"},
+		{ip: "10.1.2.3", want: true},
+		{ip: "fd00:1::1", want: true},
+	}
+	for _, tc := range cases {
+		if got := g.Allowed(net.ParseIP(tc.ip)); got != tc.want {
+			t.Errorf("Allowed(%s) = %t, want %t", tc.ip, got, tc.want)
+		}
+	}
+}
+
+func TestParseNetworks(t *testing.T) {
+	if n, err := ParseNetworks(""); err != nil || len(n) != 0 {
+		t.Errorf("ParseNetworks() = %v, %v, want none", n, err)
+	}
+	if _, err := ParseNetworks("10.0.0.0/8,10.0.0.1"); err == nil {
+		t.Error("expected an error without prefix")
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	g := New(nil)
+	cases := []struct {
+		url       string
+		forbidden bool
+		invalid   bool
+	}{
+		{url: "https://93.184.216.34/hook"},
+		{url: "http://127.0.0.1:8080/hook", forbidden: true},
+		{url: "http://[::1]/hook", forbidden: true},
+		{url: "http://169.254.169.254/latest/meta-data", forbidden: true},
+		{url: "http://localhost/hook", forbidden: true},
+		{url: "ftp://93.184.216.34/hook", invalid: true},
+		{url: "https:///hook", invalid: true},
+	}
+	for _, tc := range cases {
+		err := g.CheckURL(context.Background(), tc.url)
+		switch {
+		case tc.forbidden && !errors.Is(err, ErrForbidden):
+			t.Errorf("%s: expected ErrForbidden, got %v", tc.url, err)
+		case tc.invalid && err == nil:
+			t.Errorf("%s: expected an error", tc.url)
+		case !tc.forbidden && !tc.invalid && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.url, err)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// the connections are checked, not only the URLs registered
+	if _, err := New(nil).Client().Get(srv.URL); !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	allowed, err := ParseNetworks("127.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := New(allowed).Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
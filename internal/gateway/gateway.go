@@ -0,0 +1,340 @@
+// Package gateway exposes the RPC services as RESTful JSON endpoints. Requests are translated and
+// forwarded to the gRPC server through a client connection so they go through the same
+// interceptors as native gRPC calls.
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataHeaderPrefix is the prefix of the HTTP headers forwarded as gRPC metadata, along with
+// the Authorization, Traceparent and X-Request-Id headers.
+const MetadataHeaderPrefix = "Grpc-Metadata-"
+
+// RequestIDHeader identifies the calls in the logs and the traces, it's generated when missing and
+// sent back in the response.
+const RequestIDHeader = "X-Request-Id"
+
+// MaxBodySize is the size in bytes of the largest body read, the default largest message received
+// by the gRPC server.
+const MaxBodySize = 4 << 20
+
+type handler func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type route struct {
+	Route
+	segments []string
+	verb     string
+	handler  handler
+}
+
+// Gateway is the http.Handler translating the REST calls.
+type Gateway struct {
+	routes      []route
+	marshaler   *jsonpb.Marshaler
+	unmarshaler *jsonpb.Unmarshaler
+}
+
+// New returns the gateway forwarding the calls through the connection, which is only used once
+// requests are served.
+func New(conn *grpc.ClientConn) *Gateway {
+	g := &Gateway{
+		marshaler:   &jsonpb.Marshaler{},
+		unmarshaler: &jsonpb.Unmarshaler{},
+	}
+	g.registerUsers(conn)
+	g.registerRequests(conn)
+	g.registerNews(conn)
+	return g
+}
+
+// ResponseKind describes how the responses of the RPC are written.
+type ResponseKind int
+
+const (
+	// Single is the JSON response of an unary RPC
+	Single ResponseKind = iota
+	// List is a JSON array of the responses of a server stream
+	List
+	// Stream is the newline delimited JSON responses of a server stream, written as they arrive
+	Stream
+)
+
+// QueryParam is a query parameter accepted by an endpoint.
+type QueryParam struct {
+	Name string
+	// Field is the field of the RPC request set from the parameter, empty when the parameter
+	// selects another RPC (e.g. ?postcode= searching by postcode)
+	Field string
+}
+
+// Route describes an endpoint of the gateway.
+type Route struct {
+	Method string
+	// Path is the path template, e.g. /v1/requests/{request_id}:accept
+	Path string
+	// RPC is the full name of the method called, e.g. /requestspb.RequestsRPC/AcceptHelp
+	RPC string
+	// Body is the field of the RPC request read from the body, * for the whole request, empty
+	// when the body is ignored
+	Body     string
+	Query    []QueryParam
+	Response ResponseKind
+}
+
+// Routes returns the endpoints exposed by the gateway.
+func (g *Gateway) Routes() []Route {
+	routes := make([]Route, len(g.routes))
+	for i, rt := range g.routes {
+		routes[i] = rt.Route
+	}
+	return routes
+}
+
+func (g *Gateway) handle(rt Route, h handler) {
+	segments, verb := split(rt.Path)
+	g.routes = append(g.routes, route{
+		Route:    rt,
+		segments: segments,
+		verb:     verb,
+		handler:  h,
+	})
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(RequestIDHeader) == "" {
+		r.Header.Set(RequestIDHeader, uuid.New().String())
+	}
+	w.Header().Set(RequestIDHeader, r.Header.Get(RequestIDHeader))
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+
+	segments, verb := split(r.URL.Path)
+	var pathFound bool
+	for _, rt := range g.routes {
+		params, ok := rt.match(segments, verb)
+		if !ok {
+			continue
+		}
+		pathFound = true
+		if rt.Method != r.Method {
+			continue
+		}
+		rt.handler(w, r, params)
+		return
+	}
+	if pathFound {
+		g.writeStatus(w, http.StatusMethodNotAllowed, status.New(codes.Unimplemented, "method not allowed"))
+		return
+	}
+	g.writeError(w, status.Error(codes.NotFound, "not found"))
+}
+
+func (rt route) match(segments []string, verb string) (map[string]string, bool) {
+	if len(segments) != len(rt.segments) || verb != rt.verb {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, s := range rt.segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[s[1:len(s)-1]] = segments[i]
+			continue
+		}
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// split returns the segments of the path and its custom verb (/v1/requests/{id}:accept).
+func split(path string) ([]string, string) {
+	path = strings.Trim(path, "/")
+	var verb string
+	last := strings.LastIndex(path, "/")
+	if i := strings.LastIndex(path, ":"); i > last {
+		path, verb = path[:i], path[i+1:]
+	}
+	return strings.Split(path, "/"), verb
+}
+
+// reserved is the metadata the services trust from the gateway, it isn't taken from the headers.
+var reserved = map[string]bool{"x-forwarded-for": true, "x-actor": true}
+
+// outgoingContext forwards the Authorization, Traceparent and X-Request-Id headers and the headers
+// prefixed with MetadataHeaderPrefix as gRPC metadata, but the reserved ones. The address of the
+// HTTP client is sent as x-forwarded-for.
+func outgoingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for k, vs := range r.Header {
+		switch {
+		case k == "Authorization", k == "Traceparent", k == RequestIDHeader:
+			md.Append(strings.ToLower(k), vs...)
+		case strings.HasPrefix(k, MetadataHeaderPrefix):
+			if key := strings.ToLower(strings.TrimPrefix(k, MetadataHeaderPrefix)); !reserved[key] {
+				md.Append(key, vs...)
+			}
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		md.Append("x-forwarded-for", host)
+	}
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// decode unmarshals the JSON body of the request into msg, an empty body leaves msg untouched.
+func (g *Gateway) decode(r *http.Request, msg proto.Message) error {
+	var b bytes.Buffer
+	if _, err := io.Copy(&b, r.Body); err != nil {
+		if b.Len() >= MaxBodySize {
+			return status.Errorf(codes.InvalidArgument, "body larger than %d bytes", MaxBodySize)
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(bytes.TrimSpace(b.Bytes())) == 0 {
+		return nil
+	}
+	if err := g.unmarshaler.Unmarshal(&b, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid body: %s", err)
+	}
+	return nil
+}
+
+func (g *Gateway) write(w http.ResponseWriter, msg proto.Message) {
+	var b bytes.Buffer
+	if err := g.marshaler.Marshal(&b, msg); err != nil {
+		g.writeError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b.Bytes())
+}
+
+// writeList writes the messages received from a server stream as a JSON array.
+func (g *Gateway) writeList(w http.ResponseWriter, recv func() (proto.Message, error)) {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := 0; ; i++ {
+		msg, err := recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if err := g.marshaler.Marshal(&b, msg); err != nil {
+			g.writeError(w, status.Error(codes.Internal, err.Error()))
+			return
+		}
+	}
+	b.WriteByte(']')
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b.Bytes())
+}
+
+// writeStream writes the messages received from a server stream as they arrive, as newline
+// delimited JSON. Errors happening once the stream started are sent as a last {"error": ...} line.
+func (g *Gateway) writeStream(w http.ResponseWriter, recv func() (proto.Message, error)) {
+	flusher, _ := w.(http.Flusher)
+	var started bool
+	for {
+		msg, err := recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			if !started {
+				g.writeError(w, err)
+				return
+			}
+			if s := status.Convert(err); s.Code() != codes.Canceled {
+				var b bytes.Buffer
+				b.WriteString(`{"error":`)
+				_ = g.marshaler.Marshal(&b, s.Proto())
+				b.WriteString("}\n")
+				_, _ = w.Write(b.Bytes())
+			}
+			return
+		}
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			started = true
+		}
+		var b bytes.Buffer
+		if err := g.marshaler.Marshal(&b, msg); err != nil {
+			return
+		}
+		b.WriteByte('\n')
+		if _, err := w.Write(b.Bytes()); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// writeError writes the gRPC status as JSON with the matching HTTP status code.
+func (g *Gateway) writeError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	g.writeStatus(w, HTTPStatusFromCode(s.Code()), s)
+}
+
+func (g *Gateway) writeStatus(w http.ResponseWriter, code int, s *status.Status) {
+	var b bytes.Buffer
+	_ = g.marshaler.Marshal(&b, s.Proto())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(b.Bytes())
+}
+
+// HTTPStatusFromCode maps the gRPC status codes to HTTP status codes.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
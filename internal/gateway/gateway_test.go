@@ -0,0 +1,343 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	newsService "github.com/euvsvirus-banan/backend/news/pkg/service"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	requestsService "github.com/euvsvirus-banan/backend/requests/pkg/service"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	usersService "github.com/euvsvirus-banan/backend/users/pkg/service"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func getTestGateway(t *testing.T, opts ...grpc.ServerOption) (*httptest.Server, func()) {
+	logger := logrus.NewEntry(logrus.New())
+	userData := storagetest.Users(map[string]*userspb.User{
+		"Brown": {
+			Name:    "Brown",
+			Address: &userspb.User_Address{Postcode: "12345"},
+		},
+		"Blue": {
+			Name:    "Blue",
+			Address: &userspb.User_Address{Postcode: "54321"},
+		},
+	})
+	requestData := storagetest.Requests(map[string]*requestspb.Request{
+		"a": {
+			Title:       "help with groceries",
+			Postcode:    "12345",
+			RequesterId: "Brown",
+			State:       requestspb.Request_WAITING,
+		},
+	})
+	newsData := storagetest.News(nil)
+	preferenceData := storagetest.Preferences(nil)
+	deadLetterData := storagetest.DeadLetters(nil)
+
+	grpcServer := grpc.NewServer(opts...)
+	userspb.RegisterUsersRPCServer(grpcServer, usersService.New(logger, userData, requestData, preferenceData, deadLetterData, ""))
+	requestspb.RegisterRequestsRPCServer(grpcServer, requestsService.New(logger, requestData, userData))
+	newspb.RegisterNewsRPCServer(grpcServer, newsService.New(logger, newsData))
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	conn, err := grpc.Dial(
+		"bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(New(conn))
+	return srv, func() {
+		srv.Close()
+		conn.Close()
+		grpcServer.Stop()
+	}
+}
+
+func do(t *testing.T, srv *httptest.Server, method, path, body string) (int, string) {
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(b)
+}
+
+func TestGateway(t *testing.T) {
+	srv, stop := getTestGateway(t)
+	defer stop()
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		resp   string
+	}{
+		{
+			name:   "get request",
+			method: http.MethodGet,
+			path:   "/v1/requests/a",
+			status: http.StatusOK,
+			resp:   `{"request":{"title":"help with groceries","requesterId":"Brown","postcode":"12345"}}`,
+		},
+		{
+			name:   "search requests",
+			method: http.MethodGet,
+			path:   "/v1/requests?postcode=12345",
+			status: http.StatusOK,
+			resp:   `[{"requestId":"a","request":{"title":"help with groceries","requesterId":"Brown","postcode":"12345"}}]`,
+		},
+		{
+			name:   "search requests without results",
+			method: http.MethodGet,
+			path:   "/v1/requests?postcode=99999",
+			status: http.StatusOK,
+			resp:   `[]`,
+		},
+		{
+			name:   "answer request",
+			method: http.MethodPost,
+			path:   "/v1/requests/a:answer",
+			body:   `{"volunteerId":"Blue","comment":"on my way"}`,
+			status: http.StatusOK,
+			resp:   `{}`,
+		},
+		{
+			name:   "accept help",
+			method: http.MethodPost,
+			path:   "/v1/requests/a:accept",
+			body:   `{"volunteerId":"Blue"}`,
+			status: http.StatusOK,
+			resp:   `{}`,
+		},
+		{
+			name:   "accepted request",
+			method: http.MethodGet,
+			path:   "/v1/requests/a",
+			status: http.StatusOK,
+			resp:   `{"request":{"title":"help with groceries","requesterId":"Brown","volunteerId":"Blue","postcode":"12345","state":"ACCEPTED","answers":[{"volunteerId":"Blue","comment":"on my way"}],"stateDate":"<date>"}}`,
+		},
+		{
+			name:   "rate before completion",
+			method: http.MethodPost,
+			path:   "/v1/requests/a:rate",
+			body:   `{"raterId":"Brown","score":5}`,
+			status: http.StatusPreconditionFailed,
+			resp:   `{"code":9,"message":"only completed requests can be rated"}`,
+		},
+		{
+			name:   "unknown request",
+			method: http.MethodGet,
+			path:   "/v1/requests/b",
+			status: http.StatusNotFound,
+			resp:   `{"code":5,"message":"request not found"}`,
+		},
+		{
+			name:   "invalid body",
+			method: http.MethodPost,
+			path:   "/v1/requests/a:accept",
+			body:   `{"volunteer":"Blue"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "method not allowed",
+			method: http.MethodPatch,
+			path:   "/v1/requests/a",
+			status: http.StatusMethodNotAllowed,
+			resp:   `{"code":12,"message":"method not allowed"}`,
+		},
+		{
+			name:   "unknown path",
+			method: http.MethodGet,
+			path:   "/v1/unknown",
+			status: http.StatusNotFound,
+			resp:   `{"code":5,"message":"not found"}`,
+		},
+		{
+			name:   "user reputation",
+			method: http.MethodGet,
+			path:   "/v1/users/Blue/reputation",
+			status: http.StatusOK,
+			resp:   `{"reputation":{}}`,
+		},
+		{
+			name:   "grant consent",
+			method: http.MethodPost,
+			path:   "/v1/users/Brown/consent:grant",
+			body:   `{"purposes": ["CONTACT_SHARING", "MARKETING"], "source": "web"}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "revoke consent",
+			method: http.MethodPost,
+			path:   "/v1/users/Brown/consent:revoke",
+			body:   `{"purposes": ["MARKETING"]}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "grant unknown purpose",
+			method: http.MethodPost,
+			path:   "/v1/users/Brown/consent:grant",
+			body:   `{"purposes": ["SPAM"]}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "get consent",
+			method: http.MethodGet,
+			path:   "/v1/users/Brown/consent",
+			status: http.StatusOK,
+		},
+	}
+
+	// the dates set by the services vary
+	dates := regexp.MustCompile(`"stateDate":"[^"]+"`)
+	for _, tc := range cases {
+		status, resp := do(t, srv, tc.method, tc.path, tc.body)
+		resp = dates.ReplaceAllString(resp, `"stateDate":"<date>"`)
+		if status != tc.status {
+			t.Errorf("%s: expected status %d, got %d: %s", tc.name, tc.status, status, resp)
+		}
+		if tc.resp != "" && !cmp.Equal(resp, tc.resp) {
+			t.Errorf("%s: %s", tc.name, cmp.Diff(tc.resp, resp))
+		}
+	}
+}
+
+func TestGatewayMetadata(t *testing.T) {
+	var md metadata.MD
+	srv, stop := getTestGateway(t, grpc.UnaryInterceptor(
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			md, _ = metadata.FromIncomingContext(ctx)
+			return handler(ctx, req)
+		},
+	))
+	defer stop()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/v1/version", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set(MetadataHeaderPrefix+"Tenant", "42")
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("X-Ignored", "1")
+	// the metadata the services trust can't be sent by the clients
+	req.Header.Set(MetadataHeaderPrefix+"X-Forwarded-For", "203.0.113.9")
+	req.Header.Set(MetadataHeaderPrefix+"X-Actor", "admin")
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	requestID := resp.Header.Get(RequestIDHeader)
+	if requestID == "" {
+		t.Errorf("expected a request id to be generated")
+	}
+	for k, v := range map[string]string{
+		"authorization":   "Bearer token",
+		"tenant":          "42",
+		"traceparent":     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"x-request-id":    requestID,
+		"x-forwarded-for": "127.0.0.1",
+	} {
+		if got := md.Get(k); len(got) != 1 || got[0] != v {
+			t.Errorf("expected %s metadata to be %q, got %v", k, v, got)
+		}
+	}
+	for _, k := range []string{"x-ignored", "x-actor"} {
+		if got := md.Get(k); len(got) != 0 {
+			t.Errorf("unexpected %s metadata: %v", k, got)
+		}
+	}
+}
+
+func TestGatewayBodySize(t *testing.T) {
+	srv, stop := getTestGateway(t)
+	defer stop()
+
+	body := `{"name": "` + strings.Repeat("a", MaxBodySize) + `"}`
+	code, got := do(t, srv, http.MethodPost, "/v1/users", body)
+	if code != http.StatusBadRequest || !strings.Contains(got, "body larger than") {
+		t.Errorf("expected the body to be refused, got %d %s", code, got)
+	}
+}
+
+func TestGatewayWatch(t *testing.T) {
+	srv, stop := getTestGateway(t)
+	defer stop()
+
+	for _, body := range []string{
+		`{"title":"shop opening hours","priority":"NORMAL"}`,
+		`{"title":"water outage","priority":"URGENT"}`,
+	} {
+		if status, resp := do(t, srv, http.MethodPost, "/v1/news", body); status != http.StatusOK {
+			t.Fatalf("problem adding news: %d %s", status, resp)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/v1/news:watch?minPriority=HIGH&replay=10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Client().Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event struct {
+		Replayed bool `json:"replayed"`
+		News     struct {
+			Title string `json:"title"`
+		} `json:"news"`
+	}
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		t.Fatal(err)
+	}
+	if !event.Replayed || event.News.Title != "water outage" {
+		t.Errorf("unexpected event: %s", line)
+	}
+}
@@ -0,0 +1,151 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (g *Gateway) registerNews(conn *grpc.ClientConn) { // nolint: funlen
+	client := newspb.NewNewsRPCClient(conn)
+
+	g.handle(Route{
+		Method:   http.MethodGet,
+		Path:     "/v1/news",
+		RPC:      "/newspb.NewsRPC/GetNews",
+		Query:    []QueryParam{{Name: "postcode"}},
+		Response: List,
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if postcode := r.URL.Query().Get("postcode"); postcode != "" {
+			stream, err := client.SearchNewsByPostcode(outgoingContext(r), &newspb.SearchNewsByPostcodeRequest{
+				Postcode: postcode,
+			})
+			if err != nil {
+				g.writeError(w, err)
+				return
+			}
+			g.writeList(w, func() (proto.Message, error) { return stream.Recv() })
+			return
+		}
+		stream, err := client.GetNews(outgoingContext(r), &newspb.GetNewsRequest{})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.writeList(w, func() (proto.Message, error) { return stream.Recv() })
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/news",
+		RPC:    "/newspb.NewsRPC/AddNew",
+		Body:   "new",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &newspb.AddNewRequest{New: &newspb.News{}}
+		if err := g.decode(r, req.New); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		resp, err := client.AddNew(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodGet,
+		Path:   "/v1/news:watch",
+		RPC:    "/newspb.NewsRPC/WatchNews",
+		Query: []QueryParam{
+			{Name: "postcode", Field: "postcodes"},
+			{Name: "region", Field: "regions"},
+			{Name: "minPriority", Field: "min_priority"},
+			{Name: "replay", Field: "replay"},
+		},
+		Response: Stream,
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		q := r.URL.Query()
+		req := &newspb.WatchNewsRequest{
+			Postcodes: q["postcode"],
+			Regions:   q["region"],
+		}
+		if p := q.Get("minPriority"); p != "" {
+			v, ok := newspb.News_Priority_value[p]
+			if !ok {
+				g.writeError(w, status.Error(codes.InvalidArgument, "invalid minPriority"))
+				return
+			}
+			req.MinPriority = newspb.News_Priority(v)
+		}
+		if replay := q.Get("replay"); replay != "" {
+			v, err := strconv.ParseUint(replay, 10, 32)
+			if err != nil {
+				g.writeError(w, status.Error(codes.InvalidArgument, "invalid replay"))
+				return
+			}
+			req.Replay = uint32(v)
+		}
+		stream, err := client.WatchNews(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.writeStream(w, func() (proto.Message, error) { return stream.Recv() })
+	})
+
+	g.handle(Route{
+		Method: http.MethodGet,
+		Path:   "/v1/news/{news_id}",
+		RPC:    "/newspb.NewsRPC/GetNewsByID",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.GetNewsByID(outgoingContext(r), &newspb.GetNewsByIDRequest{
+			NewsId: params["news_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPut,
+		Path:   "/v1/news/{news_id}",
+		RPC:    "/newspb.NewsRPC/UpdateNew",
+		Body:   "new",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &newspb.UpdateNewRequest{NewId: params["news_id"], New: &newspb.News{}}
+		if err := g.decode(r, req.New); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		resp, err := client.UpdateNew(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodDelete,
+		Path:   "/v1/news/{news_id}",
+		RPC:    "/newspb.NewsRPC/DeleteNew",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.DeleteNew(outgoingContext(r), &newspb.DeleteNewRequest{
+			NewId: params["news_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+}
@@ -0,0 +1,222 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+func (g *Gateway) registerRequests(conn *grpc.ClientConn) { // nolint: funlen
+	client := requestspb.NewRequestsRPCClient(conn)
+
+	g.handle(Route{
+		Method:   http.MethodGet,
+		Path:     "/v1/requests",
+		RPC:      "/requestspb.RequestsRPC/GetRequests",
+		Query:    []QueryParam{{Name: "postcode"}},
+		Response: List,
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if postcode := r.URL.Query().Get("postcode"); postcode != "" {
+			stream, err := client.SearchRequestsByPostcode(outgoingContext(r), &requestspb.SearchRequestsByPostcodeRequest{
+				Postcode: postcode,
+			})
+			if err != nil {
+				g.writeError(w, err)
+				return
+			}
+			g.writeList(w, func() (proto.Message, error) { return stream.Recv() })
+			return
+		}
+		stream, err := client.GetRequests(outgoingContext(r), &requestspb.GetRequestsRequest{})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.writeList(w, func() (proto.Message, error) { return stream.Recv() })
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/requests",
+		RPC:    "/requestspb.RequestsRPC/AddRequest",
+		Body:   "request",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &requestspb.AddRequestRequest{Request: &requestspb.Request{}}
+		if err := g.decode(r, req.Request); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		resp, err := client.AddRequest(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodGet,
+		Path:   "/v1/requests:watch",
+		RPC:    "/requestspb.RequestsRPC/WatchRequests",
+		Query: []QueryParam{
+			{Name: "postcode", Field: "postcodes"},
+			{Name: "skill", Field: "skills"},
+			{Name: "category", Field: "categories"},
+			{Name: "resumeToken", Field: "resume_token"},
+		},
+		Response: Stream,
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		q := r.URL.Query()
+		stream, err := client.WatchRequests(outgoingContext(r), &requestspb.WatchRequestsRequest{
+			Postcodes:   q["postcode"],
+			Skills:      q["skill"],
+			Categories:  q["category"],
+			ResumeToken: q.Get("resumeToken"),
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.writeStream(w, func() (proto.Message, error) { return stream.Recv() })
+	})
+
+	g.handle(Route{
+		Method: http.MethodGet,
+		Path:   "/v1/requests/{request_id}",
+		RPC:    "/requestspb.RequestsRPC/GetRequestByID",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.GetRequestByID(outgoingContext(r), &requestspb.GetRequestByIDRequest{
+			RequestId: params["request_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPut,
+		Path:   "/v1/requests/{request_id}",
+		RPC:    "/requestspb.RequestsRPC/UpdateRequest",
+		Body:   "request",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &requestspb.UpdateRequestRequest{RequestId: params["request_id"], Request: &requestspb.Request{}}
+		if err := g.decode(r, req.Request); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		resp, err := client.UpdateRequest(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodDelete,
+		Path:   "/v1/requests/{request_id}",
+		RPC:    "/requestspb.RequestsRPC/DeleteRequest",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.DeleteRequest(outgoingContext(r), &requestspb.DeleteRequestRequest{
+			RequestId: params["request_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/requests/{request_id}:answer",
+		RPC:    "/requestspb.RequestsRPC/AnswerRequest",
+		Body:   "answer",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &requestspb.AnswerRequestRequest{RequestId: params["request_id"], Answer: &requestspb.Request_Answer{}}
+		if err := g.decode(r, req.Answer); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		resp, err := client.AnswerRequest(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/requests/{request_id}:accept",
+		RPC:    "/requestspb.RequestsRPC/AcceptHelp",
+		Body:   "*",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &requestspb.AcceptHelpRequest{}
+		if err := g.decode(r, req); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		req.RequestId = params["request_id"]
+		resp, err := client.AcceptHelp(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/requests/{request_id}:complete",
+		RPC:    "/requestspb.RequestsRPC/CompleteHelp",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.CompleteHelp(outgoingContext(r), &requestspb.CompleteHelpRequest{
+			RequestId: params["request_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/requests/{request_id}:cancel",
+		RPC:    "/requestspb.RequestsRPC/CancelHelp",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.CancelHelp(outgoingContext(r), &requestspb.CancelHelpRequest{
+			RequestId: params["request_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/requests/{request_id}:rate",
+		RPC:    "/requestspb.RequestsRPC/RateHelp",
+		Body:   "*",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &requestspb.RateHelpRequest{}
+		if err := g.decode(r, req); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		req.RequestId = params["request_id"]
+		resp, err := client.RateHelp(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+}
@@ -0,0 +1,204 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (g *Gateway) registerUsers(conn *grpc.ClientConn) { // nolint: funlen
+	client := userspb.NewUsersRPCClient(conn)
+
+	g.handle(Route{
+		Method: http.MethodGet,
+		Path:   "/v1/version",
+		RPC:    "/userspb.UsersRPC/GetVersion",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.GetVersion(outgoingContext(r), &userspb.GetVersionRequest{})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method:   http.MethodGet,
+		Path:     "/v1/users",
+		RPC:      "/userspb.UsersRPC/GetUsers",
+		Query:    []QueryParam{{Name: "postcode"}},
+		Response: List,
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if postcode := r.URL.Query().Get("postcode"); postcode != "" {
+			stream, err := client.SearchUsersByPostcode(outgoingContext(r), &userspb.SearchUsersByPostcodeRequest{
+				Postcode: postcode,
+			})
+			if err != nil {
+				g.writeError(w, err)
+				return
+			}
+			g.writeList(w, func() (proto.Message, error) { return stream.Recv() })
+			return
+		}
+		stream, err := client.GetUsers(outgoingContext(r), &userspb.GetUsersRequest{})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.writeList(w, func() (proto.Message, error) { return stream.Recv() })
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/users",
+		RPC:    "/userspb.UsersRPC/AddUser",
+		Body:   "user",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &userspb.AddUserRequest{User: &userspb.User{}}
+		if err := g.decode(r, req.User); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		resp, err := client.AddUser(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodGet,
+		Path:   "/v1/users/{user_id}",
+		RPC:    "/userspb.UsersRPC/GetUserByID",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.GetUserByID(outgoingContext(r), &userspb.GetUserByIDRequest{
+			UserId: params["user_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPut,
+		Path:   "/v1/users/{user_id}",
+		RPC:    "/userspb.UsersRPC/UpdateUser",
+		Body:   "user",
+		Query: []QueryParam{
+			{Name: "clearContactDetails", Field: "clear_contact_details"},
+		},
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &userspb.UpdateUserRequest{UserId: params["user_id"], User: &userspb.User{}}
+		if clear := r.URL.Query().Get("clearContactDetails"); clear != "" {
+			v, err := strconv.ParseBool(clear)
+			if err != nil {
+				g.writeError(w, status.Error(codes.InvalidArgument, "invalid clearContactDetails"))
+				return
+			}
+			req.ClearContactDetails = v
+		}
+		if err := g.decode(r, req.User); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		resp, err := client.UpdateUser(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodDelete,
+		Path:   "/v1/users/{user_id}",
+		RPC:    "/userspb.UsersRPC/DeleteUser",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.DeleteUser(outgoingContext(r), &userspb.DeleteUserRequest{
+			UserId: params["user_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodGet,
+		Path:   "/v1/users/{user_id}/reputation",
+		RPC:    "/userspb.UsersRPC/GetUserReputation",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.GetUserReputation(outgoingContext(r), &userspb.GetUserReputationRequest{
+			UserId: params["user_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodGet,
+		Path:   "/v1/users/{user_id}/consent",
+		RPC:    "/userspb.UsersRPC/GetConsent",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		resp, err := client.GetConsent(outgoingContext(r), &userspb.GetConsentRequest{
+			UserId: params["user_id"],
+		})
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/users/{user_id}/consent:grant",
+		RPC:    "/userspb.UsersRPC/GrantConsent",
+		Body:   "*",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &userspb.GrantConsentRequest{}
+		if err := g.decode(r, req); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		req.UserId = params["user_id"]
+		resp, err := client.GrantConsent(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+
+	g.handle(Route{
+		Method: http.MethodPost,
+		Path:   "/v1/users/{user_id}/consent:revoke",
+		RPC:    "/userspb.UsersRPC/RevokeConsent",
+		Body:   "*",
+	}, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := &userspb.RevokeConsentRequest{}
+		if err := g.decode(r, req); err != nil {
+			g.writeError(w, err)
+			return
+		}
+		req.UserId = params["user_id"]
+		resp, err := client.RevokeConsent(outgoingContext(r), req)
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+		g.write(w, resp)
+	})
+}
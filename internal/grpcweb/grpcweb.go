@@ -0,0 +1,66 @@
+// Package grpcweb serves the RPC services to browsers through the gRPC-Web protocol, so the
+// generated gRPC-Web stubs can be used directly from the frontend.
+package grpcweb
+
+import (
+	"net/http"
+	"strings"
+
+	improbable "github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// AllowAllOrigins allows cross-origin requests from any origin when given as an allowed origin.
+const AllowAllOrigins = "*"
+
+// allowedHeaders are the headers the browsers are allowed to send, the ones set by the gRPC-Web
+// clients, the authorization and the tracing headers.
+var allowedHeaders = []string{
+	"authorization",
+	"content-type",
+	"grpc-timeout",
+	"traceparent",
+	"x-grpc-web",
+	"x-request-id",
+	"x-user-agent",
+}
+
+// New returns the handler translating the gRPC-Web requests, including the CORS preflight
+// requests, for the given services of the server, named like "requestspb.RequestsRPC". The other
+// services registered on the server aren't reachable. Requests from origins that aren't allowed get
+// no CORS headers and are rejected by the browsers.
+func New(server *grpc.Server, services []string, allowedOrigins []string) http.Handler {
+	wrapped := improbable.WrapServer(
+		server,
+		improbable.WithOriginFunc(originFunc(allowedOrigins)),
+		improbable.WithAllowedRequestHeaders(allowedHeaders),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if served(services, r.URL.Path) && (wrapped.IsGrpcWebRequest(r) || wrapped.IsAcceptableGrpcCorsRequest(r)) {
+			wrapped.ServeHTTP(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
+
+// served tells whether the path, "/<service>/<method>", is a method of one of the services.
+func served(services []string, path string) bool {
+	for _, s := range services {
+		if strings.HasPrefix(path, "/"+s+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func originFunc(allowedOrigins []string) func(origin string) bool {
+	return func(origin string) bool {
+		for _, o := range allowedOrigins {
+			if o == AllowAllOrigins || strings.EqualFold(o, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
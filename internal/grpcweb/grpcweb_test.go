@@ -0,0 +1,255 @@
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	newsService "github.com/euvsvirus-banan/backend/news/pkg/service"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	requestsService "github.com/euvsvirus-banan/backend/requests/pkg/service"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+const allowedOrigin = "https://app.example.com"
+
+var services = []string{"requestspb.RequestsRPC", "newspb.NewsRPC"}
+
+func getTestServer() *httptest.Server {
+	return getTestServerOf(services)
+}
+
+// getTestServerOf serves the requests and news services, only the given ones through gRPC-Web.
+func getTestServerOf(services []string) *httptest.Server {
+	logger := logrus.NewEntry(logrus.New())
+	grpcServer := grpc.NewServer()
+	requestspb.RegisterRequestsRPCServer(grpcServer, requestsService.New(
+		logger,
+		storagetest.Requests(map[string]*requestspb.Request{
+			"a": {Title: "help with groceries", Postcode: "12345"},
+		}),
+		storagetest.Users(nil),
+	))
+	newspb.RegisterNewsRPCServer(grpcServer, newsService.New(
+		logger,
+		storagetest.News(map[string]*newspb.News{
+			"a": {Title: "shop opening hours", Postcode: "12345"},
+			"b": {Title: "water outage", Postcode: "12345"},
+			"c": {Title: "new bus line", Postcode: "54321"},
+		}),
+	))
+	return httptest.NewServer(New(grpcServer, services, []string{allowedOrigin}))
+}
+
+// call makes a gRPC-Web call and returns the messages and the trailers received, including the
+// status sent in the headers by trailers-only responses.
+func call(t *testing.T, srv *httptest.Server, method string, req proto.Message) ([][]byte, string) {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := make([]byte, 5+len(b))
+	binary.BigEndian.PutUint32(body[1:5], uint32(len(b)))
+	copy(body[5:], b)
+
+	httpReq, err := http.NewRequest(http.MethodPost, srv.URL+method, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/grpc-web+proto")
+	httpReq.Header.Set("X-Grpc-Web", "1")
+	httpReq.Header.Set("Origin", allowedOrigin)
+	resp, err := srv.Client().Do(httpReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %s", resp.Status)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != allowedOrigin {
+		t.Errorf("unexpected allowed origin %q", got)
+	}
+
+	var (
+		messages [][]byte
+		trailers string
+	)
+	if s := resp.Header.Get("Grpc-Status"); s != "" {
+		trailers = "grpc-status: " + s + "\r\ngrpc-message: " + resp.Header.Get("Grpc-Message") + "\r\n"
+	}
+	for {
+		header := make([]byte, 5)
+		if _, err := io.ReadFull(resp.Body, header); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		frame := make([]byte, binary.BigEndian.Uint32(header[1:]))
+		if _, err := io.ReadFull(resp.Body, frame); err != nil {
+			t.Fatal(err)
+		}
+		if header[0]&0x80 != 0 {
+			trailers = string(frame)
+			continue
+		}
+		messages = append(messages, frame)
+	}
+	return messages, trailers
+}
+
+func TestUnary(t *testing.T) {
+	srv := getTestServer()
+	defer srv.Close()
+
+	messages, trailers := call(t, srv, "/requestspb.RequestsRPC/GetRequestByID", &requestspb.GetRequestByIDRequest{RequestId: "a"})
+	if !strings.Contains(trailers, "grpc-status: 0") {
+		t.Errorf("unexpected trailers %q", trailers)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+	resp := &requestspb.GetRequestByIDResponse{}
+	if err := proto.Unmarshal(messages[0], resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Request.GetTitle() != "help with groceries" {
+		t.Errorf("unexpected response %v", resp)
+	}
+
+	_, trailers = call(t, srv, "/requestspb.RequestsRPC/GetRequestByID", &requestspb.GetRequestByIDRequest{RequestId: "b"})
+	if !strings.Contains(trailers, "grpc-status: 5") || !strings.Contains(trailers, "grpc-message: request not found") {
+		t.Errorf("unexpected trailers %q", trailers)
+	}
+}
+
+func TestServerStreaming(t *testing.T) {
+	srv := getTestServer()
+	defer srv.Close()
+
+	messages, trailers := call(t, srv, "/newspb.NewsRPC/SearchNewsByPostcode", &newspb.SearchNewsByPostcodeRequest{Postcode: "12345"})
+	if !strings.Contains(trailers, "grpc-status: 0") {
+		t.Errorf("unexpected trailers %q", trailers)
+	}
+	titles := make(map[string]bool)
+	for _, m := range messages {
+		resp := &newspb.SearchNewsByPostcodeResponse{}
+		if err := proto.Unmarshal(m, resp); err != nil {
+			t.Fatal(err)
+		}
+		titles[resp.News.GetTitle()] = true
+	}
+	expected := map[string]bool{"shop opening hours": true, "water outage": true}
+	if !cmp.Equal(titles, expected) {
+		t.Error(cmp.Diff(expected, titles))
+	}
+}
+
+func TestCORS(t *testing.T) {
+	srv := getTestServer()
+	defer srv.Close()
+
+	cases := []struct {
+		name    string
+		origin  string
+		allowed bool
+	}{
+		{
+			name:    "allowed origin",
+			origin:  allowedOrigin,
+			allowed: true,
+		},
+		{
+			name:   "other origin",
+			origin: "https://evil.example.com",
+		},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(http.MethodOptions, srv.URL+"/requestspb.RequestsRPC/GetRequests", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Origin", tc.origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,authorization")
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		got := resp.Header.Get("Access-Control-Allow-Origin")
+		if tc.allowed && got != tc.origin {
+			t.Errorf("%s: expected origin to be allowed, got %q", tc.name, got)
+		}
+		if !tc.allowed && got != "" {
+			t.Errorf("%s: expected origin to be rejected, got %q", tc.name, got)
+		}
+	}
+}
+
+func TestOriginFunc(t *testing.T) {
+	cases := []struct {
+		name    string
+		allowed []string
+		want    bool
+	}{
+		{name: "none", want: false},
+		{name: "listed", allowed: []string{"https://other.example.com", "HTTPS://app.example.com"}, want: true},
+		{name: "not listed", allowed: []string{"https://other.example.com"}, want: false},
+		{name: "any", allowed: []string{AllowAllOrigins}, want: true},
+	}
+	for _, tc := range cases {
+		if got := originFunc(tc.allowed)(allowedOrigin); got != tc.want {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestNotGrpcWeb(t *testing.T) {
+	srv := getTestServer()
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/requestspb.RequestsRPC/GetRequests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected %d, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestServices(t *testing.T) {
+	srv := getTestServerOf([]string{"requestspb.RequestsRPC"})
+	defer srv.Close()
+
+	for _, path := range []string{"/newspb.NewsRPC/GetNews", "/requestspb.RequestsRPCX/GetRequests", "/grpc.health.v1.Health/Check"} {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader(""))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/grpc-web+proto")
+		req.Header.Set("X-Grpc-Web", "1")
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: expected %d, got %d", path, http.StatusNotFound, resp.StatusCode)
+		}
+	}
+}
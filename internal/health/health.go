@@ -0,0 +1,223 @@
+// Package health checks the dependencies of the services and reports their status through the
+// grpc.health.v1 Health service and the /healthz and /readyz HTTP endpoints.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check is a dependency of the services.
+type Check struct {
+	Name string
+	// Func returns why the dependency can't be used
+	Func func() error
+	// Services are the gRPC services not serving while the check fails
+	Services []string
+	// Live marks the checks only fixed by restarting the process, reported by /healthz
+	Live bool
+}
+
+// Checker runs the checks and updates the status of the services.
+type Checker struct {
+	logger *logrus.Entry
+	server *grpchealth.Server
+	checks []Check
+
+	mu       sync.Mutex
+	failures map[string]error
+	shutdown bool
+}
+
+// New returns the checker of the checks, the services are reported as serving until the checks
+// run.
+func New(logger *logrus.Entry, checks ...Check) *Checker {
+	c := &Checker{
+		logger:   logger,
+		server:   grpchealth.NewServer(),
+		checks:   checks,
+		failures: make(map[string]error),
+	}
+	for _, check := range checks {
+		for _, svc := range check.Services {
+			c.server.SetServingStatus(svc, healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+	return c
+}
+
+// Server returns the implementation of the Health service reporting the status of the services.
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+// Run runs the checks on every interval until the context is cancelled.
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c.Update()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Update runs the checks and updates the status of the services, it returns the failed checks.
+func (c *Checker) Update() map[string]error {
+	failures := make(map[string]error)
+	for _, check := range c.checks {
+		if err := check.Func(); err != nil {
+			failures[check.Name] = err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, err := range failures {
+		if _, ok := c.failures[name]; !ok {
+			c.logger.WithError(err).WithField("check", name).Error("health check failed")
+		}
+	}
+	for name := range c.failures {
+		if _, ok := failures[name]; !ok {
+			c.logger.WithField("check", name).Info("health check recovered")
+		}
+	}
+	c.failures = failures
+	if c.shutdown {
+		return failures
+	}
+
+	statuses := map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"": healthpb.HealthCheckResponse_SERVING,
+	}
+	for _, check := range c.checks {
+		for _, svc := range check.Services {
+			if _, ok := statuses[svc]; !ok {
+				statuses[svc] = healthpb.HealthCheckResponse_SERVING
+			}
+			if _, ok := failures[check.Name]; ok {
+				statuses[svc] = healthpb.HealthCheckResponse_NOT_SERVING
+				statuses[""] = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+		}
+	}
+	for svc, s := range statuses {
+		c.server.SetServingStatus(svc, s)
+	}
+	return failures
+}
+
+// Shutdown reports every service as not serving from now on.
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shutdown = true
+	c.server.Shutdown()
+}
+
+// Live is the handler of /healthz, failing when the process needs to be restarted.
+func (c *Checker) Live(w http.ResponseWriter, r *http.Request) {
+	failures := c.Update()
+	for _, check := range c.checks {
+		if !check.Live {
+			delete(failures, check.Name)
+		}
+	}
+	writeFailures(w, failures)
+}
+
+// Ready is the handler of /readyz, failing when a check fails or while shutting down.
+func (c *Checker) Ready(w http.ResponseWriter, r *http.Request) {
+	failures := c.Update()
+	c.mu.Lock()
+	shutdown := c.shutdown
+	c.mu.Unlock()
+	if shutdown {
+		failures["shutdown"] = fmt.Errorf("shutting down")
+	}
+	writeFailures(w, failures)
+}
+
+func writeFailures(w http.ResponseWriter, failures map[string]error) {
+	code := http.StatusOK
+	resp := struct {
+		Status string            `json:"status"`
+		Failed map[string]string `json:"failed,omitempty"`
+	}{
+		Status: "ok",
+	}
+	if len(failures) > 0 {
+		code = http.StatusServiceUnavailable
+		resp.Status = "failing"
+		resp.Failed = make(map[string]string, len(failures))
+		for name, err := range failures {
+			resp.Failed[name] = err.Error()
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Writable checks that the file can be opened for writing.
+func Writable(path string) func() error {
+	return func() error {
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("%s isn't writable: %w", path, err)
+		}
+		return f.Close()
+	}
+}
+
+// Loop tracks whether a background loop is running.
+type Loop struct {
+	name string
+
+	mu      sync.Mutex
+	stopped bool
+	err     error
+}
+
+// NewLoop returns the tracker of a loop started right after, it's reported as running until Run
+// returns.
+func NewLoop(name string) *Loop {
+	return &Loop{name: name}
+}
+
+// Run calls run and reports the loop as stopped once it returns.
+func (l *Loop) Run(ctx context.Context, run func(context.Context) error) error {
+	err := run(ctx)
+	l.mu.Lock()
+	l.stopped = true
+	l.err = err
+	l.mu.Unlock()
+	return err
+}
+
+// Check returns an error once the loop stopped.
+func (l *Loop) Check() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case !l.stopped:
+		return nil
+	case l.err != nil:
+		return fmt.Errorf("%s stopped: %w", l.name, l.err)
+	default:
+		return fmt.Errorf("%s stopped", l.name)
+	}
+}
@@ -0,0 +1,181 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func logger() *logrus.Entry {
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+	return logrus.NewEntry(l)
+}
+
+func status(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Status
+}
+
+func TestChecker(t *testing.T) {
+	var usersErr, loopErr error
+	c := New(
+		logger(),
+		Check{
+			Name:     "users-file",
+			Func:     func() error { return usersErr },
+			Services: []string{"userspb.UsersRPC", "requestspb.RequestsRPC"},
+		},
+		Check{
+			Name:     "notifier",
+			Func:     func() error { return loopErr },
+			Services: []string{"requestspb.RequestsRPC"},
+			Live:     true,
+		},
+	)
+
+	tests := []struct {
+		name     string
+		usersErr error
+		loopErr  error
+		statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+		live     int
+		ready    int
+	}{
+		{
+			name: "healthy",
+			statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{
+				"":                       healthpb.HealthCheckResponse_SERVING,
+				"userspb.UsersRPC":       healthpb.HealthCheckResponse_SERVING,
+				"requestspb.RequestsRPC": healthpb.HealthCheckResponse_SERVING,
+			},
+			live:  http.StatusOK,
+			ready: http.StatusOK,
+		},
+		{
+			name:    "loop stopped",
+			loopErr: errors.New("notifier stopped"),
+			statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{
+				"":                       healthpb.HealthCheckResponse_NOT_SERVING,
+				"userspb.UsersRPC":       healthpb.HealthCheckResponse_SERVING,
+				"requestspb.RequestsRPC": healthpb.HealthCheckResponse_NOT_SERVING,
+			},
+			live:  http.StatusServiceUnavailable,
+			ready: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "file not writable",
+			usersErr: errors.New("users.json isn't writable"),
+			statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{
+				"":                       healthpb.HealthCheckResponse_NOT_SERVING,
+				"userspb.UsersRPC":       healthpb.HealthCheckResponse_NOT_SERVING,
+				"requestspb.RequestsRPC": healthpb.HealthCheckResponse_NOT_SERVING,
+			},
+			live:  http.StatusOK,
+			ready: http.StatusServiceUnavailable,
+		},
+		{
+			name: "recovered",
+			statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{
+				"":                       healthpb.HealthCheckResponse_SERVING,
+				"userspb.UsersRPC":       healthpb.HealthCheckResponse_SERVING,
+				"requestspb.RequestsRPC": healthpb.HealthCheckResponse_SERVING,
+			},
+			live:  http.StatusOK,
+			ready: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersErr, loopErr = tt.usersErr, tt.loopErr
+			c.Update()
+			for svc, want := range tt.statuses {
+				if got := status(t, c, svc); got != want {
+					t.Errorf("expected %q to be %s, got %s", svc, want, got)
+				}
+			}
+
+			w := httptest.NewRecorder()
+			c.Live(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			if w.Code != tt.live {
+				t.Errorf("expected /healthz to return %d, got %d: %s", tt.live, w.Code, w.Body)
+			}
+			w = httptest.NewRecorder()
+			c.Ready(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if w.Code != tt.ready {
+				t.Errorf("expected /readyz to return %d, got %d: %s", tt.ready, w.Code, w.Body)
+			}
+		})
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	c := New(logger(), Check{
+		Name:     "users-file",
+		Func:     func() error { return nil },
+		Services: []string{"userspb.UsersRPC"},
+	})
+	c.Shutdown()
+	c.Update()
+	if got := status(t, c, "userspb.UsersRPC"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected the service to stop serving, got %s", got)
+	}
+	w := httptest.NewRecorder()
+	c.Ready(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable || !strings.Contains(w.Body.String(), "shutting down") {
+		t.Errorf("expected /readyz to fail while shutting down, got %d: %s", w.Code, w.Body)
+	}
+	w = httptest.NewRecorder()
+	c.Live(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected /healthz to succeed while shutting down, got %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestWritable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "users.json")
+	if err := ioutil.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Writable(path)(); err != nil {
+		t.Errorf("expected the file to be writable, got %s", err)
+	}
+	if err := Writable(filepath.Join(dir, "missing.json"))(); err == nil {
+		t.Error("expected a missing file not to be writable")
+	}
+}
+
+func TestLoop(t *testing.T) {
+	loop := NewLoop("notifier")
+	if err := loop.Check(); err != nil {
+		t.Errorf("expected the loop to be running, got %s", err)
+	}
+	_ = loop.Run(context.Background(), func(context.Context) error {
+		if err := loop.Check(); err != nil {
+			t.Errorf("expected the loop to be running, got %s", err)
+		}
+		return errors.New("feed closed")
+	})
+	if err := loop.Check(); err == nil || err.Error() != "notifier stopped: feed closed" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
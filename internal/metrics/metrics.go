@@ -0,0 +1,189 @@
+// Package metrics records the RPCs served and the state of the data, exposed in the Prometheus
+// text format.
+package metrics
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+const namespace = "euvsvirus"
+
+// Metrics holds the collectors of the service.
+type Metrics struct {
+	registry     *prometheus.Registry
+	server       *grpc_prometheus.ServerMetrics
+	writeLatency *prometheus.HistogramVec
+	retained     *prometheus.CounterVec
+	files        *filesCollector
+}
+
+// New returns the metrics of the RPCs and the data files.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		server:   grpc_prometheus.NewServerMetrics(),
+		writeLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "write_duration_seconds",
+			Help:      "Time spent writing the data files.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"store"}),
+		retained: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "retention",
+			Name:      "records_total",
+			Help:      "Records purged or anonymized by the retention rules.",
+		}, []string{"collection", "action"}),
+		files: &filesCollector{
+			size: prometheus.NewDesc(
+				prometheus.BuildFQName(namespace, "storage", "file_size_bytes"),
+				"Size of the data files.",
+				[]string{"store"},
+				nil,
+			),
+			paths: make(map[string]string),
+		},
+	}
+	m.server.EnableHandlingTimeHistogram()
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.server,
+		m.writeLatency,
+		m.retained,
+		m.files,
+	)
+	return m
+}
+
+// RegisterData exposes the users, requests and news counts, read from the storages when scraped.
+func (m *Metrics) RegisterData(userData *storage.UsersStorage, requestData *storage.RequestsStorage, newsData *storage.NewsStorage) {
+	m.registry.MustRegister(newDataCollector(userData, requestData, newsData))
+}
+
+// Retained counts the records of the collection the retention action was applied to.
+func (m *Metrics) Retained(collection, action string, count int) {
+	m.retained.WithLabelValues(collection, action).Add(float64(count))
+}
+
+// UnaryServerInterceptor counts the calls by method and code, and records their latency.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return m.server.UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor counts the streams by method and code, and records their duration.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return m.server.StreamServerInterceptor()
+}
+
+// InitializeMetrics sets the metrics of every method of the server to zero so they're exposed
+// before being called.
+func (m *Metrics) InitializeMetrics(server *grpc.Server) {
+	m.server.InitializeMetrics(server)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// File records the write latency and the size of the data file of the store, it's used in place
+// of the file by the store.
+func (m *Metrics) File(store string, f *os.File) *File {
+	m.files.add(store, f.Name())
+	return &File{
+		File:    f,
+		latency: m.writeLatency.WithLabelValues(store),
+	}
+}
+
+// File is a data file whose writes are timed.
+type File struct {
+	*os.File
+	latency prometheus.Observer
+}
+
+func (f *File) Write(p []byte) (int, error) {
+	start := time.Now()
+	n, err := f.File.Write(p)
+	f.latency.Observe(time.Since(start).Seconds())
+	return n, err
+}
+
+type filesCollector struct {
+	size  *prometheus.Desc
+	paths map[string]string
+}
+
+func (c *filesCollector) add(store, path string) {
+	c.paths[store] = path
+}
+
+func (c *filesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.size
+}
+
+func (c *filesCollector) Collect(ch chan<- prometheus.Metric) {
+	for store, path := range c.paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(fi.Size()), store)
+	}
+}
+
+// dataCollector counts the entities stored when scraped.
+type dataCollector struct {
+	users    *storage.UsersStorage
+	requests *storage.RequestsStorage
+	news     *storage.NewsStorage
+
+	usersDesc    *prometheus.Desc
+	requestsDesc *prometheus.Desc
+	newsDesc     *prometheus.Desc
+}
+
+func newDataCollector(userData *storage.UsersStorage, requestData *storage.RequestsStorage, newsData *storage.NewsStorage) *dataCollector {
+	return &dataCollector{
+		users:        userData,
+		requests:     requestData,
+		news:         newsData,
+		usersDesc:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "users"), "Number of users.", nil, nil),
+		requestsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "requests"), "Number of requests by state.", []string{"state"}, nil),
+		newsDesc:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "news"), "Number of news.", nil, nil),
+	}
+}
+
+func (c *dataCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.usersDesc
+	ch <- c.requestsDesc
+	ch <- c.newsDesc
+}
+
+func (c *dataCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(c.usersDesc, prometheus.GaugeValue, float64(len(c.users.All())))
+
+	states := make(map[requestspb.Request_State]int, len(requestspb.Request_State_name))
+	for state := range requestspb.Request_State_name {
+		states[requestspb.Request_State(state)] = 0
+	}
+	for _, request := range c.requests.All() {
+		states[request.State]++
+	}
+	for state, count := range states {
+		ch <- prometheus.MustNewConstMetric(c.requestsDesc, prometheus.GaugeValue, float64(count), state.String())
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.newsDesc, prometheus.GaugeValue, float64(len(c.news.All())))
+}
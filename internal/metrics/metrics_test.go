@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func tempFile(t *testing.T, m *Metrics, store string) *File {
+	t.Helper()
+	f, err := ioutil.TempFile("", store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m.File(store, f)
+}
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	usersFile := tempFile(t, m, "users")
+	defer os.Remove(usersFile.Name())
+	defer usersFile.Close()
+	requestsFile := tempFile(t, m, "requests")
+	defer os.Remove(requestsFile.Name())
+	defer requestsFile.Close()
+
+	userData := storage.NewUsersStorage(usersFile, map[string]*userspb.User{})
+	requestData := storage.NewRequestsStorage(requestsFile, map[string]*requestspb.Request{})
+	newsData := storagetest.News(nil)
+	m.RegisterData(userData, requestData, newsData)
+
+	if err := userData.Add(context.Background(), "1", &userspb.User{Name: "David BP"}); err != nil {
+		t.Fatal(err)
+	}
+	for id, state := range map[string]requestspb.Request_State{
+		"1": requestspb.Request_WAITING,
+		"2": requestspb.Request_WAITING,
+		"3": requestspb.Request_COMPLETED,
+	} {
+		if err := requestData.Add(context.Background(), id, &requestspb.Request{State: state}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/userspb.UsersRPC/GetUserByID"}
+	_, _ = m.UnaryServerInterceptor()(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	})
+	m.Retained("requests", "purge", 2)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, line := range []string{
+		`euvsvirus_users 1`,
+		`euvsvirus_requests{state="WAITING"} 2`,
+		`euvsvirus_requests{state="COMPLETED"} 1`,
+		`euvsvirus_requests{state="CANCELLED"} 0`,
+		`euvsvirus_news 0`,
+		`euvsvirus_storage_write_duration_seconds_count{store="users"} 1`,
+		`euvsvirus_storage_write_duration_seconds_count{store="requests"} 3`,
+		`euvsvirus_storage_file_size_bytes{store="users"}`,
+		`euvsvirus_retention_records_total{action="purge",collection="requests"} 2`,
+		`grpc_server_handled_total{grpc_code="NotFound",grpc_method="GetUserByID",grpc_service="userspb.UsersRPC",grpc_type="unary"} 1`,
+		`grpc_server_handling_seconds_count{grpc_method="GetUserByID",grpc_service="userspb.UsersRPC",grpc_type="unary"} 1`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected %s in metrics", line)
+		}
+	}
+}
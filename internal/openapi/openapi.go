@@ -0,0 +1,384 @@
+// Package openapi generates the OpenAPI 3 document describing the HTTP/JSON gateway from the
+// descriptors of the service protos, so it can't drift from the messages sent on the wire.
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/euvsvirus-banan/backend/internal/gateway"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+	// register the descriptors of the protos exposed by the gateway
+	_ "github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	_ "github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	_ "github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+// Files are the protos exposed by the gateway.
+var Files = []string{
+	"users/rpc/userspb/service.proto",
+	"requests/rpc/requestspb/service.proto",
+	"news/rpc/newspb/service.proto",
+}
+
+const (
+	// Version is the version of the HTTP API described
+	Version = "v1"
+
+	statusSchema = "Status"
+)
+
+type object map[string]interface{}
+
+type method struct {
+	service string
+	name    string
+	input   string
+	output  string
+}
+
+type generator struct {
+	messages map[string]*descriptor.DescriptorProto
+	enums    map[string]*descriptor.EnumDescriptorProto
+	methods  map[string]method
+	schemas  object
+}
+
+// Generate returns the OpenAPI document describing the given routes as indented JSON.
+func Generate(routes []gateway.Route) ([]byte, error) {
+	g := &generator{
+		messages: make(map[string]*descriptor.DescriptorProto),
+		enums:    make(map[string]*descriptor.EnumDescriptorProto),
+		methods:  make(map[string]method),
+		schemas: object{
+			statusSchema: object{
+				"type": "object",
+				"properties": object{
+					"code":    object{"type": "integer", "format": "int32"},
+					"message": object{"type": "string"},
+					"details": object{"type": "array", "items": object{"type": "object"}},
+				},
+			},
+		},
+	}
+	for _, f := range Files {
+		if err := g.load(f); err != nil {
+			return nil, err
+		}
+	}
+
+	paths := make(map[string]object)
+	for _, rt := range routes {
+		op, err := g.operation(rt)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", rt.Method, rt.Path, err)
+		}
+		if paths[rt.Path] == nil {
+			paths[rt.Path] = object{}
+		}
+		paths[rt.Path][strings.ToLower(rt.Method)] = op
+	}
+
+	b, err := json.MarshalIndent(object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "euvsvirus-banan backend",
+			"version": Version,
+		},
+		"paths": paths,
+		"components": object{
+			"schemas": g.schemas,
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("problem marshalling document: %w", err)
+	}
+	return append(b, '\n'), nil
+}
+
+// load indexes the messages, enums and methods of a registered proto.
+func (g *generator) load(filename string) error {
+	gz := proto.FileDescriptor(filename)
+	if gz == nil {
+		return fmt.Errorf("proto %s isn't registered", filename)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return fmt.Errorf("%w: problem reading descriptor of %s", err, filename)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("%w: problem reading descriptor of %s", err, filename)
+	}
+	fd := &descriptor.FileDescriptorProto{}
+	if err := proto.Unmarshal(b, fd); err != nil {
+		return fmt.Errorf("%w: problem unmarshalling descriptor of %s", err, filename)
+	}
+
+	prefix := "." + fd.GetPackage()
+	for _, e := range fd.EnumType {
+		g.enums[prefix+"."+e.GetName()] = e
+	}
+	for _, m := range fd.MessageType {
+		g.addMessage(prefix, m)
+	}
+	for _, s := range fd.Service {
+		for _, m := range s.Method {
+			if m.GetClientStreaming() {
+				continue
+			}
+			g.methods["/"+fd.GetPackage()+"."+s.GetName()+"/"+m.GetName()] = method{
+				service: s.GetName(),
+				name:    m.GetName(),
+				input:   m.GetInputType(),
+				output:  m.GetOutputType(),
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) addMessage(prefix string, m *descriptor.DescriptorProto) {
+	name := prefix + "." + m.GetName()
+	g.messages[name] = m
+	for _, e := range m.EnumType {
+		g.enums[name+"."+e.GetName()] = e
+	}
+	for _, nested := range m.NestedType {
+		g.addMessage(name, nested)
+	}
+}
+
+func (g *generator) operation(rt gateway.Route) (object, error) {
+	m, ok := g.methods[rt.RPC]
+	if !ok {
+		return nil, fmt.Errorf("unknown RPC %s", rt.RPC)
+	}
+	input := g.messages[m.input]
+
+	var params []object
+	for _, s := range strings.Split(rt.Path, "/") {
+		if i := strings.Index(s, ":"); i >= 0 {
+			s = s[:i]
+		}
+		if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+			continue
+		}
+		name := s[1 : len(s)-1]
+		params = append(params, object{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   g.fieldSchema(input, name),
+		})
+	}
+	for _, q := range rt.Query {
+		params = append(params, object{
+			"name":   q.Name,
+			"in":     "query",
+			"schema": g.fieldSchema(input, q.Field),
+		})
+	}
+
+	op := object{
+		"operationId": m.service + "_" + m.name,
+		"tags":        []string{m.service},
+		"responses": object{
+			"200": g.response(m.output, rt.Response),
+			"default": object{
+				"description": "Error",
+				"content": object{
+					"application/json": object{
+						"schema": ref(statusSchema),
+					},
+				},
+			},
+		},
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	switch rt.Body {
+	case "":
+	case "*":
+		op["requestBody"] = body(g.messageSchema(m.input))
+	default:
+		f := field(input, rt.Body)
+		if f == nil {
+			return nil, fmt.Errorf("unknown body field %s", rt.Body)
+		}
+		op["requestBody"] = body(g.schema(f))
+	}
+	return op, nil
+}
+
+func (g *generator) response(output string, kind gateway.ResponseKind) object {
+	schema := g.messageSchema(output)
+	contentType := "application/json"
+	switch kind {
+	case gateway.List:
+		schema = object{"type": "array", "items": schema}
+	case gateway.Stream:
+		// each line is a message
+		contentType = "application/x-ndjson"
+	}
+	return object{
+		"description": http.StatusText(http.StatusOK),
+		"content": object{
+			contentType: object{
+				"schema": schema,
+			},
+		},
+	}
+}
+
+func body(schema object) object {
+	return object{
+		"required": true,
+		"content": object{
+			"application/json": object{
+				"schema": schema,
+			},
+		},
+	}
+}
+
+// fieldSchema returns the schema of a field of the message, parameters that aren't fields are
+// strings.
+func (g *generator) fieldSchema(m *descriptor.DescriptorProto, name string) object {
+	if f := field(m, name); f != nil {
+		return g.schema(f)
+	}
+	return object{"type": "string"}
+}
+
+func field(m *descriptor.DescriptorProto, name string) *descriptor.FieldDescriptorProto {
+	if m == nil || name == "" {
+		return nil
+	}
+	for _, f := range m.Field {
+		if f.GetName() == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func (g *generator) schema(f *descriptor.FieldDescriptorProto) object {
+	if f.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		if entry := g.messages[f.GetTypeName()]; entry != nil && entry.GetOptions().GetMapEntry() {
+			return object{
+				"type":                 "object",
+				"additionalProperties": g.singleSchema(entry.Field[1]),
+			}
+		}
+		return object{
+			"type":  "array",
+			"items": g.singleSchema(f),
+		}
+	}
+	return g.singleSchema(f)
+}
+
+func (g *generator) singleSchema(f *descriptor.FieldDescriptorProto) object {
+	switch f.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		return g.messageSchema(f.GetTypeName())
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		return g.enumSchema(f.GetTypeName())
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return object{"type": "boolean"}
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return object{"type": "string"}
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return object{"type": "string", "format": "byte"}
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return object{"type": "number", "format": "double"}
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return object{"type": "number", "format": "float"}
+	case descriptor.FieldDescriptorProto_TYPE_INT64, descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		// 64 bits integers are strings in the JSON mapping
+		return object{"type": "string", "format": "int64"}
+	case descriptor.FieldDescriptorProto_TYPE_UINT64, descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return object{"type": "string", "format": "uint64"}
+	case descriptor.FieldDescriptorProto_TYPE_UINT32, descriptor.FieldDescriptorProto_TYPE_FIXED32:
+		return object{"type": "integer", "format": "int64", "minimum": 0}
+	default:
+		return object{"type": "integer", "format": "int32"}
+	}
+}
+
+// messageSchema adds the schema of the message to the components and returns a reference to it.
+func (g *generator) messageSchema(typeName string) object {
+	name := strings.TrimPrefix(typeName, ".")
+	if _, ok := g.schemas[name]; ok {
+		return ref(name)
+	}
+	m := g.messages[typeName]
+	if m == nil {
+		return object{"type": "object"}
+	}
+	properties := object{}
+	schema := object{
+		"type":       "object",
+		"properties": properties,
+	}
+	// set before adding the fields to handle recursive messages
+	g.schemas[name] = schema
+	for _, f := range m.Field {
+		properties[jsonName(f.GetName())] = g.schema(f)
+	}
+	return ref(name)
+}
+
+func (g *generator) enumSchema(typeName string) object {
+	name := strings.TrimPrefix(typeName, ".")
+	if _, ok := g.schemas[name]; ok {
+		return ref(name)
+	}
+	e := g.enums[typeName]
+	if e == nil {
+		return object{"type": "string"}
+	}
+	values := make([]string, len(e.Value))
+	for i, v := range e.Value {
+		values[i] = v.GetName()
+	}
+	g.schemas[name] = object{
+		"type":    "string",
+		"enum":    values,
+		"default": values[0],
+	}
+	return ref(name)
+}
+
+func ref(name string) object {
+	return object{"$ref": "#/components/schemas/" + name}
+}
+
+// jsonName returns the lowerCamelCase name used by the JSON mapping of the field.
+func jsonName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range name {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(c)
+	}
+	return b.String()
+}
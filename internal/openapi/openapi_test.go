@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/gateway"
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestUpToDate fails when the committed document doesn't match the protos and the gateway routes,
+// run make openapi to update it.
+func TestUpToDate(t *testing.T) {
+	expected, err := Generate(gateway.New(nil).Routes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile("../../api/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(string(b), string(expected)) {
+		t.Errorf("api/openapi.json is out of date, run make openapi:\n%s", cmp.Diff(string(expected), string(b)))
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	b, err := Generate([]gateway.Route{
+		{
+			Method: http.MethodPost,
+			Path:   "/v1/requests/{request_id}:rate",
+			RPC:    "/requestspb.RequestsRPC/RateHelp",
+			Body:   "*",
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/v1/news:watch",
+			RPC:      "/newspb.NewsRPC/WatchNews",
+			Query:    []gateway.QueryParam{{Name: "minPriority", Field: "min_priority"}},
+			Response: gateway.Stream,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"requestspb.RateHelpRequest":  `{"properties":{"comment":{"type":"string"},"raterId":{"type":"string"},"requestId":{"type":"string"},"score":{"format":"int32","type":"integer"}},"type":"object"}`,
+		"requestspb.RateHelpResponse": `{"properties":{},"type":"object"}`,
+		"newspb.News.Priority":        `{"default":"NORMAL","enum":["NORMAL","HIGH","URGENT"],"type":"string"}`,
+	}
+	for name, schema := range expected {
+		var got, want interface{}
+		if err := json.Unmarshal(doc.Components.Schemas[name], &got); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		_ = json.Unmarshal([]byte(schema), &want)
+		if !cmp.Equal(got, want) {
+			t.Errorf("%s: %s", name, cmp.Diff(want, got))
+		}
+	}
+
+	if _, err := Generate([]gateway.Route{{Method: http.MethodGet, Path: "/v1/unknown", RPC: "/requestspb.RequestsRPC/Unknown"}}); err == nil {
+		t.Error("expected an error for an unknown RPC")
+	}
+}
@@ -0,0 +1,359 @@
+// Package retention enforces how long the requests and news are kept, the records matching a rule
+// are purged or anonymized once old enough.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/sirupsen/logrus"
+)
+
+// the collections the rules apply to
+const (
+	Requests = "requests"
+	News     = "news"
+)
+
+// Expired is the state of the news whose end date is passed, their age is counted from the end
+// date instead of the creation date.
+const Expired = "EXPIRED"
+
+// Action is what's done to the records matching a rule.
+type Action string
+
+const (
+	// Purge deletes the records.
+	Purge Action = "purge"
+	// Anonymize clears the texts written by the users: the title and body of the requests and the
+	// comments of their answers and ratings. The states, scores and user ids are kept.
+	Anonymize Action = "anonymize"
+)
+
+// Rule applies an action to the records of a collection in a state once they're older than After.
+type Rule struct {
+	Collection string
+	// State is the state of the records matched, all the records of the collection match if empty.
+	State  string
+	Action Action
+	After  time.Duration
+}
+
+// String returns the rule as it's parsed.
+func (r Rule) String() string {
+	s := r.Collection
+	if r.State != "" {
+		s += "." + r.State
+	}
+	age := r.After.String()
+	if r.After%(24*time.Hour) == 0 {
+		age = strconv.Itoa(int(r.After/(24*time.Hour))) + "d"
+	}
+	return s + "=" + string(r.Action) + ":" + age
+}
+
+// ParseRules parses rules formatted as collection[.STATE]=action:age, separated by commas or new
+// lines. The age is a number of days like 30d or a duration like 12h.
+//
+//	requests.CANCELLED=purge:30d,requests.COMPLETED=anonymize:90d,news.EXPIRED=purge:0d
+func ParseRules(s string) ([]Rule, error) {
+	var rules []Rule
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		r, err := parseRule(field)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", field, err)
+		}
+		key := r.Collection + "." + r.State + "=" + string(r.Action)
+		if seen[key] {
+			return nil, fmt.Errorf("rule %q: %s of %s.%s is given twice", field, r.Action, r.Collection, r.State)
+		}
+		seen[key] = true
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func parseRule(s string) (Rule, error) {
+	var r Rule
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return r, fmt.Errorf("expected collection[.STATE]=action:age")
+	}
+	target, spec := parts[0], parts[1]
+	r.Collection = target
+	if i := strings.Index(target, "."); i >= 0 {
+		r.Collection, r.State = target[:i], strings.ToUpper(target[i+1:])
+	}
+	parts = strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 {
+		return r, fmt.Errorf("expected collection[.STATE]=action:age")
+	}
+	r.Action = Action(parts[0])
+	after, err := parseAge(parts[1])
+	if err != nil {
+		return r, err
+	}
+	r.After = after
+
+	switch r.Collection {
+	case Requests:
+		if _, ok := requestspb.Request_State_value[r.State]; r.State != "" && !ok {
+			return r, fmt.Errorf("unknown request state %s", r.State)
+		}
+		if r.Action != Purge && r.Action != Anonymize {
+			return r, fmt.Errorf("unknown action %q, expected purge or anonymize", r.Action)
+		}
+	case News:
+		if r.State != "" && r.State != Expired {
+			return r, fmt.Errorf("unknown news state %s, expected %s", r.State, Expired)
+		}
+		if r.Action != Purge {
+			return r, fmt.Errorf("news can only be purged")
+		}
+	default:
+		return r, fmt.Errorf("unknown collection %q, expected requests or news", r.Collection)
+	}
+	return r, nil
+}
+
+func parseAge(s string) (time.Duration, error) {
+	var d time.Duration
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		d = time.Duration(days) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("age %q can't be negative", s)
+	}
+	return d, nil
+}
+
+// Item is a record a rule applies to.
+type Item struct {
+	Collection string
+	ID         string
+	State      string
+	Action     Action
+}
+
+// Recorder counts the records the rules were applied to.
+type Recorder interface {
+	Retained(collection, action string, count int)
+}
+
+// Retention applies the rules to the requests and news.
+type Retention struct {
+	logger   *logrus.Entry
+	rules    []Rule
+	requests *storage.RequestsStorage
+	news     *storage.NewsStorage
+	recorder Recorder
+}
+
+// New returns the retention of the requests and news, the recorder can be nil.
+func New(logger *logrus.Entry, rules []Rule, requests *storage.RequestsStorage, news *storage.NewsStorage, recorder Recorder) *Retention {
+	return &Retention{
+		logger:   logger,
+		rules:    rules,
+		requests: requests,
+		news:     news,
+		recorder: recorder,
+	}
+}
+
+// Due returns the records the rules apply to at the given time, by collection and id. A record
+// matching several rules is purged rather than anonymized, the requests already anonymized aren't
+// returned again.
+func (r *Retention) Due(now time.Time) []Item {
+	var items []Item
+	for id, request := range r.requests.All() {
+		// the requests without a valid date would never be old enough, they're treated as old
+		date := stateDate(request)
+		_, err := time.Parse(time.RFC3339, date)
+		undated := err != nil
+		action := Action("")
+		for _, rule := range r.rules {
+			if rule.Collection != Requests || (rule.State != "" && rule.State != request.State.String()) {
+				continue
+			}
+			if !undated && !olderThan(date, rule.After, now) {
+				continue
+			}
+			if rule.Action == Purge || (rule.Action == Anonymize && !anonymized(request)) {
+				if action != Purge {
+					action = rule.Action
+				}
+			}
+		}
+		if action != "" {
+			if undated {
+				r.logger.WithFields(
+					logrus.Fields{
+						"id":   id,
+						"date": date,
+					},
+				).Warn("request without a valid date treated as old by the retention rules")
+			}
+			items = append(items, Item{Collection: Requests, ID: id, State: request.State.String(), Action: action})
+		}
+	}
+	for id, n := range r.news.All() {
+		for _, rule := range r.rules {
+			if rule.Collection != News {
+				continue
+			}
+			since := n.CreationDate
+			if rule.State == Expired {
+				since = n.EndDate
+			}
+			if olderThan(since, rule.After, now) {
+				state := ""
+				if olderThan(n.EndDate, 0, now) {
+					state = Expired
+				}
+				items = append(items, Item{Collection: News, ID: id, State: state, Action: Purge})
+				break
+			}
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Collection != items[j].Collection {
+			return items[i].Collection < items[j].Collection
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items
+}
+
+// Apply applies the rules at the given time and returns the records changed, or that would be
+// changed when dryRun is set. The records changed before an error are returned with it.
+func (r *Retention) Apply(ctx context.Context, now time.Time, dryRun bool) ([]Item, error) {
+	items := r.Due(now)
+	if dryRun {
+		return items, nil
+	}
+	counts := make(map[Item]int)
+	defer func() {
+		if r.recorder == nil {
+			return
+		}
+		for k, count := range counts {
+			r.recorder.Retained(k.Collection, string(k.Action), count)
+		}
+	}()
+	for i, item := range items {
+		if err := r.apply(ctx, item); err != nil {
+			return items[:i], fmt.Errorf("problem applying %s to %s %s: %w", item.Action, item.Collection, item.ID, err)
+		}
+		counts[Item{Collection: item.Collection, Action: item.Action}]++
+	}
+	return items, nil
+}
+
+func (r *Retention) apply(ctx context.Context, item Item) error {
+	switch {
+	case item.Collection == News:
+		return r.news.Delete(ctx, item.ID)
+	case item.Action == Purge:
+		return r.requests.Delete(ctx, item.ID)
+	default:
+		// cleared under the lock of the storage, along with the events not delivered yet
+		return r.requests.Erase(ctx, item.ID, anonymize)
+	}
+}
+
+// Run applies the rules at once then at every interval until the context is cancelled, the
+// records are only logged when dryRun is set.
+func (r *Retention) Run(ctx context.Context, interval time.Duration, dryRun bool) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		items, err := r.Apply(ctx, time.Now(), dryRun)
+		for _, item := range items {
+			r.logger.WithFields(
+				logrus.Fields{
+					"collection": item.Collection,
+					"id":         item.ID,
+					"state":      item.State,
+					"action":     item.Action,
+					"dry_run":    dryRun,
+				},
+			).Info("retention rule applied")
+		}
+		if err != nil {
+			r.logger.WithError(err).Error("problem applying retention rules")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// olderThan reports whether the RFC 3339 date is older than the age, the news without a valid date
+// are never old enough.
+func olderThan(date string, age time.Duration, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return false
+	}
+	return !now.Before(t.Add(age))
+}
+
+// stateDate returns the date the request entered its state, its creation date for the requests
+// stored before the state date was.
+func stateDate(r *requestspb.Request) string {
+	if r.StateDate != "" {
+		return r.StateDate
+	}
+	return r.CreationDate
+}
+
+func anonymized(r *requestspb.Request) bool {
+	if r.Title != "" || r.Body != "" {
+		return false
+	}
+	for _, a := range r.Answers {
+		if a.Comment != "" {
+			return false
+		}
+	}
+	for _, rating := range r.Ratings {
+		if rating.Comment != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func anonymize(r *requestspb.Request) *requestspb.Request {
+	r.Title = ""
+	r.Body = ""
+	for _, a := range r.Answers {
+		a.Comment = ""
+	}
+	for _, rating := range r.Ratings {
+		rating.Comment = ""
+	}
+	return r
+}
@@ -0,0 +1,175 @@
+package retention
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+type recorder map[string]int
+
+func (r recorder) Retained(collection, action string, count int) {
+	r[collection+" "+action] += count
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		want    []Rule
+		wantErr string
+	}{
+		{name: "none", rules: " \n"},
+		{
+			name:  "rules",
+			rules: "requests.CANCELLED=purge:30d, requests.completed=anonymize:90d\nnews.EXPIRED=purge:0d,news=purge:12h",
+			want: []Rule{
+				{Collection: Requests, State: "CANCELLED", Action: Purge, After: 30 * 24 * time.Hour},
+				{Collection: Requests, State: "COMPLETED", Action: Anonymize, After: 90 * 24 * time.Hour},
+				{Collection: News, State: Expired, Action: Purge},
+				{Collection: News, Action: Purge, After: 12 * time.Hour},
+			},
+		},
+		{name: "no action", rules: "requests.CANCELLED", wantErr: "expected collection[.STATE]=action:age"},
+		{name: "no age", rules: "requests.CANCELLED=purge", wantErr: "expected collection[.STATE]=action:age"},
+		{name: "invalid age", rules: "requests=purge:a month", wantErr: `invalid age "a month"`},
+		{name: "negative age", rules: "requests=purge:-1d", wantErr: "can't be negative"},
+		{name: "unknown collection", rules: "users=purge:1d", wantErr: `unknown collection "users"`},
+		{name: "unknown state", rules: "requests.DONE=purge:1d", wantErr: "unknown request state DONE"},
+		{name: "unknown action", rules: "requests=archive:1d", wantErr: `unknown action "archive"`},
+		{name: "anonymized news", rules: "news=anonymize:1d", wantErr: "news can only be purged"},
+		{name: "twice", rules: "news=purge:1d,news=purge:2d", wantErr: "given twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRules(tt.rules)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseRules() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseRules() differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRuleString(t *testing.T) {
+	rules, err := ParseRules("requests.CANCELLED=purge:30d,news=purge:12h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rules[0].String() + "," + rules[1].String(); got != "requests.CANCELLED=purge:30d,news=purge:12h0m0s" {
+		t.Errorf("String() = %s", got)
+	}
+}
+
+func TestApply(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) string {
+		return now.AddDate(0, 0, -days).Format(time.RFC3339)
+	}
+	requests := storagetest.Requests(map[string]*requestspb.Request{
+		"cancelled-old":    {Title: "groceries", State: requestspb.Request_CANCELLED, CreationDate: daysAgo(31)},
+		"cancelled-recent": {Title: "groceries", State: requestspb.Request_CANCELLED, CreationDate: daysAgo(29)},
+		"completed-old": {
+			Title:        "dog walk",
+			Body:         "my address is...",
+			RequesterId:  "Brown",
+			VolunteerId:  "Blue",
+			State:        requestspb.Request_COMPLETED,
+			CreationDate: daysAgo(91),
+			Answers:      []*requestspb.Request_Answer{{VolunteerId: "Blue", Comment: "I can"}},
+			Ratings:      []*requestspb.Request_Rating{{RaterId: "Brown", RateeId: "Blue", Score: 5, Comment: "thanks"}},
+		},
+		"completed-ancient": {Title: "pharmacy", State: requestspb.Request_COMPLETED, CreationDate: daysAgo(400)},
+		"waiting-old":       {Title: "plumbing", State: requestspb.Request_WAITING, CreationDate: daysAgo(400)},
+		// the requests without a valid date are treated as old
+		"no-date":  {Title: "plumbing", State: requestspb.Request_CANCELLED},
+		"bad-date": {Title: "plumbing", State: requestspb.Request_CANCELLED, CreationDate: "19/10/2020"},
+		// the age is counted from the change of state
+		"completed-recent": {Title: "plumbing", State: requestspb.Request_COMPLETED, CreationDate: daysAgo(400), StateDate: daysAgo(10)},
+	})
+	news := storagetest.News(map[string]*newspb.News{
+		"expired":   {Title: "market closed", CreationDate: daysAgo(10), EndDate: daysAgo(1)},
+		"current":   {Title: "market open", CreationDate: daysAgo(10), EndDate: now.AddDate(0, 0, 1).Format(time.RFC3339)},
+		"never-end": {Title: "pharmacy open", CreationDate: daysAgo(10)},
+	})
+	rules, err := ParseRules("requests.CANCELLED=purge:30d,requests.COMPLETED=anonymize:90d,requests.COMPLETED=purge:365d,news.EXPIRED=purge:0d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := make(recorder)
+	r := New(logrus.NewEntry(logrus.New()), rules, requests, news, rec)
+
+	want := []Item{
+		{Collection: News, ID: "expired", State: Expired, Action: Purge},
+		{Collection: Requests, ID: "bad-date", State: "CANCELLED", Action: Purge},
+		{Collection: Requests, ID: "cancelled-old", State: "CANCELLED", Action: Purge},
+		{Collection: Requests, ID: "completed-ancient", State: "COMPLETED", Action: Purge},
+		{Collection: Requests, ID: "completed-old", State: "COMPLETED", Action: Anonymize},
+		{Collection: Requests, ID: "no-date", State: "CANCELLED", Action: Purge},
+	}
+	dryRun, err := r.Apply(context.Background(), now, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, dryRun); diff != "" {
+		t.Errorf("dry run differs (-want +got):\n%s", diff)
+	}
+	if len(requests.All()) != 8 || len(news.All()) != 3 || len(rec) != 0 {
+		t.Fatal("expected nothing to change in a dry run")
+	}
+
+	applied, err := r.Apply(context.Background(), now, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, applied); diff != "" {
+		t.Errorf("applied differs (-want +got):\n%s", diff)
+	}
+	for _, id := range []string{"cancelled-old", "completed-ancient", "no-date", "bad-date"} {
+		if _, err := requests.Get(id); err != storage.ErrNotFound {
+			t.Errorf("expected %s to be purged, got %v", id, err)
+		}
+	}
+	if _, err := news.Get("expired"); err != storage.ErrNotFound {
+		t.Errorf("expected the expired news to be purged, got %v", err)
+	}
+	got, err := requests.Get("completed-old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAnonymized := &requestspb.Request{
+		RequesterId:  "Brown",
+		VolunteerId:  "Blue",
+		State:        requestspb.Request_COMPLETED,
+		CreationDate: daysAgo(91),
+		Answers:      []*requestspb.Request_Answer{{VolunteerId: "Blue"}},
+		Ratings:      []*requestspb.Request_Rating{{RaterId: "Brown", RateeId: "Blue", Score: 5}},
+	}
+	if diff := cmp.Diff(wantAnonymized, got); diff != "" {
+		t.Errorf("anonymized request differs (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(recorder{"requests purge": 4, "requests anonymize": 1, "news purge": 1}, rec); diff != "" {
+		t.Errorf("recorded counts differ (-want +got):\n%s", diff)
+	}
+
+	// the anonymized requests aren't changed again
+	if again, err := r.Apply(context.Background(), now, false); err != nil || len(again) != 0 {
+		t.Errorf("expected nothing left to apply, got %v, %v", again, err)
+	}
+}
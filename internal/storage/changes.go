@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Change is a change made to an entity, recorded under the lock of its storage: Before and After
+// are the values the change replaced and saved, not ones read around it.
+type Change struct {
+	Entity string
+	ID     string
+	// Before is nil when the entity is added, After when it's deleted
+	Before proto.Message
+	After  proto.Message
+}
+
+type changesKey struct{}
+
+type changes struct {
+	mu   sync.Mutex
+	list []Change
+}
+
+// WithChanges returns a context the storages record the changes made with it in, the function
+// returns them in the order they were saved.
+func WithChanges(ctx context.Context) (context.Context, func() []Change) {
+	c := &changes{}
+	return context.WithValue(ctx, changesKey{}, c), func() []Change {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return append([]Change(nil), c.list...)
+	}
+}
+
+// recordChange records the change saved in the context, when it records them. The entities are
+// copied, they can be changed once the lock is released.
+func recordChange(ctx context.Context, entity, id string, before, after proto.Message) {
+	c, ok := ctx.Value(changesKey{}).(*changes)
+	if !ok {
+		return
+	}
+	change := Change{Entity: entity, ID: id}
+	if before != nil {
+		change.Before = proto.Clone(before)
+	}
+	if after != nil {
+		change.After = proto.Clone(after)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.list = append(c.list, change)
+}
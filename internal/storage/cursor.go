@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Cursor is the position of a consumer of the outbox, the sequence of the last event it handled.
+// It's stored as text in its own file so the consumer carries on where it stopped after a
+// restart, and the outbox keeps the events it didn't handle yet.
+type Cursor struct {
+	mu   sync.Mutex
+	path string
+	seq  uint64
+}
+
+// OpenCursor reads the cursor stored in the file, which is created at the initial sequence when
+// missing.
+func OpenCursor(path string, initial uint64) (*Cursor, error) {
+	c := &Cursor{path: path, seq: initial}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		if err := c.Advance(initial); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("problem reading cursor: %w", err)
+	}
+	if c.seq, err = strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64); err != nil {
+		return nil, fmt.Errorf("problem reading cursor %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Last returns the sequence of the last event handled.
+func (c *Cursor) Last() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.seq
+}
+
+// Advance records the sequence of the last event handled, the file is replaced at once.
+func (c *Cursor) Advance(seq uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tmp := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strconv.FormatUint(seq, 10)+"\n"), 0640); err != nil {
+		return fmt.Errorf("problem writing cursor: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("problem replacing cursor: %w", err)
+	}
+	c.seq = seq
+	return nil
+}
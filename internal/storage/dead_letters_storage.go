@@ -0,0 +1,64 @@
+package storage // nolint: dupl
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+)
+
+type DeadLettersStorage struct {
+	mu   sync.RWMutex
+	wr   io.WriteSeeker
+	data map[string]*notificationspb.DeadLetter
+}
+
+func NewDeadLettersStorage(wr io.WriteSeeker, data map[string]*notificationspb.DeadLetter) *DeadLettersStorage {
+	return &DeadLettersStorage{
+		wr:   wr,
+		data: data,
+	}
+}
+
+func (s *DeadLettersStorage) Add(ctx context.Context, id string, deadLetter *notificationspb.DeadLetter) (err error) {
+	defer trace(ctx, "DeadLettersStorage.Add")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.data[id]
+	if ok {
+		return ErrDuplicate
+	}
+	s.data[id] = deadLetter
+	return dump(s.wr, s.data)
+}
+
+func (s *DeadLettersStorage) Delete(ctx context.Context, id string) (err error) {
+	defer trace(ctx, "DeadLettersStorage.Delete")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.data[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.data, id)
+	return dump(s.wr, s.data)
+}
+
+// All returns a snapshot of the stored dead letters, the dead letters must not be modified.
+func (s *DeadLettersStorage) All() map[string]*notificationspb.DeadLetter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := make(map[string]*notificationspb.DeadLetter, len(s.data))
+	for id, e := range s.data {
+		all[id] = e
+	}
+	return all
+}
+
+// Sync waits for the change in progress and commits the file to stable storage.
+func (s *DeadLettersStorage) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return flush(s.wr)
+}
@@ -1,21 +1,44 @@
 package storage
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 )
 
+type truncater interface {
+	Truncate(size int64) error
+}
+
+type syncer interface {
+	Sync() error
+}
+
 func dump(wr io.WriteSeeker, data interface{}) error {
 	if _, err := wr.Seek(0, 0); err != nil {
 		return fmt.Errorf("problem rewinding file: %w", err)
 	}
-	b, err := json.Marshal(data)
+	b, err := CodecOf(wr).Encode(data)
 	if err != nil {
 		return fmt.Errorf("problem marshaling data: %w", err)
 	}
 	if _, err = wr.Write(b); err != nil {
 		return fmt.Errorf("problem saving data: %w", err)
 	}
+	// the previous content is left after the data when it was longer
+	if t, ok := wr.(truncater); ok {
+		if err := t.Truncate(int64(len(b))); err != nil {
+			return fmt.Errorf("problem truncating file: %w", err)
+		}
+	}
+	return nil
+}
+
+// flush commits the data written to stable storage when the writer is a file.
+func flush(wr io.WriteSeeker) error {
+	if s, ok := wr.(syncer); ok {
+		if err := s.Sync(); err != nil {
+			return fmt.Errorf("problem syncing file: %w", err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+func TestDumpTruncates(t *testing.T) {
+	f, err := ioutil.TempFile("", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	users := NewUsersStorage(f, map[string]*userspb.User{})
+	if err := users.Add(context.Background(), "1", &userspb.User{Name: "David BP"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.Add(context.Background(), "2", &userspb.User{Name: "Pi the Dog"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.Delete(context.Background(), "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data map[string]*userspb.User
+	if err := Decode(b, &data); err != nil {
+		t.Fatalf("file is corrupted: %s", err)
+	}
+	if _, ok := data["1"]; !ok || len(data) != 1 {
+		t.Errorf("unexpected users saved: %s", b)
+	}
+}
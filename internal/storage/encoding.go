@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// envelope is the format of the data files, the records are encoded like the API encodes them so
+// the files don't change with the Go structs generated from the protos. The records of the
+// encrypted files are replaced by their encryption.
+type envelope struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Records       json.RawMessage `json:"records,omitempty"`
+	Encrypted     *crypt.Sealed   `json:"encrypted,omitempty"`
+}
+
+// null is the record of a nil message.
+var null = json.RawMessage("null")
+
+// Codec encodes the data files.
+type Codec interface {
+	// Encode returns the content of a data file, data is a map of ids to protobuf messages.
+	Encode(data interface{}) ([]byte, error)
+	// Decode reads the content of a data file into data, a pointer to a map of ids to protobuf
+	// messages.
+	Decode(b []byte, data interface{}) error
+}
+
+// Plain is the codec of the files whose records aren't encrypted, it can't read the encrypted
+// files.
+var Plain Codec = codec{}
+
+// Encrypted returns the codec of the files whose records are encrypted with the primary key of
+// the keyring. It reads the files encrypted with any of its keys and the files not encrypted, they
+// are encrypted when written back.
+func Encrypted(keys *crypt.Keyring) Codec {
+	return codec{keys: keys}
+}
+
+// CodecOf returns the codec of a data file, Plain unless it's an encrypted file.
+func CodecOf(f interface{}) Codec {
+	if e, ok := f.(*EncryptedFile); ok {
+		return e.codec
+	}
+	return Plain
+}
+
+// Encode encodes with Plain.
+func Encode(data interface{}) ([]byte, error) {
+	return Plain.Encode(data)
+}
+
+// Decode decodes with Plain. An empty file has no records, and the files written before the
+// envelope, maps of the messages encoded with encoding/json, are read like they were written.
+func Decode(b []byte, data interface{}) error {
+	return Plain.Decode(b, data)
+}
+
+// KeyID returns the id of the key the content of a data file is encrypted with, empty when it isn't.
+// The records aren't decrypted, no key is needed.
+func KeyID(b []byte) (string, error) {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return "", nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return "", err
+	}
+	if _, ok := fields["schemaVersion"]; !ok {
+		return "", nil
+	}
+	var env envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return "", err
+	}
+	if env.Encrypted == nil {
+		return "", nil
+	}
+	return env.Encrypted.KeyID, nil
+}
+
+type codec struct {
+	keys *crypt.Keyring
+}
+
+func (c codec) Encode(data interface{}) ([]byte, error) {
+	m := reflect.ValueOf(data)
+	if m.Kind() != reflect.Map || m.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("can't encode %T, a map of ids to messages expected", data)
+	}
+	var marshaler jsonpb.Marshaler
+	records := make(map[string]json.RawMessage, m.Len())
+	iter := m.MapRange()
+	for iter.Next() {
+		id := iter.Key().String()
+		if iter.Value().IsNil() {
+			records[id] = null
+			continue
+		}
+		msg, ok := iter.Value().Interface().(proto.Message)
+		if !ok {
+			return nil, fmt.Errorf("can't encode %T, a map of ids to messages expected", data)
+		}
+		s, err := marshaler.MarshalToString(msg)
+		if err != nil {
+			return nil, fmt.Errorf("problem encoding %s: %w", id, err)
+		}
+		records[id] = json.RawMessage(s)
+	}
+	b, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	env := envelope{SchemaVersion: datadir.SchemaVersion, Records: b}
+	if c.keys != nil {
+		sealed, err := c.keys.Seal(b)
+		if err != nil {
+			return nil, fmt.Errorf("problem encrypting records: %w", err)
+		}
+		env.Records, env.Encrypted = nil, sealed
+	}
+	return json.Marshal(env)
+}
+
+func (c codec) Decode(b []byte, data interface{}) error {
+	p := reflect.ValueOf(data)
+	if p.Kind() != reflect.Ptr || p.Elem().Kind() != reflect.Map || p.Elem().Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("can't decode into %T, a pointer to a map of ids to messages expected", data)
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	if _, ok := fields["schemaVersion"]; !ok {
+		return json.Unmarshal(b, data)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return err
+	}
+	if env.SchemaVersion != datadir.SchemaVersion {
+		return fmt.Errorf("data has schema version %d, %d expected", env.SchemaVersion, datadir.SchemaVersion)
+	}
+	if env.Encrypted != nil {
+		if c.keys == nil {
+			return fmt.Errorf("data is encrypted with key %s, no encryption key given", env.Encrypted.KeyID)
+		}
+		plaintext, err := c.keys.Open(env.Encrypted)
+		if err != nil {
+			return err
+		}
+		env.Records = plaintext
+	}
+	var records map[string]json.RawMessage
+	if len(env.Records) > 0 {
+		if err := json.Unmarshal(env.Records, &records); err != nil {
+			return err
+		}
+	}
+
+	m := p.Elem()
+	if m.IsNil() {
+		m.Set(reflect.MakeMapWithSize(m.Type(), len(records)))
+	}
+	typ := m.Type().Elem()
+	if typ.Kind() != reflect.Ptr || !typ.Implements(reflect.TypeOf((*proto.Message)(nil)).Elem()) {
+		return fmt.Errorf("can't decode into %T, a pointer to a map of ids to messages expected", data)
+	}
+	var unmarshaler jsonpb.Unmarshaler
+	for id, raw := range records {
+		key := reflect.ValueOf(id).Convert(m.Type().Key())
+		if bytes.Equal(raw, null) {
+			m.SetMapIndex(key, reflect.Zero(typ))
+			continue
+		}
+		v := reflect.New(typ.Elem())
+		if err := unmarshaler.Unmarshal(bytes.NewReader(raw), v.Interface().(proto.Message)); err != nil {
+			return fmt.Errorf("problem decoding %s: %w", id, err)
+		}
+		m.SetMapIndex(key, v)
+	}
+	return nil
+}
+
+// EncryptedFile is a data file whose records are encrypted, it's used in place of the file by the
+// store.
+type EncryptedFile struct {
+	io.ReadWriteSeeker
+	codec Codec
+}
+
+// Encrypt returns the file encrypting its records with the keys.
+func Encrypt(f io.ReadWriteSeeker, keys *crypt.Keyring) *EncryptedFile {
+	return &EncryptedFile{ReadWriteSeeker: f, codec: Encrypted(keys)}
+}
+
+// Truncate truncates the file when it can be.
+func (f *EncryptedFile) Truncate(size int64) error {
+	if t, ok := f.ReadWriteSeeker.(truncater); ok {
+		return t.Truncate(size)
+	}
+	return nil
+}
+
+// Sync commits the file to stable storage when it can be.
+func (f *EncryptedFile) Sync() error {
+	if s, ok := f.ReadWriteSeeker.(syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
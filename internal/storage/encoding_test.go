@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncode(t *testing.T) {
+	users := map[string]*userspb.User{
+		"u1": {
+			Name:           "Pi the Dog",
+			ContactDetails: []*userspb.User_ContactDetails{{Platform: userspb.User_ContactDetails_FACEBOOK, Identifier: "@pi"}},
+		},
+		"u2": nil,
+	}
+	b, err := Encode(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"schemaVersion":2,"records":{"u1":{"name":"Pi the Dog","contactDetails":[{"platform":"FACEBOOK","identifier":"@pi"}]},"u2":null}}`
+	if string(b) != want {
+		t.Errorf("Encode() = %s, want %s", b, want)
+	}
+
+	got := make(map[string]*userspb.User)
+	if err := Decode(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(users, got); diff != "" {
+		t.Errorf("decoded users differ (-want +got):\n%s", diff)
+	}
+
+	if _, err := Encode([]*userspb.User{}); err == nil {
+		t.Error("expected an error encoding a slice")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]*userspb.User
+		wantErr string
+	}{
+		{
+			name: "empty",
+			data: " \n",
+			want: map[string]*userspb.User{},
+		},
+		{
+			name: "no data",
+			data: "{}",
+			want: map[string]*userspb.User{},
+		},
+		{
+			name: "first schema version",
+			data: `{"u1":{"name":"Pi the Dog","contact_details":[{"platform":3,"identifier":"@pi"}]}}`,
+			want: map[string]*userspb.User{"u1": {
+				Name:           "Pi the Dog",
+				ContactDetails: []*userspb.User_ContactDetails{{Platform: userspb.User_ContactDetails_FACEBOOK, Identifier: "@pi"}},
+			}},
+		},
+		{
+			name: "envelope",
+			data: `{"schemaVersion":2,"records":{"u1":{"name":"Ada","contactDetails":[{"platform":"EMAIL","identifier":"ada@example.com"}]}}}`,
+			want: map[string]*userspb.User{"u1": {
+				Name:           "Ada",
+				ContactDetails: []*userspb.User_ContactDetails{{Platform: userspb.User_ContactDetails_EMAIL, Identifier: "ada@example.com"}},
+			}},
+		},
+		{
+			name:    "newer schema version",
+			data:    `{"schemaVersion":3,"records":{}}`,
+			wantErr: "schema version 3",
+		},
+		{
+			name:    "unknown field",
+			data:    `{"schemaVersion":2,"records":{"u1":{"nickname":"Ada"}}}`,
+			wantErr: "problem decoding u1",
+		},
+		{
+			name:    "not JSON",
+			data:    "users",
+			wantErr: "invalid character",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string]*userspb.User)
+			err := Decode([]byte(tt.data), &got)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Decode() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Decode() differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEncrypted(t *testing.T) {
+	keys, err := crypt.ParseKeys("k1:" + strings.Repeat("A", 43) + "=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := map[string]*userspb.User{"u1": {Name: "Ada"}}
+	b, err := Encrypted(keys).Encode(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "Ada") || !strings.Contains(string(b), `"keyId":"k1"`) {
+		t.Errorf("expected the records encrypted with k1, got %s", b)
+	}
+
+	got := make(map[string]*userspb.User)
+	if err := Decode(b, &got); err == nil || !strings.Contains(err.Error(), "no encryption key given") {
+		t.Errorf("expected an error decoding without the key, got %v", err)
+	}
+	if err := Encrypted(keys).Decode(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(users, got); diff != "" {
+		t.Errorf("decoded users differ (-want +got):\n%s", diff)
+	}
+
+	// the files not encrypted yet are read
+	plain, err := Encode(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = make(map[string]*userspb.User)
+	if err := Encrypted(keys).Decode(plain, &got); err != nil || len(got) != 1 {
+		t.Errorf("expected the plain users, got %v, %v", got, err)
+	}
+}
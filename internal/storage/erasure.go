@@ -0,0 +1,51 @@
+package storage
+
+import "github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+
+// References reports whether the user took part in the request.
+func References(r *requestspb.Request, userID string) bool {
+	if r.RequesterId == userID || r.VolunteerId == userID {
+		return true
+	}
+	for _, a := range r.Answers {
+		if a.VolunteerId == userID {
+			return true
+		}
+	}
+	for _, rating := range r.Ratings {
+		if rating.RaterId == userID || rating.RateeId == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Pseudonymize replaces the id of the user with the pseudonym in the request and clears the texts
+// written by or about the user, the states and scores are kept.
+func Pseudonymize(r *requestspb.Request, userID, pseudonym string) *requestspb.Request {
+	if r.RequesterId == userID {
+		r.RequesterId = pseudonym
+		r.Title = ""
+		r.Body = ""
+	}
+	if r.VolunteerId == userID {
+		r.VolunteerId = pseudonym
+	}
+	for _, a := range r.Answers {
+		if a.VolunteerId == userID {
+			a.VolunteerId = pseudonym
+			a.Comment = ""
+		}
+	}
+	for _, rating := range r.Ratings {
+		if rating.RaterId == userID {
+			rating.RaterId = pseudonym
+			rating.Comment = ""
+		}
+		if rating.RateeId == userID {
+			rating.RateeId = pseudonym
+			rating.Comment = ""
+		}
+	}
+	return r
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFeedSize         = 1024
+	defaultSubscriptionSize = 256
+)
+
+var (
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+	ErrResumeTokenExpired = errors.New("resume token expired")
+)
+
+type EventType int
+
+const (
+	EventCreated EventType = iota
+	EventUpdated
+	EventStateChanged
+	EventDeleted
+)
+
+// Event is a change applied to a store, Value holds the element after the change (or the last
+// known element for deletions) and Previous the element before the change (nil for creations).
+// Neither must be modified by subscribers.
+type Event struct {
+	Seq      uint64
+	Type     EventType
+	ID       string
+	Value    interface{}
+	Previous interface{}
+	Token    string
+}
+
+// Feed keeps the latest changes of a store in memory so subscribers can follow them live and
+// resume after a reconnection. The resume tokens are made of the epoch of the feed, set when it's
+// created, and the sequence of the change: they're only valid for the lifetime of the process, the
+// tokens of another epoch fail with ErrResumeTokenExpired like the tokens of the changes dropped.
+type Feed struct {
+	mu     sync.Mutex
+	epoch  string
+	seq    uint64
+	events []Event
+	size   int
+	subs   map[*Subscription]struct{}
+}
+
+func NewFeed(size int) *Feed {
+	return &Feed{
+		epoch: strconv.FormatInt(time.Now().UnixNano(), 36),
+		size:  size,
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+func (f *Feed) Publish(t EventType, id string, value, previous interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	e := Event{
+		Seq:      f.seq,
+		Type:     t,
+		ID:       id,
+		Value:    value,
+		Previous: previous,
+		Token:    fmt.Sprintf("%s-%d", f.epoch, f.seq),
+	}
+	f.events = append(f.events, e)
+	if len(f.events) > f.size {
+		f.events = f.events[len(f.events)-f.size:]
+	}
+
+	for sub := range f.subs {
+		select {
+		case sub.events <- e:
+		default:
+			// the subscriber can't keep up, it has to resume with the last token it received
+			sub.overflow = true
+			f.remove(sub)
+		}
+	}
+}
+
+// Subscribe returns a subscription to the changes published after the given resume token, an
+// empty token only follows new changes.
+func (f *Feed) Subscribe(token string) (*Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var backlog []Event
+	if token != "" {
+		seq, err := f.parseToken(token)
+		if err != nil {
+			return nil, err
+		}
+		if len(f.events) > 0 && seq < f.events[0].Seq-1 {
+			return nil, ErrResumeTokenExpired
+		}
+		for _, e := range f.events {
+			if e.Seq > seq {
+				backlog = append(backlog, e)
+			}
+		}
+	}
+
+	sub := &Subscription{
+		feed:   f,
+		events: make(chan Event, len(backlog)+defaultSubscriptionSize),
+	}
+	for _, e := range backlog {
+		sub.events <- e
+	}
+	f.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Recent returns up to n of the latest changes, oldest first.
+func (f *Feed) Recent(n int) []Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if n > len(f.events) {
+		n = len(f.events)
+	}
+	events := make([]Event, n)
+	copy(events, f.events[len(f.events)-n:])
+	return events
+}
+
+func (f *Feed) parseToken(token string) (uint64, error) {
+	i := strings.LastIndex(token, "-")
+	if i < 0 {
+		return 0, ErrInvalidResumeToken
+	}
+	seq, err := strconv.ParseUint(token[i+1:], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidResumeToken
+	}
+	if token[:i] != f.epoch || seq > f.seq {
+		return 0, ErrResumeTokenExpired
+	}
+	return seq, nil
+}
+
+func (f *Feed) remove(sub *Subscription) {
+	if _, ok := f.subs[sub]; !ok {
+		return
+	}
+	delete(f.subs, sub)
+	close(sub.events)
+}
+
+type Subscription struct {
+	feed     *Feed
+	events   chan Event
+	overflow bool
+}
+
+// Events returns the channel the changes are delivered on, it gets closed once the subscription
+// is closed or the subscriber falls behind.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Overflowed reports whether the subscription was dropped because the subscriber fell behind.
+func (s *Subscription) Overflowed() bool {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	return s.overflow
+}
+
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.remove(s)
+}
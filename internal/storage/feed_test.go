@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFeedResume(t *testing.T) {
+	f := NewFeed(3)
+	for _, id := range []string{"a", "b", "c"} {
+		f.Publish(EventCreated, id, nil, nil)
+	}
+	token := f.Recent(3)[0].Token
+
+	f.Publish(EventCreated, "d", nil, nil)
+
+	sub, err := f.Subscribe(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	f.Publish(EventDeleted, "a", nil, nil)
+
+	var ids []string
+	for len(ids) < 4 {
+		e := <-sub.Events()
+		ids = append(ids, e.ID)
+	}
+	if ids[0] != "b" || ids[1] != "c" || ids[2] != "d" || ids[3] != "a" {
+		t.Errorf("unexpected events replayed: %v", ids)
+	}
+}
+
+func TestFeedResumeErrors(t *testing.T) {
+	f := NewFeed(2)
+	for _, id := range []string{"a", "b", "c", "d"} {
+		f.Publish(EventCreated, id, nil, nil)
+	}
+
+	cases := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{
+			name:  "too old",
+			token: f.epoch + "-1",
+			err:   ErrResumeTokenExpired,
+		},
+		{
+			name:  "other process",
+			token: "abc-4",
+			err:   ErrResumeTokenExpired,
+		},
+		{
+			name:  "malformed",
+			token: "abc",
+			err:   ErrInvalidResumeToken,
+		},
+		{
+			name:  "oldest kept",
+			token: f.epoch + "-2",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			sub, err := f.Subscribe(tc.token)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected %v, got %v", tc.err, err)
+			}
+			if sub != nil {
+				sub.Close()
+			}
+		})
+	}
+}
+
+func TestFeedOverflow(t *testing.T) {
+	f := NewFeed(1)
+	sub, err := f.Subscribe("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i <= defaultSubscriptionSize; i++ {
+		f.Publish(EventUpdated, "a", nil, nil)
+	}
+
+	n := 0
+	for range sub.Events() {
+		n++
+	}
+	if n != defaultSubscriptionSize {
+		t.Errorf("expected %d events before dropping the subscriber, got %d", defaultSubscriptionSize, n)
+	}
+	if !sub.Overflowed() {
+		t.Error("expected subscription to be flagged as overflowed")
+	}
+	sub.Close()
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/euvsvirus-banan/backend/internal/crypt"
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+)
+
+// Migrations are the upgrades of the data files of a data directory, in order. The files stored
+// outside the directory aren't migrated, the older formats they can be in are still read and
+// they're upgraded with their first change.
+var Migrations = []datadir.Migration{
+	{
+		Version:     2,
+		Description: "store the records in a versioned envelope, encoded like the API encodes them",
+		Apply:       migrateEnvelope,
+	},
+}
+
+// dataFiles are the data files stored in a data directory, with the map their records are read
+// into.
+var dataFiles = map[string]func() interface{}{
+	"users.json":        func() interface{} { return &map[string]*userspb.User{} },
+	"requests.json":     func() interface{} { return &map[string]*requestspb.Request{} },
+	"news.json":         func() interface{} { return &map[string]*newspb.News{} },
+	"preferences.json":  func() interface{} { return &map[string]*notificationspb.Preferences{} },
+	"dead_letters.json": func() interface{} { return &map[string]*notificationspb.DeadLetter{} },
+	"webhooks.json":     func() interface{} { return &map[string]*webhookspb.Webhook{} },
+	"outbox.json":       func() interface{} { return &map[string]*webhookspb.Event{} },
+}
+
+// migrateEnvelope rewrites the maps of messages encoded with encoding/json, with enums as numbers
+// and the fields named after the Go struct tags, in the envelope.
+func migrateEnvelope(d *datadir.Dir) error {
+	for name, data := range dataFiles {
+		if err := rewrite(d.Path(name), data(), Plain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reencrypt rewrites the data files of a data directory encrypted with the primary key of the
+// keyring, the files not encrypted yet included.
+func Reencrypt(d *datadir.Dir, keys *crypt.Keyring) error {
+	for name, data := range dataFiles {
+		if err := rewrite(d.Path(name), data(), Encrypted(keys)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KeyIDs returns the paths of the data files of a data directory by the id of the key they're
+// encrypted with, the files not encrypted are left out.
+func KeyIDs(d *datadir.Dir) (map[string][]string, error) {
+	ids := make(map[string][]string)
+	for name := range dataFiles {
+		path := d.Path(name)
+		b, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		id, err := KeyID(b)
+		if err != nil {
+			return nil, fmt.Errorf("problem reading %s: %w", path, err)
+		}
+		if id != "" {
+			ids[id] = append(ids[id], path)
+		}
+	}
+	for _, paths := range ids {
+		sort.Strings(paths)
+	}
+	return ids, nil
+}
+
+// rewrite reads a data file and writes it back with the codec, a missing file is left missing.
+func rewrite(path string, data interface{}, c Codec) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := c.Decode(b, data); err != nil {
+		return fmt.Errorf("problem reading %s: %w", path, err)
+	}
+	b, err = c.Encode(reflect.ValueOf(data).Elem().Interface())
+	if err != nil {
+		return fmt.Errorf("problem encoding %s: %w", path, err)
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("problem writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("problem replacing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/datadir"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMigrations(t *testing.T) {
+	for i, m := range Migrations {
+		if m.Version != i+2 {
+			t.Errorf("migration %d is to schema version %d, want %d", i, m.Version, i+2)
+		}
+	}
+	if last := Migrations[len(Migrations)-1].Version; last != datadir.SchemaVersion {
+		t.Errorf("migrations end at schema version %d, want %d", last, datadir.SchemaVersion)
+	}
+}
+
+func TestMigrateEnvelope(t *testing.T) {
+	path, err := ioutil.TempDir("", "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+	files := map[string]string{
+		"requests.json": `{"r1":{"title":"groceries","requester_id":"u1","state":1}}`,
+		"webhooks.json": `{}`,
+		"news.json":     "",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(path, name), []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d, err := datadir.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	if err := migrateEnvelope(d); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"requests.json": `{"schemaVersion":2,"records":{"r1":{"title":"groceries","requesterId":"u1","state":"ACCEPTED"}}}`,
+		"webhooks.json": `{"schemaVersion":2,"records":{}}`,
+		"news.json":     `{"schemaVersion":2,"records":{}}`,
+	}
+	for name, data := range want {
+		b, err := ioutil.ReadFile(filepath.Join(path, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != data {
+			t.Errorf("%s = %s, want %s", name, b, data)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(path, "requests.json")); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("expected the mode of the file to be kept, got %v, %v", info.Mode(), err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "users.json")); !os.IsNotExist(err) {
+		t.Errorf("expected the missing users file to be left missing, got %v", err)
+	}
+
+	// the migrated files are left as they are
+	if err := migrateEnvelope(d); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(path, "requests.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := make(map[string]*requestspb.Request)
+	if err := Decode(b, &requests); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]*requestspb.Request{"r1": {Title: "groceries", RequesterId: "u1", State: requestspb.Request_ACCEPTED}}, requests); diff != "" {
+		t.Errorf("requests differ (-want +got):\n%s", diff)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(path, "webhooks.json"), []byte(`{"w1":`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateEnvelope(d); err == nil {
+		t.Error("expected an error migrating a corrupted file")
+	}
+}
@@ -1,58 +1,173 @@
 package storage // nolint: dupl
 
 import (
+	"context"
 	"io"
+	"sync"
 
 	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/proto"
 )
 
+const newsEntity = "news"
+
 type NewsStorage struct {
-	wr   io.WriteSeeker
-	data map[string]*newspb.News
+	mu     sync.RWMutex
+	wr     io.WriteSeeker
+	data   map[string]*newspb.News
+	feed   *Feed
+	outbox *Outbox
 }
 
 func NewNewsStorage(wr io.WriteSeeker, data map[string]*newspb.News) *NewsStorage {
 	return &NewsStorage{
 		wr:   wr,
 		data: data,
+		feed: NewFeed(defaultFeedSize),
 	}
 }
 
-func (s *NewsStorage) Add(id string, new *newspb.News) error {
+// SetOutbox makes the storage record the domain events of the changes in the outbox.
+func (s *NewsStorage) SetOutbox(outbox *Outbox) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbox = outbox
+}
+
+func (s *NewsStorage) Add(ctx context.Context, id string, new *newspb.News) (err error) {
+	defer trace(ctx, "NewsStorage.Add")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	_, ok := s.data[id]
 	if ok {
 		return ErrDuplicate
 	}
 	s.data[id] = new
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(NewsAdded, newsEntity, id, new), func() {
+		delete(s.data, id)
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, newsEntity, id, nil, new)
+	s.feed.Publish(EventCreated, id, new, nil)
+	return nil
 }
 
-func (s *NewsStorage) Update(id string, element *newspb.News) error {
-	_, ok := s.data[id]
+// AddAll adds the news at once, the file is written once. None is added when one of the ids
+// already exists.
+func (s *NewsStorage) AddAll(ctx context.Context, news map[string]*newspb.News) (err error) {
+	defer trace(ctx, "NewsStorage.AddAll")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids, err := newIDs(s.data, news)
+	if err != nil {
+		return err
+	}
+	events := make([]*webhookspb.Event, len(ids))
+	for i, id := range ids {
+		s.data[id] = news[id]
+		events[i] = newEvent(NewsAdded, newsEntity, id, news[id])
+	}
+	if err := commitAll(s.wr, s.data, s.outbox, events, func() {
+		for _, id := range ids {
+			delete(s.data, id)
+		}
+	}); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		recordChange(ctx, newsEntity, id, nil, news[id])
+		s.feed.Publish(EventCreated, id, news[id], nil)
+	}
+	return nil
+}
+
+func (s *NewsStorage) Update(ctx context.Context, id string, element *newspb.News) (err error) {
+	defer trace(ctx, "NewsStorage.Update")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
 	if !ok {
 		return ErrNotFound
 	}
 	s.data[id] = element
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(NewsUpdated, newsEntity, id, element), func() {
+		s.data[id] = old
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, newsEntity, id, old, element)
+	s.feed.Publish(EventUpdated, id, element, old)
+	return nil
 }
 
-func (s *NewsStorage) Delete(id string) error {
-	_, ok := s.data[id]
+func (s *NewsStorage) Delete(ctx context.Context, id string) (err error) {
+	defer trace(ctx, "NewsStorage.Delete")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
 	if !ok {
 		return ErrNotFound
 	}
 	delete(s.data, id)
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(NewsDeleted, newsEntity, id, old), func() {
+		s.data[id] = old
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, newsEntity, id, old, nil)
+	s.feed.Publish(EventDeleted, id, old, old)
+	return nil
 }
 
+// Get returns a copy of the news, changes have to be saved with Update.
 func (s *NewsStorage) Get(id string) (*newspb.News, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	e, ok := s.data[id]
 	if !ok {
 		return nil, ErrNotFound
 	}
-	return e, nil
+	return proto.Clone(e).(*newspb.News), nil
 }
 
+// All returns a snapshot of the stored news, the news must not be modified.
 func (s *NewsStorage) All() map[string]*newspb.News {
-	return s.data
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := make(map[string]*newspb.News, len(s.data))
+	for id, e := range s.data {
+		all[id] = e
+	}
+	return all
+}
+
+// WatchAll returns a snapshot of the stored news like All and follows the changes made after it,
+// a change is either in the snapshot or followed.
+func (s *NewsStorage) WatchAll() (map[string]*newspb.News, *Subscription, error) {
+	// the changes are published with the lock held
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sub, err := s.feed.Subscribe("")
+	if err != nil {
+		return nil, nil, err
+	}
+	all := make(map[string]*newspb.News, len(s.data))
+	for id, e := range s.data {
+		all[id] = e
+	}
+	return all, sub, nil
+}
+
+// Watch follows the changes made to the news after the given resume token.
+func (s *NewsStorage) Watch(token string) (*Subscription, error) {
+	return s.feed.Subscribe(token)
+}
+
+// Sync waits for the change in progress and commits the file to stable storage.
+func (s *NewsStorage) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return flush(s.wr)
 }
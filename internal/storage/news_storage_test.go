@@ -0,0 +1,47 @@
+package storage_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+)
+
+func TestNewsWatchAll(t *testing.T) {
+	news := storagetest.News(nil)
+	// the news are added while the watch starts, fewer than a subscription holds
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			if i == 10 {
+				close(started)
+			}
+			if err := news.Add(context.Background(), strconv.Itoa(i), &newspb.News{Title: "water cut"}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	<-started
+	all, sub, err := news.WatchAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+	<-done
+
+	// every news is either in the snapshot or followed, never both
+	for followed := 0; len(all)+followed < 200; followed++ {
+		e, ok := <-sub.Events()
+		if !ok {
+			t.Fatal("subscription closed")
+		}
+		if _, ok := all[e.ID]; ok {
+			t.Fatalf("news %s is both in the snapshot and followed", e.ID)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// Domain events recorded in the outbox.
+const (
+	UserAdded   = "UserAdded"
+	UserUpdated = "UserUpdated"
+	UserDeleted = "UserDeleted"
+	UserRated   = "UserRated"
+	UserErased  = "UserErased"
+
+	RequestAdded    = "RequestAdded"
+	RequestUpdated  = "RequestUpdated"
+	RequestDeleted  = "RequestDeleted"
+	RequestAnswered = "RequestAnswered"
+	HelpAccepted    = "HelpAccepted"
+	HelpCompleted   = "HelpCompleted"
+	HelpCancelled   = "HelpCancelled"
+	HelpRated       = "HelpRated"
+
+	NewsAdded   = "NewsAdded"
+	NewsUpdated = "NewsUpdated"
+	NewsDeleted = "NewsDeleted"
+)
+
+// Outbox records the domain events along with the changes made to the stores, so they can be
+// delivered to third parties once the change is saved.
+type Outbox struct {
+	mu      sync.Mutex
+	wr      io.WriteSeeker
+	data    map[string]*webhookspb.Event
+	seq     uint64
+	waiting chan struct{}
+	// consumers are the cursors of the consumers other than the webhooks, Prune keeps the events
+	// they didn't handle
+	consumers []*Cursor
+}
+
+func NewOutbox(wr io.WriteSeeker, data map[string]*webhookspb.Event) *Outbox {
+	o := &Outbox{
+		wr:      wr,
+		data:    data,
+		waiting: make(chan struct{}),
+	}
+	for _, e := range data {
+		if e.Sequence > o.seq {
+			o.seq = e.Sequence
+		}
+	}
+	return o
+}
+
+// Last returns the sequence of the last event recorded.
+func (o *Outbox) Last() uint64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.seq
+}
+
+// Since returns up to limit events recorded after the given sequence, in order.
+func (o *Outbox) Since(seq uint64, limit int) []*webhookspb.Event {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var events []*webhookspb.Event
+	for _, e := range o.data {
+		if e.Sequence > seq {
+			events = append(events, e)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Sequence < events[j].Sequence
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events
+}
+
+// Wait returns a channel closed once new events are recorded.
+func (o *Outbox) Wait() <-chan struct{} {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.waiting
+}
+
+// AddConsumer makes Prune keep the events after the cursor.
+func (o *Outbox) AddConsumer(c *Cursor) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.consumers = append(o.consumers, c)
+}
+
+// Prune removes the events up to the given sequence, the events not handled by the consumers are
+// kept.
+func (o *Outbox) Prune(seq uint64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, c := range o.consumers {
+		if last := c.Last(); last < seq {
+			seq = last
+		}
+	}
+	var pruned bool
+	for k, e := range o.data {
+		if e.Sequence <= seq {
+			delete(o.data, k)
+			pruned = true
+		}
+	}
+	if !pruned {
+		return nil
+	}
+	return dump(o.wr, o.data)
+}
+
+// Sync waits for the change in progress and commits the file to stable storage.
+func (o *Outbox) Sync() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return flush(o.wr)
+}
+
+// append records the events in order, they're written at once.
+func (o *Outbox) append(events []*webhookspb.Event) ([]uint64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	seqs := make([]uint64, len(events))
+	for i, e := range events {
+		o.seq++
+		e.Sequence = o.seq
+		o.data[strconv.FormatUint(e.Sequence, 10)] = e
+		seqs[i] = e.Sequence
+	}
+	if err := dump(o.wr, o.data); err != nil {
+		for _, seq := range seqs {
+			delete(o.data, strconv.FormatUint(seq, 10))
+		}
+		o.seq -= uint64(len(seqs))
+		return nil, err
+	}
+	return seqs, nil
+}
+
+// redact replaces the payloads of the pending events of the entity with fn applied to them, the
+// data erased isn't delivered afterwards. The events are replaced rather than changed, they can be
+// in delivery.
+func (o *Outbox) redact(entityType, id string, fn func(payload string) (string, error)) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	redacted := make(map[string]*webhookspb.Event)
+	for k, e := range o.data {
+		if e.EntityType != entityType || e.EntityId != id {
+			continue
+		}
+		payload, err := fn(e.Payload)
+		if err != nil {
+			return err
+		}
+		r := proto.Clone(e).(*webhookspb.Event)
+		r.Payload = payload
+		redacted[k] = r
+	}
+	if len(redacted) == 0 {
+		return nil
+	}
+	old := make(map[string]*webhookspb.Event, len(redacted))
+	for k, e := range redacted {
+		old[k], o.data[k] = o.data[k], e
+	}
+	if err := dump(o.wr, o.data); err != nil {
+		for k, e := range old {
+			o.data[k] = e
+		}
+		return err
+	}
+	return nil
+}
+
+func (o *Outbox) discard(seqs []uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, seq := range seqs {
+		delete(o.data, strconv.FormatUint(seq, 10))
+	}
+	_ = dump(o.wr, o.data)
+}
+
+func (o *Outbox) signal() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	close(o.waiting)
+	o.waiting = make(chan struct{})
+}
+
+func newEvent(eventType, entityType, id string, entity proto.Message) *webhookspb.Event {
+	var b bytes.Buffer
+	_ = (&jsonpb.Marshaler{}).Marshal(&b, entity)
+	return &webhookspb.Event{
+		Type:         eventType,
+		EntityType:   entityType,
+		EntityId:     id,
+		CreationDate: time.Now().UTC().Format(time.RFC3339),
+		Payload:      b.String(),
+	}
+}
+
+// commit saves the data along with the event describing the change. The event is recorded first
+// so a change is never saved without it, if the data can't be saved the event is discarded and
+// rollback is called to undo the change made in memory.
+func commit(wr io.WriteSeeker, data interface{}, outbox *Outbox, event *webhookspb.Event, rollback func()) error {
+	return commitAll(wr, data, outbox, []*webhookspb.Event{event}, rollback)
+}
+
+// commitAll saves the data along with the events of a batch of changes, like commit.
+func commitAll(wr io.WriteSeeker, data interface{}, outbox *Outbox, events []*webhookspb.Event, rollback func()) error {
+	return commitFiles([]dataFile{{wr, data}}, outbox, events, rollback)
+}
+
+// dataFile is the file of a store along with its data.
+type dataFile struct {
+	wr   io.WriteSeeker
+	data interface{}
+}
+
+// commitFiles saves the data of the stores changed together along with the events, like commit.
+// When a file can't be saved the files saved before it are written back with the data rolled back.
+func commitFiles(files []dataFile, outbox *Outbox, events []*webhookspb.Event, rollback func()) error {
+	var seqs []uint64
+	if outbox != nil {
+		var err error
+		if seqs, err = outbox.append(events); err != nil {
+			rollback()
+			return err
+		}
+	}
+	for i, f := range files {
+		if err := dump(f.wr, f.data); err != nil {
+			rollback()
+			for _, saved := range files[:i] {
+				if undoErr := dump(saved.wr, saved.data); undoErr != nil {
+					err = fmt.Errorf("%w, and problem writing back the previous data: %v", err, undoErr)
+				}
+			}
+			if outbox != nil {
+				outbox.discard(seqs)
+			}
+			return err
+		}
+	}
+	if outbox != nil {
+		outbox.signal()
+	}
+	return nil
+}
+
+// newIDs returns the sorted ids of the records added to data, both maps of ids to messages. The
+// error wraps ErrDuplicate when one of them is already in data.
+func newIDs(data, added interface{}) ([]string, error) {
+	d, a := reflect.ValueOf(data), reflect.ValueOf(added)
+	ids := make([]string, 0, a.Len())
+	for _, k := range a.MapKeys() {
+		if d.MapIndex(k).IsValid() {
+			return nil, fmt.Errorf("%s: %w", k.String(), ErrDuplicate)
+		}
+		ids = append(ids, k.String())
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
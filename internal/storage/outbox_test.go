@@ -0,0 +1,121 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/golang/protobuf/proto"
+)
+
+func TestOutboxCommit(t *testing.T) {
+	outbox := storagetest.Outbox(nil)
+	data := &storagetest.File{}
+	requests := storage.NewRequestsStorage(data, map[string]*requestspb.Request{})
+	requests.SetOutbox(outbox)
+
+	if err := requests.Add(context.Background(), "a", &requestspb.Request{Title: "groceries"}); err != nil {
+		t.Fatal(err)
+	}
+	events := outbox.Since(0, 10)
+	if len(events) != 1 || events[0].Type != storage.RequestAdded || events[0].EntityId != "a" {
+		t.Fatalf("unexpected events: %v", events)
+	}
+	if !strings.Contains(events[0].Payload, `"title":"groceries"`) {
+		t.Errorf("unexpected payload: %s", events[0].Payload)
+	}
+
+	data.Err = errors.New("disk full")
+	if err := requests.Delete(context.Background(), "a"); err == nil {
+		t.Fatal("expected delete to fail")
+	}
+	if _, err := requests.Get("a"); err != nil {
+		t.Error("expected the deletion to be rolled back")
+	}
+	if events := outbox.Since(0, 10); len(events) != 1 {
+		t.Errorf("expected the event of the failed change to be discarded, got %v", events)
+	}
+}
+
+func TestOutboxCommitAll(t *testing.T) {
+	outbox := storagetest.Outbox(nil)
+	data := &storagetest.File{}
+	requests := storage.NewRequestsStorage(data, map[string]*requestspb.Request{"a": {Title: "groceries"}})
+	requests.SetOutbox(outbox)
+
+	// none is added when one exists
+	err := requests.AddAll(context.Background(), map[string]*requestspb.Request{"b": {Title: "plumbing"}, "a": {Title: "again"}})
+	if !errors.Is(err, storage.ErrDuplicate) {
+		t.Errorf("expected storage.ErrDuplicate, got %v", err)
+	}
+	if _, err := requests.Get("b"); err == nil {
+		t.Error("expected b not to be added")
+	}
+
+	if err := requests.AddAll(context.Background(), map[string]*requestspb.Request{"c": {Title: "dog walking"}, "b": {Title: "plumbing"}}); err != nil {
+		t.Fatal(err)
+	}
+	events := outbox.Since(0, 10)
+	if len(events) != 2 || events[0].EntityId != "b" || events[1].EntityId != "c" || events[1].Type != storage.RequestAdded {
+		t.Fatalf("expected the events of b and c in order, got %v", events)
+	}
+
+	data.Err = errors.New("disk full")
+	if err := requests.AddAll(context.Background(), map[string]*requestspb.Request{"d": {Title: "pharmacy"}}); err == nil {
+		t.Fatal("expected the batch to fail")
+	}
+	if _, err := requests.Get("d"); err == nil {
+		t.Error("expected the batch to be rolled back")
+	}
+	if events := outbox.Since(0, 10); len(events) != 2 {
+		t.Errorf("expected the events of the failed batch to be discarded, got %v", events)
+	}
+}
+
+func TestUserEventsHideContactDetails(t *testing.T) {
+	outbox := storagetest.Outbox(nil)
+	users := storagetest.Users(nil)
+	users.SetOutbox(outbox)
+	requests := storagetest.Requests(map[string]*requestspb.Request{
+		"a": {RequesterId: "Brown", VolunteerId: "Blue", State: requestspb.Request_COMPLETED},
+	})
+	requests.SetOutbox(outbox)
+
+	user := &userspb.User{
+		Name:           "Blue",
+		ContactDetails: []*userspb.User_ContactDetails{{Platform: userspb.User_ContactDetails_EMAIL, Identifier: "blue@example.org"}},
+	}
+	if err := users.Add(context.Background(), "Blue", user); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Rate(context.Background(), users, requests, "a", &requestspb.Request_Rating{RaterId: "Brown", RateeId: "Blue", Score: 5}); err != nil {
+		t.Fatal(err)
+	}
+	consented := proto.Clone(user).(*userspb.User)
+	consented.Consents = []*userspb.User_Consent{{Purpose: userspb.User_Consent_CONTACT_SHARING, Granted: true}}
+	if err := users.Update(context.Background(), "Blue", consented); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.Delete(context.Background(), "Blue"); err != nil {
+		t.Fatal(err)
+	}
+
+	events := outbox.Since(0, 10)
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events, got %v", events)
+	}
+	for i, e := range events {
+		// only shared once the user consented
+		if shared := strings.Contains(e.Payload, "blue@example.org"); shared != (i >= 3) {
+			t.Errorf("%s: unexpected payload %s", e.Type, e.Payload)
+		}
+	}
+	if len(user.ContactDetails) != 1 {
+		t.Error("expected the stored user to keep its contact details")
+	}
+}
@@ -0,0 +1,62 @@
+package storage // nolint: dupl
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/golang/protobuf/proto"
+)
+
+type PreferencesStorage struct {
+	mu   sync.RWMutex
+	wr   io.WriteSeeker
+	data map[string]*notificationspb.Preferences
+}
+
+func NewPreferencesStorage(wr io.WriteSeeker, data map[string]*notificationspb.Preferences) *PreferencesStorage {
+	return &PreferencesStorage{
+		wr:   wr,
+		data: data,
+	}
+}
+
+// Set adds or replaces the preferences of the given user.
+func (s *PreferencesStorage) Set(ctx context.Context, userID string, preferences *notificationspb.Preferences) (err error) {
+	defer trace(ctx, "PreferencesStorage.Set")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[userID] = preferences
+	return dump(s.wr, s.data)
+}
+
+func (s *PreferencesStorage) Delete(ctx context.Context, userID string) (err error) {
+	defer trace(ctx, "PreferencesStorage.Delete")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.data[userID]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.data, userID)
+	return dump(s.wr, s.data)
+}
+
+// Get returns a copy of the preferences of the given user.
+func (s *PreferencesStorage) Get(userID string) (*notificationspb.Preferences, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.data[userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return proto.Clone(e).(*notificationspb.Preferences), nil
+}
+
+// Sync waits for the change in progress and commits the file to stable storage.
+func (s *PreferencesStorage) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return flush(s.wr)
+}
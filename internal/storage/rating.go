@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/proto"
+)
+
+// Rate adds the rating to the request and its score to the reputation of the ratee at once, the
+// rating is never counted in one store only. It fails with ErrDuplicate when the rater already
+// rated the request and with ErrNotFound when the request or the ratee doesn't exist. The stores
+// are locked in the order of Snapshot and record the events in the outbox of the requests, they
+// share it.
+func Rate(ctx context.Context, users *UsersStorage, requests *RequestsStorage, id string, rating *requestspb.Request_Rating) (err error) {
+	defer trace(ctx, "storage.Rate")(&err)
+	users.mu.Lock()
+	defer users.mu.Unlock()
+	requests.mu.Lock()
+	defer requests.mu.Unlock()
+
+	oldRequest, ok := requests.data[id]
+	if !ok {
+		return ErrNotFound
+	}
+	for _, r := range oldRequest.Ratings {
+		if r.RaterId == rating.RaterId {
+			return ErrDuplicate
+		}
+	}
+	oldUser, ok := users.data[rating.RateeId]
+	if !ok {
+		return ErrNotFound
+	}
+
+	request := proto.Clone(oldRequest).(*requestspb.Request)
+	request.Ratings = append(request.Ratings, rating)
+	user := proto.Clone(oldUser).(*userspb.User)
+	if user.Reputation == nil {
+		user.Reputation = &userspb.User_Reputation{}
+	}
+	user.Reputation.Ratings++
+	user.Reputation.Total += uint32(rating.Score)
+	user.Reputation.Score = float64(user.Reputation.Total) / float64(user.Reputation.Ratings)
+
+	requests.data[id] = request
+	users.data[rating.RateeId] = user
+	files := []dataFile{{requests.wr, requests.data}, {users.wr, users.data}}
+	events := []*webhookspb.Event{
+		newEvent(HelpRated, requestEntity, id, request),
+		newEvent(UserRated, userEntity, rating.RateeId, Shared(user)),
+	}
+	if err := commitFiles(files, requests.outbox, events, func() {
+		requests.data[id] = oldRequest
+		users.data[rating.RateeId] = oldUser
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, requestEntity, id, oldRequest, request)
+	recordChange(ctx, userEntity, rating.RateeId, oldUser, user)
+	requests.feed.Publish(EventUpdated, id, request, oldRequest)
+	return nil
+}
@@ -0,0 +1,79 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+func TestRate(t *testing.T) {
+	ctx := context.Background()
+	outbox := storagetest.Outbox(nil)
+	usersFile := &storagetest.File{}
+	users := storage.NewUsersStorage(usersFile, map[string]*userspb.User{"Blue": {Name: "Blue"}, "Brown": {Name: "Brown"}})
+	users.SetOutbox(outbox)
+	requestsFile := &storagetest.File{}
+	requests := storage.NewRequestsStorage(requestsFile, map[string]*requestspb.Request{
+		"a": {Title: "dog walk", RequesterId: "Brown", VolunteerId: "Blue", State: requestspb.Request_COMPLETED},
+	})
+	requests.SetOutbox(outbox)
+
+	// the users file can't be written, the rating is undone in both stores
+	usersFile.Err = errors.New("disk full")
+	if err := storage.Rate(ctx, users, requests, "a", &requestspb.Request_Rating{RaterId: "Brown", RateeId: "Blue", Score: 4}); err == nil {
+		t.Fatal("expected the rating to fail")
+	}
+	if r, _ := requests.Get("a"); len(r.Ratings) != 0 || strings.Contains(requestsFile.String(), "raterId") {
+		t.Errorf("expected the rating of the request to be undone, got %v and %s", r.Ratings, requestsFile.String())
+	}
+	if u, _ := users.Get("Blue"); u.Reputation != nil {
+		t.Errorf("expected the reputation to be unchanged, got %v", u.Reputation)
+	}
+	if events := outbox.Since(0, 10); len(events) != 0 {
+		t.Errorf("expected the events to be discarded, got %v", events)
+	}
+	usersFile.Err = nil
+
+	// the request is updated with stale ratings while it's rated
+	stale, err := requests.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := storage.Rate(ctx, users, requests, "a", &requestspb.Request_Rating{RaterId: "Brown", RateeId: "Blue", Score: 4}); err != nil {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := requests.Update(ctx, "a", stale); err != nil {
+			t.Error(err)
+		}
+	}()
+	wg.Wait()
+	if r, _ := requests.Get("a"); len(r.Ratings) != 1 {
+		t.Errorf("expected the rating to be kept, got %v", r.Ratings)
+	}
+	if u, _ := users.Get("Blue"); u.Reputation.GetRatings() != 1 || u.Reputation.GetTotal() != 4 {
+		t.Errorf("expected the rating to be counted, got %v", u.Reputation)
+	}
+
+	err = storage.Rate(ctx, users, requests, "a", &requestspb.Request_Rating{RaterId: "Brown", RateeId: "Blue", Score: 5})
+	if !errors.Is(err, storage.ErrDuplicate) {
+		t.Errorf("expected ErrDuplicate, got %v", err)
+	}
+	err = storage.Rate(ctx, users, requests, "a", &requestspb.Request_Rating{RaterId: "Blue", RateeId: "Green", Score: 5})
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing ratee, got %v", err)
+	}
+}
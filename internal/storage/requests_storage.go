@@ -1,58 +1,221 @@
 package storage // nolint: dupl
 
 import (
+	"context"
 	"io"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 )
 
+const requestEntity = "request"
+
 type RequestsStorage struct {
-	wr   io.WriteSeeker
-	data map[string]*requestspb.Request
+	mu     sync.RWMutex
+	wr     io.WriteSeeker
+	data   map[string]*requestspb.Request
+	feed   *Feed
+	outbox *Outbox
 }
 
 func NewRequestsStorage(wr io.WriteSeeker, data map[string]*requestspb.Request) *RequestsStorage {
 	return &RequestsStorage{
 		wr:   wr,
 		data: data,
+		feed: NewFeed(defaultFeedSize),
 	}
 }
 
-func (s *RequestsStorage) Add(id string, request *requestspb.Request) error {
+// SetOutbox makes the storage record the domain events of the changes in the outbox.
+func (s *RequestsStorage) SetOutbox(outbox *Outbox) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbox = outbox
+}
+
+func (s *RequestsStorage) Add(ctx context.Context, id string, request *requestspb.Request) (err error) {
+	defer trace(ctx, "RequestsStorage.Add")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	_, ok := s.data[id]
 	if ok {
 		return ErrDuplicate
 	}
 	s.data[id] = request
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(RequestAdded, requestEntity, id, request), func() {
+		delete(s.data, id)
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, requestEntity, id, nil, request)
+	s.feed.Publish(EventCreated, id, request, nil)
+	return nil
 }
 
-func (s *RequestsStorage) Update(id string, element *requestspb.Request) error {
-	_, ok := s.data[id]
+// AddAll adds the requests at once, the file is written once. None is added when one of the ids
+// already exists.
+func (s *RequestsStorage) AddAll(ctx context.Context, requests map[string]*requestspb.Request) (err error) {
+	defer trace(ctx, "RequestsStorage.AddAll")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids, err := newIDs(s.data, requests)
+	if err != nil {
+		return err
+	}
+	events := make([]*webhookspb.Event, len(ids))
+	for i, id := range ids {
+		s.data[id] = requests[id]
+		events[i] = newEvent(RequestAdded, requestEntity, id, requests[id])
+	}
+	if err := commitAll(s.wr, s.data, s.outbox, events, func() {
+		for _, id := range ids {
+			delete(s.data, id)
+		}
+	}); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		recordChange(ctx, requestEntity, id, nil, requests[id])
+		s.feed.Publish(EventCreated, id, requests[id], nil)
+	}
+	return nil
+}
+
+// Update replaces the request, its state date is set when its state changes and kept otherwise.
+// The ratings, only added with Rate, and the creation date are kept.
+func (s *RequestsStorage) Update(ctx context.Context, id string, element *requestspb.Request) (err error) {
+	defer trace(ctx, "RequestsStorage.Update")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
+	if !ok {
+		return ErrNotFound
+	}
+	element.Ratings = old.Ratings
+	element.CreationDate = old.CreationDate
+	return s.update(ctx, id, old, element)
+}
+
+// Erase replaces the request with erase applied to it like Update, and the request held by its
+// events not delivered yet too, so the data erased isn't delivered afterwards.
+func (s *RequestsStorage) Erase(ctx context.Context, id string, erase func(*requestspb.Request) *requestspb.Request) (err error) {
+	defer trace(ctx, "RequestsStorage.Erase")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
 	if !ok {
 		return ErrNotFound
 	}
+	if s.outbox != nil {
+		err := s.outbox.redact(requestEntity, id, func(payload string) (string, error) {
+			r := &requestspb.Request{}
+			if err := (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(strings.NewReader(payload), r); err != nil {
+				return "", err
+			}
+			return (&jsonpb.Marshaler{}).MarshalToString(erase(r))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return s.update(ctx, id, old, erase(proto.Clone(old).(*requestspb.Request)))
+}
+
+func (s *RequestsStorage) update(ctx context.Context, id string, old, element *requestspb.Request) error {
+	element.StateDate = old.StateDate
+	if old.State != element.State {
+		element.StateDate = time.Now().UTC().Format(time.RFC3339)
+	}
 	s.data[id] = element
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(requestEvent(old, element), requestEntity, id, element), func() {
+		s.data[id] = old
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, requestEntity, id, old, element)
+	if old.State != element.State {
+		s.feed.Publish(EventStateChanged, id, element, old)
+	} else {
+		s.feed.Publish(EventUpdated, id, element, old)
+	}
+	return nil
 }
 
-func (s *RequestsStorage) Delete(id string) error {
-	_, ok := s.data[id]
+func (s *RequestsStorage) Delete(ctx context.Context, id string) (err error) {
+	defer trace(ctx, "RequestsStorage.Delete")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
 	if !ok {
 		return ErrNotFound
 	}
 	delete(s.data, id)
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(RequestDeleted, requestEntity, id, old), func() {
+		s.data[id] = old
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, requestEntity, id, old, nil)
+	s.feed.Publish(EventDeleted, id, old, old)
+	return nil
 }
 
+// Get returns a copy of the request, changes have to be saved with Update.
 func (s *RequestsStorage) Get(id string) (*requestspb.Request, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	e, ok := s.data[id]
 	if !ok {
 		return nil, ErrNotFound
 	}
-	return e, nil
+	return proto.Clone(e).(*requestspb.Request), nil
 }
 
+// All returns a snapshot of the stored requests, the requests must not be modified.
 func (s *RequestsStorage) All() map[string]*requestspb.Request {
-	return s.data
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := make(map[string]*requestspb.Request, len(s.data))
+	for id, e := range s.data {
+		all[id] = e
+	}
+	return all
+}
+
+// Watch follows the changes made to the requests after the given resume token.
+func (s *RequestsStorage) Watch(token string) (*Subscription, error) {
+	return s.feed.Subscribe(token)
+}
+
+// Sync waits for the change in progress and commits the file to stable storage.
+func (s *RequestsStorage) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return flush(s.wr)
+}
+
+// requestEvent returns the domain event matching the update of a request.
+func requestEvent(old, element *requestspb.Request) string {
+	if old.State != element.State {
+		switch element.State {
+		case requestspb.Request_ACCEPTED:
+			return HelpAccepted
+		case requestspb.Request_COMPLETED:
+			return HelpCompleted
+		case requestspb.Request_CANCELLED:
+			return HelpCancelled
+		}
+	}
+	if len(element.Answers) > len(old.Answers) {
+		return RequestAnswered
+	}
+	if len(element.Ratings) > len(old.Ratings) {
+		return HelpRated
+	}
+	return RequestUpdated
 }
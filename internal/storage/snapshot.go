@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+// Snapshot returns the users, requests and news at one point, the changes wait while the three
+// stores are read. The entries must not be modified.
+func Snapshot(users *UsersStorage, requests *RequestsStorage, news *NewsStorage) (
+	map[string]*userspb.User,
+	map[string]*requestspb.Request,
+	map[string]*newspb.News,
+) {
+	// always locked in the same order, like Rate locks the users and requests
+	users.mu.RLock()
+	defer users.mu.RUnlock()
+	requests.mu.RLock()
+	defer requests.mu.RUnlock()
+	news.mu.RLock()
+	defer news.mu.RUnlock()
+
+	u := make(map[string]*userspb.User, len(users.data))
+	for id, e := range users.data {
+		u[id] = e
+	}
+	r := make(map[string]*requestspb.Request, len(requests.data))
+	for id, e := range requests.data {
+		r[id] = e
+	}
+	n := make(map[string]*newspb.News, len(news.data))
+	for id, e := range news.data {
+		n[id] = e
+	}
+	return u, r, n
+}
@@ -0,0 +1,96 @@
+// Package storagetest provides the in-memory data files and stores used by the tests.
+package storagetest
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+)
+
+// File is an in-memory data file holding what was written since it was last rewound, like the
+// stores rewrite their files. The writes fail with Err when it's set.
+type File struct {
+	mu  sync.Mutex
+	b   strings.Builder
+	Err error
+}
+
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.b = strings.Builder{}
+	return 0, nil
+}
+
+func (f *File) Write(p []byte) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return 0, f.Err
+	}
+	return f.b.Write(p)
+}
+
+// String returns the content of the file.
+func (f *File) String() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.b.String()
+}
+
+// The stores below are stored in a File, they're empty when the data is nil.
+
+func Users(data map[string]*userspb.User) *storage.UsersStorage {
+	if data == nil {
+		data = make(map[string]*userspb.User)
+	}
+	return storage.NewUsersStorage(&File{}, data)
+}
+
+func Requests(data map[string]*requestspb.Request) *storage.RequestsStorage {
+	if data == nil {
+		data = make(map[string]*requestspb.Request)
+	}
+	return storage.NewRequestsStorage(&File{}, data)
+}
+
+func News(data map[string]*newspb.News) *storage.NewsStorage {
+	if data == nil {
+		data = make(map[string]*newspb.News)
+	}
+	return storage.NewNewsStorage(&File{}, data)
+}
+
+func Preferences(data map[string]*notificationspb.Preferences) *storage.PreferencesStorage {
+	if data == nil {
+		data = make(map[string]*notificationspb.Preferences)
+	}
+	return storage.NewPreferencesStorage(&File{}, data)
+}
+
+func DeadLetters(data map[string]*notificationspb.DeadLetter) *storage.DeadLettersStorage {
+	if data == nil {
+		data = make(map[string]*notificationspb.DeadLetter)
+	}
+	return storage.NewDeadLettersStorage(&File{}, data)
+}
+
+func Webhooks(data map[string]*webhookspb.Webhook) *storage.WebhooksStorage {
+	if data == nil {
+		data = make(map[string]*webhookspb.Webhook)
+	}
+	return storage.NewWebhooksStorage(&File{}, data)
+}
+
+func Outbox(data map[string]*webhookspb.Event) *storage.Outbox {
+	if data == nil {
+		data = make(map[string]*webhookspb.Event)
+	}
+	return storage.NewOutbox(&File{}, data)
+}
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/euvsvirus-banan/backend/internal/tracing"
+)
+
+// trace starts the span of a change made to a store, the function returned ends it with the error
+// of the change: defer trace(ctx, "UsersStorage.Add")(&err)
+func trace(ctx context.Context, name string) func(*error) {
+	_, span := tracing.Start(ctx, name)
+	return func(err *error) {
+		span.Finish(*err)
+	}
+}
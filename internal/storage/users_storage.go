@@ -1,14 +1,38 @@
 package storage // nolint: dupl
 
 import (
+	"context"
 	"io"
+	"strings"
+	"sync"
 
 	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/uuid"
 )
 
+const userEntity = "user"
+
+// pseudonymPrefix starts the ids replacing the ids of the erased users.
+const pseudonymPrefix = "erased-"
+
+// Pseudonym returns a new id to replace the id of an erased user with, it can't be linked back to
+// the user.
+func Pseudonym() string {
+	return pseudonymPrefix + uuid.New().String()
+}
+
+// IsPseudonym reports whether the id replaced the id of an erased user.
+func IsPseudonym(id string) bool {
+	return strings.HasPrefix(id, pseudonymPrefix)
+}
+
 type UsersStorage struct {
-	wr   io.WriteSeeker
-	data map[string]*userspb.User
+	mu     sync.RWMutex
+	wr     io.WriteSeeker
+	data   map[string]*userspb.User
+	outbox *Outbox
 }
 
 func NewUsersStorage(wr io.WriteSeeker, data map[string]*userspb.User) *UsersStorage {
@@ -18,41 +42,223 @@ func NewUsersStorage(wr io.WriteSeeker, data map[string]*userspb.User) *UsersSto
 	}
 }
 
-func (s *UsersStorage) Add(id string, user *userspb.User) error {
+// SetOutbox makes the storage record the domain events of the changes in the outbox.
+func (s *UsersStorage) SetOutbox(outbox *Outbox) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbox = outbox
+}
+
+func (s *UsersStorage) Add(ctx context.Context, id string, user *userspb.User) (err error) {
+	defer trace(ctx, "UsersStorage.Add")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	_, ok := s.data[id]
 	if ok {
 		return ErrDuplicate
 	}
 	s.data[id] = user
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(UserAdded, userEntity, id, Shared(user)), func() {
+		delete(s.data, id)
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, userEntity, id, nil, user)
+	return nil
 }
 
-func (s *UsersStorage) Update(id string, element *userspb.User) error {
-	_, ok := s.data[id]
+// AddAll adds the users at once, the file is written once. None is added when one of the ids
+// already exists.
+func (s *UsersStorage) AddAll(ctx context.Context, users map[string]*userspb.User) (err error) {
+	defer trace(ctx, "UsersStorage.AddAll")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids, err := newIDs(s.data, users)
+	if err != nil {
+		return err
+	}
+	events := make([]*webhookspb.Event, len(ids))
+	for i, id := range ids {
+		s.data[id] = users[id]
+		events[i] = newEvent(UserAdded, userEntity, id, Shared(users[id]))
+	}
+	if err := commitAll(s.wr, s.data, s.outbox, events, func() {
+		for _, id := range ids {
+			delete(s.data, id)
+		}
+	}); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		recordChange(ctx, userEntity, id, nil, users[id])
+	}
+	return nil
+}
+
+func (s *UsersStorage) Update(ctx context.Context, id string, element *userspb.User) (err error) {
+	defer trace(ctx, "UsersStorage.Update")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
 	if !ok {
 		return ErrNotFound
 	}
 	s.data[id] = element
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(UserUpdated, userEntity, id, Shared(element)), func() {
+		s.data[id] = old
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, userEntity, id, old, element)
+	return nil
 }
 
-func (s *UsersStorage) Delete(id string) error {
-	_, ok := s.data[id]
+// UpdateProfile replaces the user but its reputation and consents, which only change through
+// Rate and AppendConsent: they're kept under the lock, so a rating or consent recorded
+// meanwhile isn't lost. The contact details the other users can't see are kept when none are
+// given, unless clearContactDetails is set. It returns a copy of the user saved.
+func (s *UsersStorage) UpdateProfile(ctx context.Context, id string, element *userspb.User, clearContactDetails bool) (_ *userspb.User, err error) {
+	defer trace(ctx, "UsersStorage.UpdateProfile")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	e := proto.Clone(element).(*userspb.User)
+	e.Reputation = old.Reputation
+	e.Consents = old.Consents
+	if len(e.ContactDetails) == 0 && !clearContactDetails && !SharesContactDetails(old) {
+		e.ContactDetails = old.ContactDetails
+	}
+	s.data[id] = e
+	if err := commit(s.wr, s.data, s.outbox, newEvent(UserUpdated, userEntity, id, Shared(e)), func() {
+		s.data[id] = old
+	}); err != nil {
+		return nil, err
+	}
+	recordChange(ctx, userEntity, id, old, e)
+	return proto.Clone(e).(*userspb.User), nil
+}
+
+// AppendConsent appends the consents to the records of the user, under the lock: an update made
+// meanwhile doesn't write back the records it read before. It returns a copy of the user saved.
+func (s *UsersStorage) AppendConsent(ctx context.Context, id string, consents ...*userspb.User_Consent) (_ *userspb.User, err error) {
+	defer trace(ctx, "UsersStorage.AppendConsent")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	e := proto.Clone(old).(*userspb.User)
+	e.Consents = append(e.Consents, consents...)
+	s.data[id] = e
+	if err := commit(s.wr, s.data, s.outbox, newEvent(UserUpdated, userEntity, id, Shared(e)), func() {
+		s.data[id] = old
+	}); err != nil {
+		return nil, err
+	}
+	recordChange(ctx, userEntity, id, old, e)
+	return proto.Clone(e).(*userspb.User), nil
+}
+
+func (s *UsersStorage) Delete(ctx context.Context, id string) (err error) {
+	defer trace(ctx, "UsersStorage.Delete")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
 	if !ok {
 		return ErrNotFound
 	}
 	delete(s.data, id)
-	return dump(s.wr, s.data)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(UserDeleted, userEntity, id, Shared(old)), func() {
+		s.data[id] = old
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, userEntity, id, old, nil)
+	return nil
 }
 
+// Erase deletes the user like Delete, the event recorded doesn't hold the user and neither do the
+// events of the user not delivered yet.
+func (s *UsersStorage) Erase(ctx context.Context, id string) (err error) {
+	defer trace(ctx, "UsersStorage.Erase")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if s.outbox != nil {
+		if err := s.outbox.redact(userEntity, id, func(string) (string, error) { return "{}", nil }); err != nil {
+			return err
+		}
+	}
+	delete(s.data, id)
+	if err := commit(s.wr, s.data, s.outbox, newEvent(UserErased, userEntity, id, &userspb.User{}), func() {
+		s.data[id] = old
+	}); err != nil {
+		return err
+	}
+	recordChange(ctx, userEntity, id, old, nil)
+	return nil
+}
+
+// Get returns a copy of the user, changes have to be saved with Update.
 func (s *UsersStorage) Get(id string) (*userspb.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	e, ok := s.data[id]
 	if !ok {
 		return nil, ErrNotFound
 	}
-	return e, nil
+	return proto.Clone(e).(*userspb.User), nil
 }
 
+// All returns a snapshot of the stored users, the users must not be modified.
 func (s *UsersStorage) All() map[string]*userspb.User {
-	return s.data
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := make(map[string]*userspb.User, len(s.data))
+	for id, e := range s.data {
+		all[id] = e
+	}
+	return all
+}
+
+// Sync waits for the change in progress and commits the file to stable storage.
+func (s *UsersStorage) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return flush(s.wr)
+}
+
+// LatestConsent returns the latest consent of the user to the purpose, nil if there's none.
+func LatestConsent(user *userspb.User, purpose userspb.User_Consent_Purpose) *userspb.User_Consent {
+	for i := len(user.Consents) - 1; i >= 0; i-- {
+		if user.Consents[i].Purpose == purpose {
+			return user.Consents[i]
+		}
+	}
+	return nil
+}
+
+// SharesContactDetails reports whether the user consented to their contact details being shared
+// with the other users.
+func SharesContactDetails(user *userspb.User) bool {
+	c := LatestConsent(user, userspb.User_Consent_CONTACT_SHARING)
+	return c != nil && c.Granted
+}
+
+// Shared returns the user as the other users and the webhooks see it, without the contact details
+// unless the user consented to share them. The user given isn't modified.
+func Shared(user *userspb.User) *userspb.User {
+	if user == nil || len(user.ContactDetails) == 0 || SharesContactDetails(user) {
+		return user
+	}
+	u := proto.Clone(user).(*userspb.User)
+	u.ContactDetails = nil
+	return u
 }
@@ -0,0 +1,65 @@
+package storage_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+func TestUpdateProfileKeepsConsents(t *testing.T) {
+	ctx := context.Background()
+	email := []*userspb.User_ContactDetails{{Platform: userspb.User_ContactDetails_EMAIL, Identifier: "blue@example.org"}}
+	reputation := &userspb.User_Reputation{Score: 4, Ratings: 1, Total: 4}
+	users := storagetest.Users(map[string]*userspb.User{"Blue": {Name: "Blue", ContactDetails: email, Reputation: reputation}})
+
+	// the updates are made with the user read before the consents were recorded
+	stale, err := users.Get("Blue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			u := &userspb.User{Name: "Blue " + strconv.Itoa(i), Consents: stale.Consents}
+			if _, err := users.UpdateProfile(ctx, "Blue", u, false); err != nil {
+				t.Error(err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			c := &userspb.User_Consent{Purpose: userspb.User_Consent_MARKETING, Granted: true}
+			if _, err := users.AppendConsent(ctx, "Blue", c); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	u, err := users.Get("Blue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Consents) != 20 {
+		t.Errorf("expected the 20 consents to be kept, got %d", len(u.Consents))
+	}
+	if u.Reputation.GetRatings() != 1 {
+		t.Errorf("expected the reputation to be kept, got %v", u.Reputation)
+	}
+	// the contact details aren't shared, they're kept when none are given
+	if len(u.ContactDetails) != 1 {
+		t.Errorf("expected the contact details to be kept, got %v", u.ContactDetails)
+	}
+
+	if u, err = users.UpdateProfile(ctx, "Blue", &userspb.User{Name: "Blue"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(u.ContactDetails) != 0 {
+		t.Errorf("expected the contact details to be cleared, got %v", u.ContactDetails)
+	}
+}
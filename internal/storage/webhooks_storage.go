@@ -0,0 +1,113 @@
+package storage // nolint: dupl
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/proto"
+)
+
+type WebhooksStorage struct {
+	mu   sync.RWMutex
+	wr   io.WriteSeeker
+	data map[string]*webhookspb.Webhook
+}
+
+func NewWebhooksStorage(wr io.WriteSeeker, data map[string]*webhookspb.Webhook) *WebhooksStorage {
+	return &WebhooksStorage{
+		wr:   wr,
+		data: data,
+	}
+}
+
+func (s *WebhooksStorage) Add(ctx context.Context, id string, webhook *webhookspb.Webhook) (err error) {
+	defer trace(ctx, "WebhooksStorage.Add")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.data[id]
+	if ok {
+		return ErrDuplicate
+	}
+	s.data[id] = webhook
+	return dump(s.wr, s.data)
+}
+
+func (s *WebhooksStorage) Update(ctx context.Context, id string, element *webhookspb.Webhook) (err error) {
+	defer trace(ctx, "WebhooksStorage.Update")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.data[id]
+	if !ok {
+		return ErrNotFound
+	}
+	s.data[id] = element
+	return dump(s.wr, s.data)
+}
+
+// Advance records the delivery progress of the webhook: the sequence of the last event handled,
+// the deliveries given up since the previous call and the error of the last one, kept when empty.
+// The other fields, changed by the users, are left as they are.
+func (s *WebhooksStorage) Advance(ctx context.Context, id string, lastSequence, failed uint64, lastError string) (err error) {
+	defer trace(ctx, "WebhooksStorage.Advance")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.data[id]
+	if !ok {
+		return ErrNotFound
+	}
+	e := proto.Clone(old).(*webhookspb.Webhook)
+	e.LastSequence = lastSequence
+	e.FailedDeliveries += failed
+	if lastError != "" {
+		e.LastError = lastError
+	}
+	s.data[id] = e
+	if err := dump(s.wr, s.data); err != nil {
+		s.data[id] = old
+		return err
+	}
+	return nil
+}
+
+func (s *WebhooksStorage) Delete(ctx context.Context, id string) (err error) {
+	defer trace(ctx, "WebhooksStorage.Delete")(&err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.data[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.data, id)
+	return dump(s.wr, s.data)
+}
+
+// Get returns a copy of the webhook, changes have to be saved with Update.
+func (s *WebhooksStorage) Get(id string) (*webhookspb.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.data[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return proto.Clone(e).(*webhookspb.Webhook), nil
+}
+
+// All returns a snapshot of the stored webhooks, the webhooks must not be modified.
+func (s *WebhooksStorage) All() map[string]*webhookspb.Webhook {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := make(map[string]*webhookspb.Webhook, len(s.data))
+	for id, e := range s.data {
+		all[id] = e
+	}
+	return all
+}
+
+// Sync waits for the change in progress and commits the file to stable storage.
+func (s *WebhooksStorage) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return flush(s.wr)
+}
@@ -0,0 +1,119 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Reloader keeps the certificates loaded from the files, reloading them when the files change.
+type Reloader struct {
+	logger       *logrus.Entry
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func NewReloader(logger *logrus.Entry, certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{
+		logger:       logger,
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Run checks the files every interval until the context is cancelled. Certificates that can't be
+// loaded are logged and the previous ones are kept.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				r.logger.WithError(err).Error("problem reloading TLS certificates")
+				continue
+			}
+			if reloaded {
+				r.logger.Info("TLS certificates reloaded")
+			}
+		}
+	}
+}
+
+// Reload loads the certificates again if any of the files changed since the last load, it
+// returns whether they were reloaded.
+func (r *Reloader) Reload() (bool, error) {
+	modTimes := make(map[string]time.Time)
+	for _, f := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if f == "" {
+			continue
+		}
+		info, err := os.Stat(f)
+		if err != nil {
+			return false, fmt.Errorf("%w: problem reading certificate file", err)
+		}
+		modTimes[f] = info.ModTime()
+	}
+
+	r.mu.RLock()
+	changed := r.modTimes == nil
+	for f, t := range modTimes {
+		if !t.Equal(r.modTimes[f]) {
+			changed = true
+		}
+	}
+	r.mu.RUnlock()
+	if !changed {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("%w: problem loading certificate", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		b, err := ioutil.ReadFile(r.clientCAFile)
+		if err != nil {
+			return false, fmt.Errorf("%w: problem reading client CA", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(b) {
+			return false, fmt.Errorf("no certificate found in client CA %s", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return true, nil
+}
+
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, r.clientCAs
+}
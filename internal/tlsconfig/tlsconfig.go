@@ -0,0 +1,177 @@
+// Package tlsconfig builds the TLS configuration of the listeners from the command line options.
+// Certificates are reloaded when the files change, so rotated certificates are picked up without
+// restarting the service.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// Cipher policies, they only apply to TLS 1.2 as the TLS 1.3 cipher suites aren't configurable.
+const (
+	// ModernCiphers allows the AEAD cipher suites with forward secrecy
+	ModernCiphers = "modern"
+	// IntermediateCiphers also allows the CBC cipher suites with forward secrecy, for older clients
+	IntermediateCiphers = "intermediate"
+	// DefaultCiphers uses the cipher suites of the Go standard library
+	DefaultCiphers = "default"
+)
+
+// Client certificate verification modes.
+const (
+	// NoClientCert doesn't ask for client certificates
+	NoClientCert = "none"
+	// VerifyClientCertIfGiven verifies the certificates of the clients sending one, the others
+	// are still accepted. Client certificates aren't asked for without a client CA.
+	VerifyClientCertIfGiven = "verify-if-given"
+	// RequireClientCert rejects the clients without a valid certificate
+	RequireClientCert = "require"
+)
+
+var modernCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+}
+
+var intermediateCipherSuites = append([]uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+}, modernCipherSuites...)
+
+type Config struct {
+	CertFile string
+	KeyFile  string
+	// MinVersion is the minimum TLS version accepted, 1.2 or 1.3
+	MinVersion   string
+	CipherPolicy string
+	// ClientCAFile holds the certificates of the authorities signing the client certificates
+	ClientCAFile string
+	ClientAuth   string
+}
+
+// Enabled returns whether TLS is configured.
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// Validate checks the options without loading the certificates.
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		if c.ClientCAFile != "" {
+			return fmt.Errorf("verifying client certificates requires a certificate")
+		}
+		return nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return fmt.Errorf("both a certificate and its private key are required")
+	}
+	if _, err := parseMinVersion(c.MinVersion); err != nil {
+		return err
+	}
+	if _, err := parseCipherPolicy(c.CipherPolicy); err != nil {
+		return err
+	}
+	auth, err := parseClientAuth(c.ClientAuth)
+	if err != nil {
+		return err
+	}
+	if auth == tls.RequireAndVerifyClientCert && c.ClientCAFile == "" {
+		return fmt.Errorf("requiring client certificates requires a client CA")
+	}
+	return nil
+}
+
+// Server returns the TLS configuration of a server using the certificates of the reloader. The
+// client certificates are only verified when clientAuth is set, so the same certificates can be
+// used for the listeners open to browsers.
+func (c Config) Server(reloader *Reloader, clientAuth bool) (*tls.Config, error) {
+	minVersion, err := parseMinVersion(c.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	cipherSuites, err := parseCipherPolicy(c.CipherPolicy)
+	if err != nil {
+		return nil, err
+	}
+	auth := tls.NoClientCert
+	if clientAuth {
+		if auth, err = parseClientAuth(c.ClientAuth); err != nil {
+			return nil, err
+		}
+		if c.ClientCAFile == "" {
+			if auth == tls.RequireAndVerifyClientCert {
+				return nil, fmt.Errorf("requiring client certificates requires a client CA")
+			}
+			auth = tls.NoClientCert
+		}
+	}
+
+	base := &tls.Config{
+		MinVersion:               minVersion,
+		CipherSuites:             cipherSuites,
+		PreferServerCipherSuites: true,
+		ClientAuth:               auth,
+		NextProtos:               []string{"h2", "http/1.1"},
+	}
+	return &tls.Config{
+		MinVersion: minVersion,
+		NextProtos: base.NextProtos,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := reloader.current()
+			return cert, nil
+		},
+		// the certificates are picked for every connection so reloads apply to new connections
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, clientCAs := reloader.current()
+			cfg := base.Clone()
+			cfg.Certificates = []tls.Certificate{*cert}
+			cfg.ClientCAs = clientCAs
+			return cfg, nil
+		},
+	}, nil
+}
+
+func parseMinVersion(v string) (uint16, error) {
+	switch v {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported minimum TLS version %q, expected 1.2 or 1.3", v)
+	}
+}
+
+func parseCipherPolicy(policy string) ([]uint16, error) {
+	switch strings.ToLower(policy) {
+	case "", ModernCiphers:
+		return modernCipherSuites, nil
+	case IntermediateCiphers:
+		return intermediateCipherSuites, nil
+	case DefaultCiphers:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown cipher policy %q", policy)
+	}
+}
+
+func parseClientAuth(mode string) (tls.ClientAuthType, error) {
+	switch mode {
+	case "", NoClientCert:
+		return tls.NoClientCert, nil
+	case VerifyClientCertIfGiven:
+		return tls.VerifyClientCertIfGiven, nil
+	case RequireClientCert:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("unknown client certificate verification %q", mode)
+	}
+}
@@ -0,0 +1,320 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type issuer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newCA(t *testing.T, name string) *issuer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &issuer{cert: cert, key: key}
+}
+
+// issue returns the PEM encoded certificate and key signed by the CA.
+func (ca *issuer) issue(t *testing.T, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func (ca *issuer) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+func (ca *issuer) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func write(t *testing.T, path string, b []byte, modTime time.Time) {
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// connect makes a TLS connection to a server using cfg and returns the name of the server
+// certificate.
+func connect(t *testing.T, cfg *tls.Config, clientCfg *tls.Config) (string, error) {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if err := conn.(*tls.Conn).Handshake(); err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("ok"))
+	}()
+
+	conn, err := tls.Dial("tcp", lis.Addr().String(), clientCfg)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	// with TLS 1.3 the client certificate is verified once the client handshake is done
+	if _, err := io.ReadFull(conn, make([]byte, 2)); err != nil {
+		return "", err
+	}
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, nil
+}
+
+func setup(t *testing.T) (string, *issuer) {
+	dir, err := ioutil.TempDir("", "tlsconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := newCA(t, "ca")
+	cert, key := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	now := time.Now()
+	write(t, filepath.Join(dir, "server.crt"), cert, now)
+	write(t, filepath.Join(dir, "server.key"), key, now)
+	write(t, filepath.Join(dir, "ca.crt"), ca.pem(), now)
+	return dir, ca
+}
+
+func TestClientAuth(t *testing.T) {
+	dir, ca := setup(t)
+	defer os.RemoveAll(dir)
+
+	reloader, err := NewReloader(logrus.NewEntry(logrus.New()), filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientCert, clientKey := ca.issue(t, "requests-worker", x509.ExtKeyUsageClientAuth)
+	validCert, err := tls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCert, otherKey := newCA(t, "other").issue(t, "intruder", x509.ExtKeyUsageClientAuth)
+	invalidCert, err := tls.X509KeyPair(otherCert, otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		clientAuth string
+		verify     bool
+		cert       *tls.Certificate
+		ok         bool
+	}{
+		{
+			name:       "required and given",
+			clientAuth: RequireClientCert,
+			verify:     true,
+			cert:       &validCert,
+			ok:         true,
+		},
+		{
+			name:       "required and missing",
+			clientAuth: RequireClientCert,
+			verify:     true,
+		},
+		{
+			name:       "required and invalid",
+			clientAuth: RequireClientCert,
+			verify:     true,
+			cert:       &invalidCert,
+		},
+		{
+			name:       "optional and missing",
+			clientAuth: VerifyClientCertIfGiven,
+			verify:     true,
+			ok:         true,
+		},
+		{
+			name:       "optional and invalid",
+			clientAuth: VerifyClientCertIfGiven,
+			verify:     true,
+			cert:       &invalidCert,
+		},
+		{
+			name:       "required but not verified by the listener",
+			clientAuth: RequireClientCert,
+			ok:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		cfg, err := Config{
+			ClientCAFile: filepath.Join(dir, "ca.crt"),
+			ClientAuth:   tc.clientAuth,
+		}.Server(reloader, tc.verify)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clientCfg := &tls.Config{RootCAs: ca.pool()}
+		if tc.cert != nil {
+			cert := tc.cert
+			// sent even when not signed by the CAs accepted by the server
+			clientCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return cert, nil
+			}
+		}
+		_, err = connect(t, cfg, clientCfg)
+		if tc.ok && err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.name, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("%s: expected the connection to be rejected", tc.name)
+		}
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir, ca := setup(t)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	reloader, err := NewReloader(logrus.NewEntry(logrus.New()), certFile, keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Config{}.Server(reloader, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCfg := &tls.Config{RootCAs: ca.pool()}
+
+	if name, err := connect(t, cfg, clientCfg); err != nil || name != "server" {
+		t.Fatalf("unexpected certificate %q: %v", name, err)
+	}
+	if reloaded, err := reloader.Reload(); err != nil || reloaded {
+		t.Errorf("expected no reload when the files didn't change: %t %v", reloaded, err)
+	}
+
+	// a broken rotation keeps the previous certificate
+	later := time.Now().Add(time.Minute)
+	write(t, keyFile, []byte("garbage"), later)
+	if _, err := reloader.Reload(); err == nil {
+		t.Error("expected an error for an invalid key")
+	}
+	if name, err := connect(t, cfg, clientCfg); err != nil || name != "server" {
+		t.Fatalf("unexpected certificate %q: %v", name, err)
+	}
+
+	cert, key := ca.issue(t, "rotated", x509.ExtKeyUsageServerAuth)
+	write(t, certFile, cert, later)
+	write(t, keyFile, key, later.Add(time.Second))
+	if reloaded, err := reloader.Reload(); err != nil || !reloaded {
+		t.Fatalf("expected the certificate to be reloaded: %t %v", reloaded, err)
+	}
+	if name, err := connect(t, cfg, clientCfg); err != nil || name != "rotated" {
+		t.Errorf("unexpected certificate %q: %v", name, err)
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	dir, _ := setup(t)
+	defer os.RemoveAll(dir)
+
+	reloader, err := NewReloader(logrus.NewEntry(logrus.New()), filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cfg := range []Config{
+		{MinVersion: "1.0"},
+		{CipherPolicy: "weak"},
+		{ClientAuth: "sometimes"},
+		{ClientAuth: RequireClientCert},
+	} {
+		if _, err := cfg.Server(reloader, true); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		cfg   Config
+		valid bool
+	}{
+		{cfg: Config{}, valid: true},
+		{cfg: Config{CertFile: "server.crt", KeyFile: "server.key", ClientAuth: VerifyClientCertIfGiven}, valid: true},
+		{cfg: Config{CertFile: "server.crt", KeyFile: "server.key", ClientCAFile: "ca.crt", ClientAuth: RequireClientCert}, valid: true},
+		{cfg: Config{CertFile: "server.crt"}},
+		{cfg: Config{ClientCAFile: "ca.crt"}},
+		{cfg: Config{CertFile: "server.crt", KeyFile: "server.key", MinVersion: "1.1"}},
+		{cfg: Config{CertFile: "server.crt", KeyFile: "server.key", CipherPolicy: "weak"}},
+		{cfg: Config{CertFile: "server.crt", KeyFile: "server.key", ClientAuth: RequireClientCert}},
+	} {
+		if err := tc.cfg.Validate(); (err == nil) != tc.valid {
+			t.Errorf("unexpected validation of %+v: %v", tc.cfg, err)
+		}
+	}
+}
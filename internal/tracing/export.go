@@ -0,0 +1,272 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JSONExporter writes the spans as newline delimited JSON, e.g. to stdout or a file.
+type JSONExporter struct {
+	logger *logrus.Entry
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewJSONExporter(logger *logrus.Entry, w io.Writer) *JSONExporter {
+	return &JSONExporter{
+		logger: logger,
+		enc:    json.NewEncoder(w),
+	}
+}
+
+type jsonSpan struct {
+	TraceID    string            `json:"traceId"`
+	SpanID     string            `json:"spanId"`
+	ParentID   string            `json:"parentSpanId,omitempty"`
+	RequestID  string            `json:"requestId,omitempty"`
+	Name       string            `json:"name"`
+	Kind       string            `json:"kind"`
+	Start      time.Time         `json:"start"`
+	End        time.Time         `json:"end"`
+	Duration   string            `json:"duration"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+func (e *JSONExporter) Export(span *Span) {
+	s := jsonSpan{
+		TraceID:    span.Context.TraceID.String(),
+		SpanID:     span.Context.SpanID.String(),
+		RequestID:  span.RequestID,
+		Name:       span.Name,
+		Kind:       "internal",
+		Start:      span.Start,
+		End:        span.End,
+		Duration:   span.End.Sub(span.Start).String(),
+		Attributes: span.Attributes,
+		Error:      span.Error,
+	}
+	if !span.ParentID.IsZero() {
+		s.ParentID = span.ParentID.String()
+	}
+	if span.Kind == Server {
+		s.Kind = "server"
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.enc.Encode(s); err != nil {
+		e.logger.WithError(err).Warn("problem exporting span")
+	}
+}
+
+// OTLPExporter sends the spans in batches to an OpenTelemetry collector with the OTLP/HTTP JSON
+// protocol. Spans are dropped when the collector can't keep up.
+type OTLPExporter struct {
+	logger      *logrus.Entry
+	client      *http.Client
+	url         string
+	serviceName string
+	batchSize   int
+
+	mu      sync.Mutex
+	pending []*Span
+	full    chan struct{}
+}
+
+// NewOTLPExporter returns the exporter posting to the traces endpoint of the collector, e.g.
+// http://localhost:4318/v1/traces.
+func NewOTLPExporter(logger *logrus.Entry, client *http.Client, url, serviceName string) *OTLPExporter {
+	return &OTLPExporter{
+		logger:      logger,
+		client:      client,
+		url:         url,
+		serviceName: serviceName,
+		batchSize:   512,
+		full:        make(chan struct{}, 1),
+	}
+}
+
+func (e *OTLPExporter) Export(span *Span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.pending) >= 4*e.batchSize {
+		return
+	}
+	e.pending = append(e.pending, span)
+	if len(e.pending) >= e.batchSize {
+		select {
+		case e.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run sends the spans on every interval, or once a batch is full, until the context is
+// cancelled. The spans left are sent before returning.
+func (e *OTLPExporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			e.Flush(context.Background())
+			return
+		case <-ticker.C:
+		case <-e.full:
+		}
+		e.Flush(ctx)
+	}
+}
+
+// Flush sends the pending spans.
+func (e *OTLPExporter) Flush(ctx context.Context) {
+	for {
+		e.mu.Lock()
+		batch := e.pending
+		if len(batch) > e.batchSize {
+			batch = batch[:e.batchSize]
+		}
+		e.pending = e.pending[len(batch):]
+		e.mu.Unlock()
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(ctx, batch); err != nil {
+			e.logger.WithError(err).WithField("spans", len(batch)).Warn("problem exporting spans")
+			return
+		}
+	}
+}
+
+func (e *OTLPExporter) send(ctx context.Context, spans []*Span) error {
+	body, err := json.Marshal(otlpRequest(e.serviceName, spans))
+	if err != nil {
+		return fmt.Errorf("problem marshaling spans: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: problem creating request", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: problem sending spans", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+// The OTLP/HTTP JSON encoding of ExportTraceServiceRequest.
+type (
+	otlpKeyValue struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+	otlpScopeSpans struct {
+		Scope struct {
+			Name string `json:"name"`
+		} `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpResourceSpans struct {
+		Resource struct {
+			Attributes []otlpKeyValue `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpTraces struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+)
+
+// Span kinds and status codes of OTLP.
+const (
+	otlpKindInternal = 1
+	otlpKindServer   = 2
+	otlpStatusOK     = 1
+	otlpStatusError  = 2
+)
+
+func keyValue(key, value string) otlpKeyValue {
+	kv := otlpKeyValue{Key: key}
+	kv.Value.StringValue = value
+	return kv
+}
+
+func otlpRequest(serviceName string, spans []*Span) otlpTraces {
+	scope := otlpScopeSpans{}
+	scope.Scope.Name = "github.com/euvsvirus-banan/backend/internal/tracing"
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.Context.TraceID.String(),
+			SpanID:            span.Context.SpanID.String(),
+			Name:              span.Name,
+			Kind:              otlpKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+			Status:            otlpStatus{Code: otlpStatusOK},
+		}
+		if !span.ParentID.IsZero() {
+			s.ParentSpanID = span.ParentID.String()
+		}
+		if span.Kind == Server {
+			s.Kind = otlpKindServer
+		}
+		if span.Error != "" {
+			s.Status = otlpStatus{Code: otlpStatusError, Message: span.Error}
+		}
+		keys := make([]string, 0, len(span.Attributes))
+		for k := range span.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			s.Attributes = append(s.Attributes, keyValue(k, span.Attributes[k]))
+		}
+		if span.RequestID != "" {
+			s.Attributes = append(s.Attributes, keyValue("request_id", span.RequestID))
+		}
+		scope.Spans = append(scope.Spans, s)
+	}
+
+	resource := otlpResourceSpans{ScopeSpans: []otlpScopeSpans{scope}}
+	resource.Resource.Attributes = []otlpKeyValue{keyValue("service.name", serviceName)}
+	return otlpTraces{ResourceSpans: []otlpResourceSpans{resource}}
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor starts the span of the call, continuing the trace of the caller given in
+// the traceparent metadata. The request id is taken from the x-request-id metadata or generated,
+// it's sent back in the headers and in the details of the errors as a RequestInfo. The ids are
+// added to the tags logged with the call.
+func (t *Tracer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := t.startServer(ctx, info.FullMethod)
+		_ = grpc.SetHeader(ctx, responseHeaders(span))
+		resp, err := handler(ctx, req)
+		return resp, finishServer(span, err)
+	}
+}
+
+// StreamServerInterceptor is the UnaryServerInterceptor of the streams.
+func (t *Tracer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := t.startServer(ss.Context(), info.FullMethod)
+		_ = ss.SetHeader(responseHeaders(span))
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		return finishServer(span, handler(srv, wrapped))
+	}
+}
+
+func (t *Tracer) startServer(ctx context.Context, method string) (context.Context, *Span) {
+	var (
+		parent    SpanContext
+		requestID string
+	)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(TraceparentKey); len(v) > 0 {
+			// a malformed header starts a new trace
+			parent, _ = ParseTraceparent(v[0])
+		}
+		if v := md.Get(RequestIDKey); len(v) > 0 && v[0] != "" {
+			requestID = v[0]
+		}
+	}
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	ctx, span := t.start(ctx, method, Server, parent, requestID)
+	span.SetAttribute("rpc.method", method)
+	grpc_ctxtags.Extract(ctx).
+		Set("request_id", requestID).
+		Set("trace_id", span.Context.TraceID.String()).
+		Set("span_id", span.Context.SpanID.String())
+	return ctx, span
+}
+
+func responseHeaders(span *Span) metadata.MD {
+	return metadata.Pairs(
+		RequestIDKey, span.RequestID,
+		TraceparentKey, span.Context.Traceparent(),
+	)
+}
+
+// finishServer ends the span of the call with its status code, the request id is added to the
+// details of the error.
+func finishServer(span *Span, err error) error {
+	s := status.Convert(err)
+	span.SetAttribute("rpc.grpc.status_code", s.Code().String())
+	span.Finish(err)
+	if err == nil {
+		return nil
+	}
+	withID, detailsErr := s.WithDetails(&errdetails.RequestInfo{RequestId: span.RequestID})
+	if detailsErr != nil {
+		return err
+	}
+	return withID.Err()
+}
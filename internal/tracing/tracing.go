@@ -0,0 +1,229 @@
+// Package tracing records the spans of the RPCs and of the operations they're made of, propagated
+// through the W3C traceparent header, and correlates the calls with request ids.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metadata keys propagated along with the calls.
+const (
+	TraceparentKey = "traceparent"
+	RequestIDKey   = "x-request-id"
+)
+
+// TraceID identifies the spans of a trace.
+type TraceID [16]byte
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanID identifies a span in a trace.
+type SpanID [8]byte
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsZero returns whether the span id is unset, e.g. the parent of a root span.
+func (id SpanID) IsZero() bool {
+	return id == SpanID{}
+}
+
+// SpanContext is the part of a span propagated to the callees.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// Traceparent returns the span context as a W3C traceparent header.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ErrInvalidTraceparent is returned when parsing a malformed traceparent header.
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+// ParseTraceparent parses a W3C traceparent header, version-trace_id-parent_id-flags.
+func ParseTraceparent(s string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	// later versions may append fields
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	var (
+		sc    SpanContext
+		flags [1]byte
+	)
+	if decode(sc.TraceID[:], parts[1]) != nil || decode(sc.SpanID[:], parts[2]) != nil || decode(flags[:], parts[3]) != nil {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	if sc.TraceID == (TraceID{}) || sc.SpanID.IsZero() {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, nil
+}
+
+func decode(dst []byte, s string) error {
+	if len(s) != 2*len(dst) || strings.ToLower(s) != s {
+		return ErrInvalidTraceparent
+	}
+	if _, err := hex.Decode(dst, []byte(s)); err != nil {
+		return ErrInvalidTraceparent
+	}
+	return nil
+}
+
+// Kind tells whether the span is an RPC served or an operation of the service.
+type Kind int
+
+const (
+	Internal Kind = iota
+	Server
+)
+
+// Span is an operation timed as part of a trace.
+type Span struct {
+	tracer *Tracer
+
+	Name       string
+	Kind       Kind
+	Context    SpanContext
+	ParentID   SpanID
+	RequestID  string
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]string
+	// Error is the error the operation ended with, empty when it succeeded
+	Error string
+
+	mu    sync.Mutex
+	ended bool
+}
+
+// SetAttribute records an attribute of the operation, nil spans are ignored.
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Attributes[key] = value
+}
+
+// Finish ends the span with the error of the operation, if any, and exports it when sampled.
+// Nil spans are ignored.
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	if err != nil {
+		s.Error = err.Error()
+	}
+	s.mu.Unlock()
+	if s.Context.Sampled {
+		s.tracer.exporter.Export(s)
+	}
+}
+
+// Exporter sends the finished spans to a backend.
+type Exporter interface {
+	Export(span *Span)
+}
+
+type noopExporter struct{}
+
+func (noopExporter) Export(*Span) {}
+
+// Tracer starts the spans of the RPCs served.
+type Tracer struct {
+	exporter Exporter
+}
+
+// New returns the tracer exporting the spans through the exporter, the spans are only
+// propagated when nil.
+func New(exporter Exporter) *Tracer {
+	if exporter == nil {
+		exporter = noopExporter{}
+	}
+	return &Tracer{exporter: exporter}
+}
+
+type spanKey struct{}
+
+// FromContext returns the current span, nil when the context isn't traced.
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// RequestID returns the id of the request the context belongs to, empty when unknown.
+func RequestID(ctx context.Context) string {
+	if s := FromContext(ctx); s != nil {
+		return s.RequestID
+	}
+	return ""
+}
+
+// start starts a span, child of the parent when its trace id is set.
+func (t *Tracer) start(ctx context.Context, name string, kind Kind, parent SpanContext, requestID string) (context.Context, *Span) {
+	s := &Span{
+		tracer:     t,
+		Name:       name,
+		Kind:       kind,
+		RequestID:  requestID,
+		Start:      time.Now(),
+		Attributes: make(map[string]string),
+	}
+	if parent.TraceID != (TraceID{}) {
+		s.Context.TraceID = parent.TraceID
+		s.Context.Sampled = parent.Sampled
+		s.ParentID = parent.SpanID
+	} else {
+		_, _ = rand.Read(s.Context.TraceID[:])
+		s.Context.Sampled = true
+	}
+	_, _ = rand.Read(s.Context.SpanID[:])
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// Start starts a span child of the current span. The span returned is nil when the context isn't
+// traced, Finish can still be called.
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	parent := FromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	return parent.tracer.start(ctx, name, Internal, parent.Context, parent.RequestID)
+}
+
+// Trace runs the operation in a span child of the current span.
+func Trace(ctx context.Context, name string, op func() error) error {
+	_, span := Start(ctx, name)
+	err := op()
+	span.Finish(err)
+	return err
+}
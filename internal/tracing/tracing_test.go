@@ -0,0 +1,225 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func logger() *logrus.Entry {
+	l := logrus.New()
+	l.SetOutput(ioutil.Discard)
+	return logrus.NewEntry(l)
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+func (r *recorder) Export(span *Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   SpanContext
+		err    error
+	}{
+		{
+			name:   "sampled",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				Sampled: true,
+			},
+		},
+		{
+			name:   "not sampled",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			want: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			},
+		},
+		{
+			name:   "later version with more fields",
+			header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			want: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				Sampled: true,
+			},
+		},
+		{
+			name:   "zero trace id",
+			header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			err:    ErrInvalidTraceparent,
+		},
+		{
+			name:   "upper case",
+			header: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			err:    ErrInvalidTraceparent,
+		},
+		{
+			name:   "invalid version",
+			header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			err:    ErrInvalidTraceparent,
+		},
+		{
+			name:   "truncated",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736",
+			err:    ErrInvalidTraceparent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTraceparent(tt.header)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+			if tt.err == nil && strings.HasPrefix(tt.header, "00-") && got.Traceparent() != tt.header {
+				t.Errorf("expected %s, got %s", tt.header, got.Traceparent())
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	rec := &recorder{}
+	tracer := New(rec)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		TraceparentKey, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/requestspb.RequestsRPC/AcceptHelp"}
+
+	var requestID string
+	_, err := tracer.UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestID = RequestID(ctx)
+		_ = Trace(ctx, "RequestsStorage.Update", func() error { return errors.New("disk full") })
+		return nil, status.Error(codes.Internal, "disk full")
+	})
+
+	if requestID == "" {
+		t.Fatal("expected a request id to be generated")
+	}
+	var details []interface{}
+	if s, ok := status.FromError(err); ok {
+		details = s.Details()
+	}
+	if len(details) != 1 || details[0].(*errdetails.RequestInfo).RequestId != requestID {
+		t.Errorf("expected the request id in the error details, got %v", details)
+	}
+
+	if len(rec.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(rec.spans))
+	}
+	storage, server := rec.spans[0], rec.spans[1]
+	if got := server.Context.TraceID.String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the trace of the caller to continue, got %s", got)
+	}
+	if got := server.ParentID.String(); got != "00f067aa0ba902b7" {
+		t.Errorf("expected the span of the caller as parent, got %s", got)
+	}
+	if server.Kind != Server || server.Name != info.FullMethod || server.Attributes["rpc.grpc.status_code"] != "Internal" {
+		t.Errorf("unexpected server span %+v", server)
+	}
+	if storage.Context.TraceID != server.Context.TraceID || storage.ParentID != server.Context.SpanID {
+		t.Errorf("expected the storage span to be a child of the server span")
+	}
+	if storage.Error != "disk full" || storage.RequestID != requestID {
+		t.Errorf("unexpected storage span %+v", storage)
+	}
+}
+
+func TestUnaryServerInterceptorRequestID(t *testing.T) {
+	rec := &recorder{}
+	tracer := New(rec)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		TraceparentKey, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+		RequestIDKey, "given-id",
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/userspb.UsersRPC/GetUsers"}
+	_, _ = tracer.UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		if got := RequestID(ctx); got != "given-id" {
+			t.Errorf("expected the request id given to be used, got %s", got)
+		}
+		return nil, nil
+	})
+	if len(rec.spans) != 0 {
+		t.Errorf("expected the spans of traces not sampled not to be exported, got %d", len(rec.spans))
+	}
+}
+
+func TestJSONExporter(t *testing.T) {
+	var b bytes.Buffer
+	tracer := New(NewJSONExporter(logger(), &b))
+	ctx, span := tracer.start(context.Background(), "/newspb.NewsRPC/AddNew", Server, SpanContext{}, "id")
+	_ = Trace(ctx, "NewsStorage.Add", func() error { return nil })
+	span.Finish(nil)
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 spans, got %q", b.String())
+	}
+	var got jsonSpan
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "NewsStorage.Add" || got.ParentID != span.Context.SpanID.String() || got.RequestID != "id" {
+		t.Errorf("unexpected span %+v", got)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	received := make(chan otlpTraces, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req otlpTraces
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		received <- req
+	}))
+	defer srv.Close()
+
+	exporter := NewOTLPExporter(logger(), srv.Client(), srv.URL+"/v1/traces", "euvsvirus-backend")
+	tracer := New(exporter)
+	_, span := tracer.start(context.Background(), "/newspb.NewsRPC/AddNew", Server, SpanContext{}, "id")
+	span.Finish(status.Error(codes.Internal, "disk full"))
+	exporter.Flush(context.Background())
+
+	req := <-received
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	if got := req.ResourceSpans[0].Resource.Attributes; !cmp.Equal(got, []otlpKeyValue{keyValue("service.name", "euvsvirus-backend")}) {
+		t.Errorf("unexpected resource attributes %+v", got)
+	}
+	got := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if got.TraceID != span.Context.TraceID.String() || got.Kind != otlpKindServer || got.Status.Code != otlpStatusError {
+		t.Errorf("unexpected span %+v", got)
+	}
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/euvsvirus-banan/backend/internal/storage"
 	"github.com/euvsvirus-banan/backend/internal/version"
@@ -35,8 +37,15 @@ func (svc *Service) GetVersion(ctx context.Context, req *newspb.GetVersionReques
 }
 
 func (svc *Service) AddNew(ctx context.Context, req *newspb.AddNewRequest) (*newspb.AddNewResponse, error) {
+	if req.New == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing news")
+	}
+	if err := validEndDate(req.New); err != nil {
+		return nil, err
+	}
 	id := uuid.New().String()
-	if err := svc.news.Add(id, req.New); err != nil {
+	req.New.CreationDate = time.Now().UTC().Format(time.RFC3339)
+	if err := svc.news.Add(ctx, id, req.New); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &newspb.AddNewResponse{
@@ -45,14 +54,25 @@ func (svc *Service) AddNew(ctx context.Context, req *newspb.AddNewRequest) (*new
 }
 
 func (svc *Service) DeleteNew(ctx context.Context, req *newspb.DeleteNewRequest) (*newspb.DeleteNewResponse, error) {
-	if err := svc.news.Delete(req.NewId); err != nil {
+	if err := svc.news.Delete(ctx, req.NewId); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &newspb.DeleteNewResponse{}, nil
 }
 
 func (svc *Service) UpdateNew(ctx context.Context, req *newspb.UpdateNewRequest) (*newspb.UpdateNewResponse, error) {
-	if err := svc.news.Update(req.NewId, req.New); err != nil {
+	if req.New == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing news")
+	}
+	if err := validEndDate(req.New); err != nil {
+		return nil, err
+	}
+	current, err := svc.news.Get(req.NewId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "news not found")
+	}
+	req.New.CreationDate = current.CreationDate
+	if err := svc.news.Update(ctx, req.NewId, req.New); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	u, err := svc.news.Get(req.NewId)
@@ -62,6 +82,17 @@ func (svc *Service) UpdateNew(ctx context.Context, req *newspb.UpdateNewRequest)
 	return &newspb.UpdateNewResponse{New: u}, nil
 }
 
+// validEndDate checks that the end date of the news, when given, is an RFC 3339 date.
+func validEndDate(n *newspb.News) error {
+	if n.GetEndDate() == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, n.EndDate); err != nil {
+		return status.Error(codes.InvalidArgument, "end date must be an RFC 3339 date")
+	}
+	return nil
+}
+
 func (svc *Service) GetNews(req *newspb.GetNewsRequest, stream newspb.NewsRPC_GetNewsServer) error {
 	for id, new := range svc.news.All() {
 		if err := stream.Send(
@@ -102,3 +133,105 @@ func (svc *Service) SearchNewsByPostcode(req *newspb.SearchNewsByPostcodeRequest
 	}
 	return nil
 }
+
+// maxReplay limits the number of news sent when a client connects to WatchNews.
+const maxReplay = 100
+
+var watchEvents = map[storage.EventType]newspb.WatchNewsResponse_Event{ // nolint: gochecknoglobals
+	storage.EventCreated: newspb.WatchNewsResponse_CREATED,
+	storage.EventUpdated: newspb.WatchNewsResponse_UPDATED,
+	storage.EventDeleted: newspb.WatchNewsResponse_DELETED,
+}
+
+func (svc *Service) WatchNews(req *newspb.WatchNewsRequest, stream newspb.NewsRPC_WatchNewsServer) error {
+	// the news replayed and the changes followed don't overlap, nothing is lost or sent twice
+	all, sub, err := svc.news.WatchAll()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer sub.Close()
+
+	for _, resp := range recentNews(req, all) {
+		if err := stream.Send(resp); err != nil {
+			return status.Error(codes.Unknown, err.Error())
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-sub.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "client too slow")
+			}
+			n := e.Value.(*newspb.News)
+			if !matchesWatch(req, n) {
+				continue
+			}
+			if err := stream.Send(
+				&newspb.WatchNewsResponse{
+					Event:  watchEvents[e.Type],
+					NewsId: e.ID,
+					News:   n,
+				},
+			); err != nil {
+				return status.Error(codes.Unknown, err.Error())
+			}
+		}
+	}
+}
+
+// recentNews returns the latest of the news matching the watch filter, oldest first.
+func recentNews(req *newspb.WatchNewsRequest, all map[string]*newspb.News) []*newspb.WatchNewsResponse {
+	limit := int(req.Replay)
+	if limit > maxReplay {
+		limit = maxReplay
+	}
+	if limit == 0 {
+		return nil
+	}
+
+	var ids []string
+	for id, n := range all {
+		if matchesWatch(req, n) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return all[ids[i]].CreationDate > all[ids[j]].CreationDate
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	replay := make([]*newspb.WatchNewsResponse, len(ids))
+	for i, id := range ids {
+		replay[len(ids)-1-i] = &newspb.WatchNewsResponse{
+			Event:    newspb.WatchNewsResponse_CREATED,
+			NewsId:   id,
+			News:     all[id],
+			Replayed: true,
+		}
+	}
+	return replay
+}
+
+func matchesWatch(req *newspb.WatchNewsRequest, n *newspb.News) bool {
+	if n.Priority < req.MinPriority {
+		return false
+	}
+	if len(req.Postcodes) == 0 && len(req.Regions) == 0 {
+		return true
+	}
+	return contains(req.Postcodes, n.Postcode) || contains(req.Regions, n.Region)
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
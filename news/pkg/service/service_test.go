@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/news/rpc/newspb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func getTestService(w io.WriteSeeker, logger *logrus.Entry) *Service {
+	return &Service{
+		logger: logger,
+		news: storage.NewNewsStorage(
+			w,
+			map[string]*newspb.News{
+				"a": {
+					Title:        "pharmacy closed",
+					Postcode:     "12345",
+					Priority:     newspb.News_URGENT,
+					CreationDate: "2020-04-25T10:00:00Z",
+				},
+				"b": {
+					Title:        "new restrictions",
+					Region:       "Stockholm",
+					Priority:     newspb.News_HIGH,
+					CreationDate: "2020-04-26T10:00:00Z",
+				},
+				"c": {
+					Title:        "market open on sunday",
+					Postcode:     "12345",
+					CreationDate: "2020-04-27T10:00:00Z",
+				},
+				"d": {
+					Title:        "bridge closed",
+					Postcode:     "54321",
+					Priority:     newspb.News_URGENT,
+					CreationDate: "2020-04-28T10:00:00Z",
+				},
+			},
+		),
+	}
+}
+
+type watchStream struct {
+	newspb.NewsRPC_WatchNewsServer
+	ctx       context.Context
+	responses chan *newspb.WatchNewsResponse
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(resp *newspb.WatchNewsResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+func TestWatchNews(t *testing.T) {
+	w := &storagetest.File{}
+	logger := logrus.NewEntry(logrus.New())
+	svc := getTestService(w, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchStream{
+		ctx:       ctx,
+		responses: make(chan *newspb.WatchNewsResponse, 10),
+	}
+	done := make(chan error)
+	go func() {
+		done <- svc.WatchNews(&newspb.WatchNewsRequest{
+			Postcodes:   []string{"12345"},
+			Regions:     []string{"Stockholm"},
+			MinPriority: newspb.News_HIGH,
+			Replay:      5,
+		}, stream)
+	}()
+
+	var replayed []string
+	for i := 0; i < 2; i++ {
+		resp := <-stream.responses
+		if !resp.Replayed {
+			t.Errorf("expected %s to be replayed", resp.NewsId)
+		}
+		replayed = append(replayed, resp.NewsId)
+	}
+	if !cmp.Equal([]string{"a", "b"}, replayed) {
+		t.Error(cmp.Diff([]string{"a", "b"}, replayed))
+	}
+
+	if _, err := svc.AddNew(context.Background(), &newspb.AddNewRequest{
+		New: &newspb.News{Title: "low priority", Postcode: "12345"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AddNew(context.Background(), &newspb.AddNewRequest{
+		New: &newspb.News{Title: "water cut", Region: "Stockholm", Priority: newspb.News_URGENT},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := <-stream.responses
+	if resp.Replayed || resp.News.Title != "water cut" {
+		t.Errorf("unexpected news: %v", resp)
+	}
+	if resp.News.CreationDate == "" {
+		t.Error("expected creation date to be set")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEndDate(t *testing.T) {
+	svc := getTestService(&storagetest.File{}, logrus.NewEntry(logrus.New()))
+
+	if _, err := svc.AddNew(context.Background(), &newspb.AddNewRequest{
+		New: &newspb.News{Title: "market closed", EndDate: "2020-05-01T00:00:00Z"},
+	}); err != nil {
+		t.Errorf("expected an RFC 3339 end date to be accepted, got %v", err)
+	}
+	if _, err := svc.AddNew(context.Background(), &newspb.AddNewRequest{
+		New: &newspb.News{Title: "market closed", EndDate: "next week"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	if _, err := svc.UpdateNew(context.Background(), &newspb.UpdateNewRequest{
+		NewId: "a",
+		New:   &newspb.News{Title: "pharmacy closed", EndDate: "2020-13-01"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestUpdateNewCreationDate(t *testing.T) {
+	svc := getTestService(&storagetest.File{}, logrus.NewEntry(logrus.New()))
+
+	resp, err := svc.UpdateNew(context.Background(), &newspb.UpdateNewRequest{
+		NewId: "a",
+		New:   &newspb.News{Title: "pharmacy open", Postcode: "12345", CreationDate: "2030-01-01T00:00:00Z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.New.CreationDate != "2020-04-25T10:00:00Z" || resp.New.Title != "pharmacy open" {
+		t.Errorf("expected the creation date to be kept, got %v", resp.New)
+	}
+	_, err = svc.UpdateNew(context.Background(), &newspb.UpdateNewRequest{NewId: "missing", New: &newspb.News{}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
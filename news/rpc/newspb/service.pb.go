@@ -26,10 +26,71 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type News_Priority int32
+
+const (
+	News_NORMAL News_Priority = 0
+	News_HIGH   News_Priority = 1
+	News_URGENT News_Priority = 2
+)
+
+var News_Priority_name = map[int32]string{
+	0: "NORMAL",
+	1: "HIGH",
+	2: "URGENT",
+}
+
+var News_Priority_value = map[string]int32{
+	"NORMAL": 0,
+	"HIGH":   1,
+	"URGENT": 2,
+}
+
+func (x News_Priority) String() string {
+	return proto.EnumName(News_Priority_name, int32(x))
+}
+
+func (News_Priority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4d353dcfc1f71761, []int{0, 0}
+}
+
+type WatchNewsResponse_Event int32
+
+const (
+	WatchNewsResponse_CREATED WatchNewsResponse_Event = 0
+	WatchNewsResponse_UPDATED WatchNewsResponse_Event = 1
+	WatchNewsResponse_DELETED WatchNewsResponse_Event = 2
+)
+
+var WatchNewsResponse_Event_name = map[int32]string{
+	0: "CREATED",
+	1: "UPDATED",
+	2: "DELETED",
+}
+
+var WatchNewsResponse_Event_value = map[string]int32{
+	"CREATED": 0,
+	"UPDATED": 1,
+	"DELETED": 2,
+}
+
+func (x WatchNewsResponse_Event) String() string {
+	return proto.EnumName(WatchNewsResponse_Event_name, int32(x))
+}
+
+func (WatchNewsResponse_Event) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4d353dcfc1f71761, []int{16, 0}
+}
+
 type News struct {
-	Title                string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Body                 string   `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
-	Postcode             string   `protobuf:"bytes,3,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	Title        string        `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Body         string        `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Postcode     string        `protobuf:"bytes,3,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	Region       string        `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	Priority     News_Priority `protobuf:"varint,5,opt,name=priority,proto3,enum=newspb.News_Priority" json:"priority,omitempty"`
+	CreationDate string        `protobuf:"bytes,6,opt,name=creation_date,json=creationDate,proto3" json:"creation_date,omitempty"`
+	// RFC 3339 date after which the news is outdated, the news never expires if empty.
+	EndDate              string   `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -89,6 +150,34 @@ func (m *News) GetPostcode() string {
 	return ""
 }
 
+func (m *News) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *News) GetPriority() News_Priority {
+	if m != nil {
+		return m.Priority
+	}
+	return News_NORMAL
+}
+
+func (m *News) GetCreationDate() string {
+	if m != nil {
+		return m.CreationDate
+	}
+	return ""
+}
+
+func (m *News) GetEndDate() string {
+	if m != nil {
+		return m.EndDate
+	}
+	return ""
+}
+
 type GetVersionRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -779,7 +868,151 @@ func (m *SearchNewsByPostcodeResponse) GetNews() *News {
 	return nil
 }
 
+type WatchNewsRequest struct {
+	Postcodes            []string      `protobuf:"bytes,1,rep,name=postcodes,proto3" json:"postcodes,omitempty"`
+	Regions              []string      `protobuf:"bytes,2,rep,name=regions,proto3" json:"regions,omitempty"`
+	MinPriority          News_Priority `protobuf:"varint,3,opt,name=min_priority,json=minPriority,proto3,enum=newspb.News_Priority" json:"min_priority,omitempty"`
+	Replay               uint32        `protobuf:"varint,4,opt,name=replay,proto3" json:"replay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WatchNewsRequest) Reset()         { *m = WatchNewsRequest{} }
+func (m *WatchNewsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchNewsRequest) ProtoMessage()    {}
+func (*WatchNewsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d353dcfc1f71761, []int{15}
+}
+func (m *WatchNewsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchNewsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchNewsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchNewsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchNewsRequest.Merge(m, src)
+}
+func (m *WatchNewsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchNewsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchNewsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchNewsRequest proto.InternalMessageInfo
+
+func (m *WatchNewsRequest) GetPostcodes() []string {
+	if m != nil {
+		return m.Postcodes
+	}
+	return nil
+}
+
+func (m *WatchNewsRequest) GetRegions() []string {
+	if m != nil {
+		return m.Regions
+	}
+	return nil
+}
+
+func (m *WatchNewsRequest) GetMinPriority() News_Priority {
+	if m != nil {
+		return m.MinPriority
+	}
+	return News_NORMAL
+}
+
+func (m *WatchNewsRequest) GetReplay() uint32 {
+	if m != nil {
+		return m.Replay
+	}
+	return 0
+}
+
+type WatchNewsResponse struct {
+	Event                WatchNewsResponse_Event `protobuf:"varint,1,opt,name=event,proto3,enum=newspb.WatchNewsResponse_Event" json:"event,omitempty"`
+	NewsId               string                  `protobuf:"bytes,2,opt,name=news_id,json=newsId,proto3" json:"news_id,omitempty"`
+	News                 *News                   `protobuf:"bytes,3,opt,name=news,proto3" json:"news,omitempty"`
+	Replayed             bool                    `protobuf:"varint,4,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *WatchNewsResponse) Reset()         { *m = WatchNewsResponse{} }
+func (m *WatchNewsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchNewsResponse) ProtoMessage()    {}
+func (*WatchNewsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d353dcfc1f71761, []int{16}
+}
+func (m *WatchNewsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchNewsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchNewsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchNewsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchNewsResponse.Merge(m, src)
+}
+func (m *WatchNewsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchNewsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchNewsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchNewsResponse proto.InternalMessageInfo
+
+func (m *WatchNewsResponse) GetEvent() WatchNewsResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return WatchNewsResponse_CREATED
+}
+
+func (m *WatchNewsResponse) GetNewsId() string {
+	if m != nil {
+		return m.NewsId
+	}
+	return ""
+}
+
+func (m *WatchNewsResponse) GetNews() *News {
+	if m != nil {
+		return m.News
+	}
+	return nil
+}
+
+func (m *WatchNewsResponse) GetReplayed() bool {
+	if m != nil {
+		return m.Replayed
+	}
+	return false
+}
+
 func init() {
+	proto.RegisterEnum("newspb.News_Priority", News_Priority_name, News_Priority_value)
+	proto.RegisterEnum("newspb.WatchNewsResponse_Event", WatchNewsResponse_Event_name, WatchNewsResponse_Event_value)
 	proto.RegisterType((*News)(nil), "newspb.News")
 	proto.RegisterType((*GetVersionRequest)(nil), "newspb.GetVersionRequest")
 	proto.RegisterType((*GetVersionResponse)(nil), "newspb.GetVersionResponse")
@@ -795,48 +1028,66 @@ func init() {
 	proto.RegisterType((*GetNewsByIDResponse)(nil), "newspb.GetNewsByIDResponse")
 	proto.RegisterType((*SearchNewsByPostcodeRequest)(nil), "newspb.SearchNewsByPostcodeRequest")
 	proto.RegisterType((*SearchNewsByPostcodeResponse)(nil), "newspb.SearchNewsByPostcodeResponse")
+	proto.RegisterType((*WatchNewsRequest)(nil), "newspb.WatchNewsRequest")
+	proto.RegisterType((*WatchNewsResponse)(nil), "newspb.WatchNewsResponse")
 }
 
 func init() { proto.RegisterFile("news/rpc/newspb/service.proto", fileDescriptor_4d353dcfc1f71761) }
 
 var fileDescriptor_4d353dcfc1f71761 = []byte{
-	// 566 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0x49, 0x9a, 0x90, 0x49, 0x29, 0xc9, 0xa6, 0x3f, 0xae, 0x4b, 0xad, 0x62, 0x90, 0x80,
-	0x03, 0x09, 0x6a, 0x0f, 0x15, 0x12, 0x07, 0x68, 0x23, 0x4a, 0x24, 0x54, 0x55, 0x41, 0x20, 0x71,
-	0x8a, 0x12, 0x7b, 0x14, 0x8c, 0x22, 0xaf, 0xb1, 0xb7, 0xb1, 0xfa, 0x26, 0xbc, 0x02, 0x6f, 0xc2,
-	0x91, 0x47, 0x40, 0xe1, 0x1d, 0x38, 0x57, 0xbb, 0xde, 0xb5, 0x5d, 0xc7, 0x49, 0x6e, 0x9e, 0x99,
-	0x6f, 0xbe, 0xf9, 0x66, 0xf7, 0x5b, 0xc3, 0xa1, 0x87, 0x51, 0xd8, 0x0d, 0x7c, 0xbb, 0xcb, 0x3f,
-	0xfc, 0x71, 0x37, 0xc4, 0x60, 0xe6, 0xda, 0xd8, 0xf1, 0x03, 0xca, 0x28, 0xa9, 0xc6, 0x59, 0xeb,
-	0x23, 0x54, 0x2e, 0x31, 0x0a, 0xc9, 0x36, 0x6c, 0x30, 0x97, 0x4d, 0x51, 0xd7, 0x8e, 0xb4, 0xe7,
-	0xf5, 0x41, 0x1c, 0x10, 0x02, 0x95, 0x31, 0x75, 0x6e, 0xf4, 0x92, 0x48, 0x8a, 0x6f, 0x62, 0xc0,
-	0x7d, 0x9f, 0x86, 0xcc, 0xa6, 0x0e, 0xea, 0x65, 0x91, 0x4f, 0x62, 0xab, 0x0d, 0xad, 0x0b, 0x64,
-	0x5f, 0x30, 0x08, 0x5d, 0xea, 0x0d, 0xf0, 0xc7, 0x35, 0x86, 0xcc, 0xfa, 0xa5, 0x01, 0xc9, 0x66,
-	0x43, 0x9f, 0x7a, 0x21, 0x12, 0x1d, 0x6a, 0x7e, 0x40, 0xbf, 0xa3, 0xcd, 0xe4, 0x4c, 0x15, 0xf2,
-	0xca, 0x2c, 0x06, 0xcb, 0xc1, 0x2a, 0x24, 0x87, 0x00, 0xe3, 0x6b, 0x77, 0xea, 0x0c, 0x9d, 0x11,
-	0x53, 0xd3, 0xeb, 0x22, 0xd3, 0x1b, 0x31, 0x24, 0x8f, 0x61, 0x73, 0xe2, 0xb2, 0x61, 0x80, 0x33,
-	0x57, 0x74, 0x57, 0x04, 0xa0, 0x31, 0x71, 0xd9, 0x40, 0xa6, 0x38, 0xc3, 0x84, 0x0e, 0x15, 0xfd,
-	0x46, 0xcc, 0x30, 0xa1, 0x52, 0x9c, 0xd5, 0x85, 0x07, 0xef, 0x1c, 0xe7, 0x12, 0x23, 0x29, 0x9e,
-	0x98, 0x50, 0xf6, 0x30, 0x12, 0x0a, 0x1b, 0xc7, 0x9b, 0x9d, 0xf8, 0xd4, 0x3a, 0xfc, 0xc8, 0x06,
-	0xbc, 0x60, 0x3d, 0x83, 0x2d, 0xd5, 0x20, 0xf7, 0xda, 0x01, 0x7e, 0xb6, 0x43, 0xd7, 0x51, 0x47,
-	0xe9, 0x61, 0xd4, 0x77, 0xac, 0x17, 0xd0, 0xec, 0xe1, 0x14, 0x19, 0x66, 0xc8, 0x97, 0x40, 0xdb,
-	0xd0, 0xca, 0x40, 0x63, 0x5a, 0xab, 0x0f, 0xcd, 0xcf, 0x3e, 0x5f, 0x7b, 0x6d, 0xbf, 0xd2, 0x5c,
-	0x5a, 0xa6, 0xf9, 0x04, 0x5a, 0x19, 0x2a, 0x29, 0x7b, 0xdd, 0xa2, 0x4d, 0xd8, 0xba, 0x40, 0x26,
-	0x62, 0x79, 0xaf, 0x1f, 0xe0, 0x61, 0x92, 0x59, 0xb9, 0xfb, 0x5a, 0x41, 0x2f, 0x85, 0x41, 0x78,
-	0x7c, 0x76, 0xd3, 0xef, 0xa9, 0xed, 0xf6, 0xa0, 0xc6, 0x91, 0x29, 0x9b, 0xf0, 0x6c, 0xdf, 0xb1,
-	0x4e, 0xa1, 0x7d, 0x07, 0x2e, 0x87, 0x1f, 0x41, 0x85, 0x03, 0x0a, 0x57, 0x10, 0x15, 0xeb, 0x35,
-	0x1c, 0x7c, 0xc2, 0x51, 0x60, 0x7f, 0x8b, 0x7b, 0xaf, 0xa4, 0x6d, 0xd5, 0xc0, 0xac, 0xb3, 0xb5,
-	0x9c, 0xb3, 0xbf, 0xc2, 0xa3, 0xe2, 0x56, 0x39, 0x7c, 0x99, 0xd8, 0x44, 0x55, 0x69, 0x99, 0xaa,
-	0xe3, 0xff, 0x65, 0xa8, 0x89, 0xf0, 0xea, 0x9c, 0x9c, 0x03, 0xa4, 0x4f, 0x85, 0xec, 0x2b, 0xf4,
-	0xc2, 0xa3, 0x32, 0x8c, 0xa2, 0x92, 0xd4, 0x72, 0x0a, 0xd5, 0xd8, 0x93, 0x64, 0x47, 0xa1, 0xee,
-	0x98, 0xda, 0xd8, 0xcd, 0xa7, 0x65, 0xe3, 0x5b, 0xa8, 0x27, 0xc6, 0x23, 0xba, 0x02, 0xe5, 0x6d,
-	0x6b, 0xec, 0x17, 0x54, 0x52, 0x86, 0xc4, 0x5a, 0x29, 0x43, 0xde, 0xb8, 0x29, 0xc3, 0xa2, 0x0f,
-	0xdf, 0x40, 0x4d, 0x5e, 0x2e, 0xd9, 0xcd, 0xec, 0x98, 0x31, 0x9e, 0xb1, 0xb7, 0x90, 0x8f, 0x7b,
-	0x5f, 0x69, 0xe4, 0x3d, 0x34, 0x32, 0xd6, 0x20, 0x46, 0x0e, 0x99, 0xb1, 0x97, 0x71, 0x50, 0x58,
-	0x93, 0x2a, 0x6c, 0xd8, 0x2e, 0xba, 0x6e, 0xf2, 0x44, 0x35, 0xad, 0xf0, 0x91, 0xf1, 0x74, 0x35,
-	0x48, 0x89, 0x3d, 0x6b, 0xfe, 0x9e, 0x9b, 0xda, 0x9f, 0xb9, 0xa9, 0xfd, 0x9d, 0x9b, 0xda, 0xcf,
-	0x7f, 0xe6, 0xbd, 0x71, 0x55, 0xfc, 0x9c, 0x4f, 0x6e, 0x03, 0x00, 0x00, 0xff, 0xff, 0xd6, 0x1a,
-	0xb7, 0x98, 0xbd, 0x05, 0x00, 0x00,
+	// 828 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0x5d, 0x6e, 0xe2, 0x56,
+	0x14, 0x8e, 0xf9, 0xe7, 0x40, 0xa8, 0xb9, 0xf9, 0x33, 0x4e, 0x42, 0x53, 0xa7, 0x52, 0x53, 0xa9,
+	0x85, 0x96, 0xa8, 0x4a, 0x2b, 0xf5, 0xa1, 0x49, 0xa0, 0x04, 0x29, 0xa5, 0xc8, 0x4d, 0x5a, 0xf5,
+	0x09, 0x01, 0xbe, 0xa2, 0xae, 0x88, 0xed, 0xb1, 0x6f, 0x40, 0x2c, 0x60, 0xf6, 0x30, 0x0f, 0xb3,
+	0x81, 0xd9, 0xc2, 0xac, 0x60, 0x1e, 0x67, 0x76, 0x30, 0xca, 0x6c, 0x64, 0x74, 0xff, 0x8c, 0x43,
+	0x0c, 0x79, 0xe3, 0x3b, 0xe7, 0xdc, 0xef, 0x7e, 0xe7, 0xf8, 0x7e, 0x47, 0xc0, 0xa1, 0x83, 0x67,
+	0x41, 0xdd, 0xf7, 0x46, 0x75, 0xfa, 0xc3, 0x1b, 0xd6, 0x03, 0xec, 0x4f, 0xed, 0x11, 0xae, 0x79,
+	0xbe, 0x4b, 0x5c, 0x94, 0xe1, 0x51, 0xe3, 0x65, 0x02, 0x52, 0x5d, 0x3c, 0x0b, 0xd0, 0x36, 0xa4,
+	0x89, 0x4d, 0x26, 0x58, 0x53, 0x8e, 0x94, 0x93, 0xbc, 0xc9, 0x01, 0x42, 0x90, 0x1a, 0xba, 0xd6,
+	0x5c, 0x4b, 0xb0, 0x20, 0xfb, 0x8d, 0x74, 0xc8, 0x79, 0x6e, 0x40, 0x46, 0xae, 0x85, 0xb5, 0x24,
+	0x8b, 0x87, 0x18, 0xed, 0x42, 0xc6, 0xc7, 0x63, 0xdb, 0x75, 0xb4, 0x14, 0xcb, 0x08, 0x84, 0x7e,
+	0x84, 0x9c, 0xe7, 0xdb, 0xae, 0x6f, 0x93, 0xb9, 0x96, 0x3e, 0x52, 0x4e, 0x4a, 0x8d, 0x9d, 0x1a,
+	0x57, 0x50, 0xa3, 0xb7, 0xd7, 0x7a, 0x22, 0x69, 0x86, 0x65, 0xe8, 0x18, 0x36, 0x47, 0x3e, 0x1e,
+	0x10, 0xdb, 0x75, 0xfa, 0xd6, 0x80, 0x60, 0x2d, 0xc3, 0x18, 0x8b, 0x32, 0xd8, 0x1c, 0x10, 0x8c,
+	0x2a, 0x90, 0xc3, 0x8e, 0xc5, 0xf3, 0x59, 0x96, 0xcf, 0x62, 0xc7, 0xa2, 0x29, 0xe3, 0x3b, 0xc8,
+	0x49, 0x56, 0x04, 0x90, 0xe9, 0xfe, 0x69, 0xfe, 0x71, 0x7e, 0xad, 0x6e, 0xa0, 0x1c, 0xa4, 0xae,
+	0x3a, 0xed, 0x2b, 0x55, 0xa1, 0xd1, 0x5b, 0xb3, 0xdd, 0xea, 0xde, 0xa8, 0x09, 0x63, 0x0b, 0xca,
+	0x6d, 0x4c, 0xfe, 0xc6, 0x7e, 0x60, 0xbb, 0x8e, 0x89, 0x5f, 0xdc, 0xe3, 0x80, 0x18, 0x6f, 0x14,
+	0x40, 0xd1, 0x68, 0xe0, 0xb9, 0x4e, 0x80, 0x91, 0x06, 0x59, 0xcf, 0x77, 0xff, 0xc7, 0x23, 0x22,
+	0x86, 0x25, 0x21, 0xcd, 0x4c, 0x79, 0xb1, 0x98, 0x98, 0x84, 0xe8, 0x10, 0x60, 0x78, 0x6f, 0x4f,
+	0x84, 0x54, 0x3e, 0xb6, 0x3c, 0x8b, 0xb0, 0x3e, 0xbe, 0x82, 0xe2, 0xd8, 0x26, 0x7d, 0x1f, 0x4f,
+	0xed, 0x60, 0x31, 0xbd, 0xc2, 0xd8, 0x26, 0xa6, 0x08, 0x51, 0x86, 0xb1, 0xdb, 0x97, 0xf4, 0x69,
+	0xce, 0x30, 0x76, 0x85, 0x38, 0xa3, 0x0e, 0x9b, 0xe7, 0x96, 0xd5, 0xc5, 0x33, 0x21, 0x1e, 0x55,
+	0x21, 0xe9, 0xe0, 0x19, 0x53, 0x58, 0x68, 0x14, 0xa3, 0xd3, 0x36, 0x69, 0xc2, 0xf8, 0x06, 0x4a,
+	0xf2, 0x80, 0xe8, 0x6b, 0x07, 0xe8, 0xab, 0xe8, 0xdb, 0x96, 0x7c, 0x03, 0x0e, 0x9e, 0x75, 0x2c,
+	0xe3, 0x5b, 0x50, 0x9b, 0x78, 0x82, 0x09, 0x8e, 0x90, 0xaf, 0x28, 0xdd, 0x82, 0x72, 0xa4, 0x94,
+	0xd3, 0x1a, 0x1d, 0x50, 0x6f, 0x3d, 0x6b, 0x20, 0x82, 0xeb, 0xce, 0x4b, 0xcd, 0x89, 0x55, 0x9a,
+	0x4f, 0xa1, 0x1c, 0xa1, 0x12, 0xb2, 0x9f, 0x6b, 0x54, 0x85, 0x52, 0x1b, 0x13, 0x86, 0xc5, 0x77,
+	0xbd, 0x82, 0x2f, 0xc2, 0xc8, 0xda, 0xde, 0x9f, 0x15, 0xf4, 0x3d, 0x7b, 0x20, 0x14, 0x5f, 0xcc,
+	0x3b, 0x4d, 0xd9, 0xdd, 0x1e, 0x64, 0x69, 0xe5, 0x82, 0x8d, 0xb9, 0xad, 0x63, 0x19, 0x67, 0xb0,
+	0xf5, 0xa8, 0x5c, 0x5c, 0x7e, 0x04, 0x29, 0x5a, 0x10, 0xdb, 0x02, 0xcb, 0x18, 0xbf, 0xc0, 0xfe,
+	0x5f, 0x78, 0xe0, 0x8f, 0xfe, 0xe3, 0x67, 0x7b, 0xc2, 0x6f, 0xf2, 0xc2, 0xa8, 0x25, 0x95, 0xc7,
+	0x96, 0x34, 0xfe, 0x85, 0x83, 0xf8, 0xa3, 0xe2, 0xf2, 0x55, 0x62, 0x43, 0x55, 0x89, 0x95, 0xaa,
+	0x5e, 0x2b, 0xa0, 0xfe, 0x33, 0x20, 0x9c, 0x5a, 0x6a, 0x39, 0x80, 0xbc, 0xbc, 0x9b, 0x76, 0x94,
+	0xa4, 0xcf, 0x34, 0x0c, 0x50, 0x87, 0xf0, 0x95, 0x40, 0x79, 0x69, 0x4e, 0x42, 0xf4, 0x33, 0x14,
+	0xef, 0x6c, 0xa7, 0x1f, 0xae, 0x89, 0xe4, 0xba, 0x35, 0x51, 0xb8, 0xb3, 0x1d, 0x09, 0xf8, 0xd2,
+	0xf1, 0x26, 0x83, 0x39, 0xb3, 0xcd, 0xa6, 0x29, 0x90, 0xf1, 0x41, 0x81, 0x72, 0x44, 0x9e, 0xe8,
+	0xf7, 0x27, 0x48, 0xe3, 0x29, 0x76, 0xb8, 0x77, 0x4b, 0x8d, 0x2f, 0xe5, 0x05, 0x4f, 0x2a, 0x6b,
+	0x2d, 0x5a, 0x66, 0xf2, 0xea, 0xe8, 0x98, 0x12, 0xb1, 0x63, 0x4a, 0xae, 0x1a, 0x13, 0xfd, 0x3a,
+	0x5c, 0x11, 0xb6, 0x98, 0xc2, 0x9c, 0x19, 0x62, 0xa3, 0x06, 0x69, 0x76, 0x0d, 0x2a, 0x40, 0xf6,
+	0xd2, 0x6c, 0x9d, 0xdf, 0xb4, 0x9a, 0xea, 0x06, 0x05, 0xb7, 0xbd, 0x26, 0x03, 0x0a, 0x05, 0xcd,
+	0xd6, 0x75, 0x8b, 0x82, 0x44, 0xe3, 0x6d, 0x0a, 0xb2, 0x8c, 0xba, 0x77, 0x89, 0x2e, 0x01, 0x16,
+	0xdb, 0x09, 0x55, 0xe4, 0xcd, 0x4f, 0xf6, 0x98, 0xae, 0xc7, 0xa5, 0xc4, 0x38, 0xce, 0x20, 0xc3,
+	0xd7, 0x00, 0x0a, 0x47, 0xfd, 0x68, 0x8f, 0xe8, 0xbb, 0xcb, 0x61, 0x71, 0xf0, 0x37, 0xc8, 0x87,
+	0x5e, 0x47, 0x9a, 0x2c, 0x5a, 0xde, 0x14, 0x7a, 0x25, 0x26, 0xb3, 0x60, 0x08, 0xdd, 0xbc, 0x60,
+	0x58, 0xde, 0x15, 0x7a, 0x25, 0x26, 0x23, 0x18, 0x7e, 0x85, 0xac, 0xf0, 0x13, 0xda, 0x8d, 0xf4,
+	0x18, 0x79, 0x8e, 0xfa, 0xde, 0x93, 0x38, 0x3f, 0xfb, 0x83, 0x82, 0x7e, 0x87, 0x42, 0xc4, 0x8d,
+	0x48, 0x5f, 0xaa, 0x8c, 0x38, 0x5a, 0xdf, 0x8f, 0xcd, 0x09, 0x15, 0x23, 0xd8, 0x8e, 0x73, 0x18,
+	0x3a, 0x96, 0x87, 0xd6, 0x58, 0x57, 0xff, 0x7a, 0x7d, 0x51, 0x28, 0xf6, 0x02, 0xf2, 0xe1, 0x0b,
+	0x5d, 0x0c, 0x6b, 0xd9, 0x7d, 0x7a, 0x25, 0x26, 0x23, 0x39, 0x2e, 0xd4, 0x77, 0x0f, 0x55, 0xe5,
+	0xfd, 0x43, 0x55, 0xf9, 0xf8, 0x50, 0x55, 0x5e, 0x7d, 0xaa, 0x6e, 0x0c, 0x33, 0xec, 0xdf, 0xc0,
+	0xe9, 0xe7, 0x01, 0x00, 0xf0, 0xec, 0xed, 0x92, 0x2e, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -864,6 +1115,10 @@ type NewsRPCClient interface {
 	GetNews(ctx context.Context, in *GetNewsRequest, opts ...grpc.CallOption) (NewsRPC_GetNewsClient, error)
 	GetNewsByID(ctx context.Context, in *GetNewsByIDRequest, opts ...grpc.CallOption) (*GetNewsByIDResponse, error)
 	SearchNewsByPostcode(ctx context.Context, in *SearchNewsByPostcodeRequest, opts ...grpc.CallOption) (NewsRPC_SearchNewsByPostcodeClient, error)
+	// Streams the news matching any of the given postcodes or regions (everything if none is given)
+	// with at least the given priority as they are published. The latest `replay` matching news are
+	// sent first, flagged as replayed
+	WatchNews(ctx context.Context, in *WatchNewsRequest, opts ...grpc.CallOption) (NewsRPC_WatchNewsClient, error)
 }
 
 type newsRPCClient struct {
@@ -983,6 +1238,38 @@ func (x *newsRPCSearchNewsByPostcodeClient) Recv() (*SearchNewsByPostcodeRespons
 	return m, nil
 }
 
+func (c *newsRPCClient) WatchNews(ctx context.Context, in *WatchNewsRequest, opts ...grpc.CallOption) (NewsRPC_WatchNewsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NewsRPC_serviceDesc.Streams[2], "/newspb.NewsRPC/WatchNews", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &newsRPCWatchNewsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NewsRPC_WatchNewsClient interface {
+	Recv() (*WatchNewsResponse, error)
+	grpc.ClientStream
+}
+
+type newsRPCWatchNewsClient struct {
+	grpc.ClientStream
+}
+
+func (x *newsRPCWatchNewsClient) Recv() (*WatchNewsResponse, error) {
+	m := new(WatchNewsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // NewsRPCServer is the server API for NewsRPC service.
 type NewsRPCServer interface {
 	// Returns software version and build details
@@ -998,6 +1285,10 @@ type NewsRPCServer interface {
 	GetNews(*GetNewsRequest, NewsRPC_GetNewsServer) error
 	GetNewsByID(context.Context, *GetNewsByIDRequest) (*GetNewsByIDResponse, error)
 	SearchNewsByPostcode(*SearchNewsByPostcodeRequest, NewsRPC_SearchNewsByPostcodeServer) error
+	// Streams the news matching any of the given postcodes or regions (everything if none is given)
+	// with at least the given priority as they are published. The latest `replay` matching news are
+	// sent first, flagged as replayed
+	WatchNews(*WatchNewsRequest, NewsRPC_WatchNewsServer) error
 }
 
 // UnimplementedNewsRPCServer can be embedded to have forward compatible implementations.
@@ -1025,6 +1316,9 @@ func (*UnimplementedNewsRPCServer) GetNewsByID(ctx context.Context, req *GetNews
 func (*UnimplementedNewsRPCServer) SearchNewsByPostcode(req *SearchNewsByPostcodeRequest, srv NewsRPC_SearchNewsByPostcodeServer) error {
 	return status.Errorf(codes.Unimplemented, "method SearchNewsByPostcode not implemented")
 }
+func (*UnimplementedNewsRPCServer) WatchNews(req *WatchNewsRequest, srv NewsRPC_WatchNewsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNews not implemented")
+}
 
 func RegisterNewsRPCServer(s *grpc.Server, srv NewsRPCServer) {
 	s.RegisterService(&_NewsRPC_serviceDesc, srv)
@@ -1162,6 +1456,27 @@ func (x *newsRPCSearchNewsByPostcodeServer) Send(m *SearchNewsByPostcodeResponse
 	return x.ServerStream.SendMsg(m)
 }
 
+func _NewsRPC_WatchNews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNewsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NewsRPCServer).WatchNews(m, &newsRPCWatchNewsServer{stream})
+}
+
+type NewsRPC_WatchNewsServer interface {
+	Send(*WatchNewsResponse) error
+	grpc.ServerStream
+}
+
+type newsRPCWatchNewsServer struct {
+	grpc.ServerStream
+}
+
+func (x *newsRPCWatchNewsServer) Send(m *WatchNewsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _NewsRPC_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "newspb.NewsRPC",
 	HandlerType: (*NewsRPCServer)(nil),
@@ -1198,6 +1513,11 @@ var _NewsRPC_serviceDesc = grpc.ServiceDesc{
 			Handler:       _NewsRPC_SearchNewsByPostcode_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchNews",
+			Handler:       _NewsRPC_WatchNews_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "news/rpc/newspb/service.proto",
 }
@@ -1226,6 +1546,32 @@ func (m *News) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.EndDate) > 0 {
+		i -= len(m.EndDate)
+		copy(dAtA[i:], m.EndDate)
+		i = encodeVarintService(dAtA, i, uint64(len(m.EndDate)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.CreationDate) > 0 {
+		i -= len(m.CreationDate)
+		copy(dAtA[i:], m.CreationDate)
+		i = encodeVarintService(dAtA, i, uint64(len(m.CreationDate)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Priority != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Region) > 0 {
+		i -= len(m.Region)
+		copy(dAtA[i:], m.Region)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Region)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.Postcode) > 0 {
 		i -= len(m.Postcode)
 		copy(dAtA[i:], m.Postcode)
@@ -1784,6 +2130,122 @@ func (m *SearchNewsByPostcodeResponse) MarshalToSizedBuffer(dAtA []byte) (int, e
 	return len(dAtA) - i, nil
 }
 
+func (m *WatchNewsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchNewsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchNewsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Replay != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Replay))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MinPriority != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.MinPriority))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Regions) > 0 {
+		for iNdEx := len(m.Regions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Regions[iNdEx])
+			copy(dAtA[i:], m.Regions[iNdEx])
+			i = encodeVarintService(dAtA, i, uint64(len(m.Regions[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Postcodes) > 0 {
+		for iNdEx := len(m.Postcodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Postcodes[iNdEx])
+			copy(dAtA[i:], m.Postcodes[iNdEx])
+			i = encodeVarintService(dAtA, i, uint64(len(m.Postcodes[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchNewsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchNewsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchNewsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Replayed {
+		i--
+		if m.Replayed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.News != nil {
+		{
+			size, err := m.News.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.NewsId) > 0 {
+		i -= len(m.NewsId)
+		copy(dAtA[i:], m.NewsId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.NewsId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Event != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Event))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintService(dAtA []byte, offset int, v uint64) int {
 	offset -= sovService(v)
 	base := offset
@@ -1813,8 +2275,23 @@ func (m *News) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovService(uint64(l))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	l = len(m.Region)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovService(uint64(m.Priority))
+	}
+	l = len(m.CreationDate)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.EndDate)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
@@ -2059,6 +2536,62 @@ func (m *SearchNewsByPostcodeResponse) Size() (n int) {
 	return n
 }
 
+func (m *WatchNewsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Postcodes) > 0 {
+		for _, s := range m.Postcodes {
+			l = len(s)
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.Regions) > 0 {
+		for _, s := range m.Regions {
+			l = len(s)
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if m.MinPriority != 0 {
+		n += 1 + sovService(uint64(m.MinPriority))
+	}
+	if m.Replay != 0 {
+		n += 1 + sovService(uint64(m.Replay))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WatchNewsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Event != 0 {
+		n += 1 + sovService(uint64(m.Event))
+	}
+	l = len(m.NewsId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.News != nil {
+		l = m.News.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Replayed {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovService(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2190,6 +2723,121 @@ func (m *News) Unmarshal(dAtA []byte) error {
 			}
 			m.Postcode = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Region = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= News_Priority(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreationDate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreationDate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndDate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndDate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -3571,6 +4219,323 @@ func (m *SearchNewsByPostcodeResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *WatchNewsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchNewsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchNewsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Postcodes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Postcodes = append(m.Postcodes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Regions = append(m.Regions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinPriority", wireType)
+			}
+			m.MinPriority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinPriority |= News_Priority(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replay", wireType)
+			}
+			m.Replay = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Replay |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchNewsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchNewsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchNewsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			m.Event = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Event |= WatchNewsResponse_Event(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewsId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewsId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field News", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.News == nil {
+				m.News = &News{}
+			}
+			if err := m.News.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replayed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Replayed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipService(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,97 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+)
+
+// EmailSender delivers notifications through an SMTP server, upgrading the connection with
+// STARTTLS when the server supports it.
+type EmailSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewEmailSender(addr, from, username, password string) *EmailSender {
+	s := &EmailSender{
+		addr: addr,
+		from: from,
+	}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s
+}
+
+func (s *EmailSender) Send(ctx context.Context, n *notificationspb.Notification) error {
+	host, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return fmt.Errorf("%w: invalid SMTP address", err)
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("%w: problem connecting to SMTP server", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("%w: problem greeting SMTP server", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil { // nolint: gosec
+			return fmt.Errorf("%w: problem starting TLS", err)
+		}
+	}
+	if s.auth != nil {
+		if err := c.Auth(s.auth); err != nil {
+			return fmt.Errorf("%w: problem authenticating", err)
+		}
+	}
+	if err := c.Mail(s.from); err != nil {
+		return fmt.Errorf("%w: sender rejected", err)
+	}
+	if err := c.Rcpt(n.Recipient); err != nil {
+		return fmt.Errorf("%w: recipient rejected", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("%w: problem sending message", err)
+	}
+	if _, err := w.Write(s.message(n)); err != nil {
+		return fmt.Errorf("%w: problem sending message", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("%w: message rejected", err)
+	}
+	return c.Quit()
+}
+
+func (s *EmailSender) message(n *notificationspb.Notification) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", n.Recipient)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", n.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(n.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
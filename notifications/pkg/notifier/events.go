@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// event is a domain event users can be notified about.
+type event struct {
+	kind       notificationspb.Event
+	requestID  string
+	request    *requestspb.Request
+	answer     *requestspb.Request_Answer
+	recipients []string
+}
+
+// requestEvents derives the domain events users are notified about from an event recorded in the
+// outbox, the events of the other changes are ignored.
+func requestEvents(e *webhookspb.Event) ([]event, error) {
+	switch e.Type {
+	case storage.RequestAnswered, storage.HelpAccepted, storage.HelpCompleted, storage.HelpCancelled:
+	default:
+		return nil, nil
+	}
+	request := &requestspb.Request{}
+	if err := (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(strings.NewReader(e.Payload), request); err != nil {
+		return nil, fmt.Errorf("problem reading request of event %d: %w", e.Sequence, err)
+	}
+
+	ev := event{
+		requestID: e.EntityId,
+		request:   request,
+	}
+	switch e.Type {
+	case storage.RequestAnswered:
+		// the answer added is the last one
+		if len(request.Answers) == 0 || request.Answers[len(request.Answers)-1] == nil {
+			return nil, nil
+		}
+		ev.kind = notificationspb.Event_ANSWERED
+		ev.answer = request.Answers[len(request.Answers)-1]
+		ev.recipients = []string{request.RequesterId}
+	case storage.HelpAccepted:
+		ev.kind = notificationspb.Event_ACCEPTED
+		ev.recipients = []string{request.VolunteerId}
+	case storage.HelpCompleted:
+		ev.kind = notificationspb.Event_COMPLETED
+		ev.recipients = []string{request.VolunteerId}
+	case storage.HelpCancelled:
+		ev.kind = notificationspb.Event_CANCELLED
+		ev.recipients = cancelledRecipients(request)
+	}
+	return []event{ev}, nil
+}
+
+// cancelledRecipients returns the volunteer helping with the request or, if none was accepted
+// yet, all the volunteers who answered it.
+func cancelledRecipients(request *requestspb.Request) []string {
+	if request.VolunteerId != "" {
+		return []string{request.VolunteerId}
+	}
+	var recipients []string
+	seen := make(map[string]bool)
+	for _, a := range request.Answers {
+		if a == nil || seen[a.VolunteerId] {
+			continue
+		}
+		seen[a.VolunteerId] = true
+		recipients = append(recipients, a.VolunteerId)
+	}
+	return recipients
+}
@@ -0,0 +1,85 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// SMSSender delivers notifications through an HTTP SMS gateway, posting the phone number and
+// the text as JSON ({"to": ..., "body": ...}).
+type SMSSender struct {
+	client *http.Client
+	url    string
+	token  string
+}
+
+func NewSMSSender(client *http.Client, url, token string) *SMSSender {
+	return &SMSSender{
+		client: client,
+		url:    url,
+		token:  token,
+	}
+}
+
+func (s *SMSSender) Send(ctx context.Context, n *notificationspb.Notification) error {
+	b, err := json.Marshal(struct {
+		To   string `json:"to"`
+		Body string `json:"body"`
+	}{
+		To:   n.Recipient,
+		Body: n.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("problem marshaling SMS: %w", err)
+	}
+	return post(ctx, s.client, s.url, s.token, bytes.NewReader(b))
+}
+
+// WebhookSender delivers notifications by posting them as JSON to the URL the user configured.
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(client *http.Client) *WebhookSender {
+	return &WebhookSender{
+		client: client,
+	}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, n *notificationspb.Notification) error {
+	var b bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&b, n); err != nil {
+		return fmt.Errorf("problem marshaling notification: %w", err)
+	}
+	return post(ctx, s.client, n.Recipient, "", &b)
+}
+
+func post(ctx context.Context, client *http.Client, url, token string, body io.Reader) error {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return fmt.Errorf("%w: problem creating request", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: problem sending request", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
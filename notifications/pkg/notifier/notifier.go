@@ -0,0 +1,285 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Sender delivers notifications through a channel.
+type Sender interface {
+	Send(ctx context.Context, n *notificationspb.Notification) error
+}
+
+type Config struct {
+	// Workers is the number of notifications delivered concurrently
+	Workers int
+	// MaxAttempts is the number of deliveries tried before giving up on a notification
+	MaxAttempts int
+	// Backoff is the time waited after the first failed delivery, doubled after each failure up
+	// to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout limits the time spent on each delivery
+	Timeout time.Duration
+	// BatchSize is the number of events read from the outbox at once
+	BatchSize int
+	// Interval is the time waited between checks of the outbox when nothing gets recorded
+	Interval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Workers:     4,
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxBackoff:  time.Minute,
+		Timeout:     10 * time.Second,
+		BatchSize:   100,
+		Interval:    time.Minute,
+	}
+}
+
+// Notifier follows the changes made to the requests, as recorded in the outbox, and notifies the
+// users involved through the channels matching their contact details. Its cursor is saved after
+// each batch of events, the events of a batch interrupted by a crash are notified again.
+type Notifier struct {
+	logger      *logrus.Entry
+	outbox      *storage.Outbox
+	cursor      *storage.Cursor
+	users       *storage.UsersStorage
+	preferences *storage.PreferencesStorage
+	deadLetters *storage.DeadLettersStorage
+	senders     map[notificationspb.Channel]Sender
+	templates   *Templates
+	cfg         Config
+	jobs        chan *notificationspb.Notification
+}
+
+func New(
+	logger *logrus.Entry,
+	outbox *storage.Outbox,
+	cursor *storage.Cursor,
+	userData *storage.UsersStorage,
+	preferenceData *storage.PreferencesStorage,
+	deadLetterData *storage.DeadLettersStorage,
+	senders map[notificationspb.Channel]Sender,
+	templates *Templates,
+	cfg Config,
+) *Notifier {
+	return &Notifier{
+		logger:      logger,
+		outbox:      outbox,
+		cursor:      cursor,
+		users:       userData,
+		preferences: preferenceData,
+		deadLetters: deadLetterData,
+		senders:     senders,
+		templates:   templates,
+		cfg:         cfg,
+		jobs:        make(chan *notificationspb.Notification, cfg.Workers),
+	}
+}
+
+// Run notifies the users until the context is cancelled, notifications still being retried at
+// that point are stored as dead letters.
+func (n *Notifier) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < n.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range n.jobs {
+				n.deliver(ctx, job)
+			}
+		}()
+	}
+	defer func() {
+		close(n.jobs)
+		wg.Wait()
+	}()
+
+	for {
+		recorded := n.outbox.Wait()
+		if n.notifyPending(ctx) && ctx.Err() == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-recorded:
+		case <-time.After(n.cfg.Interval):
+		}
+	}
+}
+
+// notifyPending queues the notifications of a batch of the events after the cursor, it returns
+// whether there are more events than the batch size left.
+func (n *Notifier) notifyPending(ctx context.Context) bool {
+	last := n.cursor.Last()
+	events := n.outbox.Since(last, n.cfg.BatchSize)
+	for _, e := range events {
+		for _, notification := range n.handle(e) {
+			select {
+			case n.jobs <- notification:
+			case <-ctx.Done():
+				n.bury(notification, 0, ctx.Err())
+			}
+		}
+		last = e.Sequence
+	}
+	if len(events) == 0 {
+		return false
+	}
+	if err := n.cursor.Advance(last); err != nil {
+		n.logger.WithError(err).Error("problem saving notifier progress")
+		return false
+	}
+	return len(events) == n.cfg.BatchSize
+}
+
+// handle returns the notifications to send for an event of the outbox.
+func (n *Notifier) handle(e *webhookspb.Event) []*notificationspb.Notification {
+	events, err := requestEvents(e)
+	if err != nil {
+		n.logger.WithError(err).Error("problem handling event")
+		return nil
+	}
+	var notifications []*notificationspb.Notification
+	for _, ev := range events {
+		for _, userID := range ev.recipients {
+			notifications = append(notifications, n.notifications(userID, ev)...)
+		}
+	}
+	return notifications
+}
+
+// notifications returns the notifications to send to the user according to their preferences.
+func (n *Notifier) notifications(userID string, ev event) []*notificationspb.Notification {
+	logger := n.logger.WithFields(logrus.Fields{
+		"user_id":    userID,
+		"request_id": ev.requestID,
+		"event":      ev.kind.String(),
+	})
+
+	prefs, err := n.preferences.Get(userID)
+	if err != nil {
+		prefs = &notificationspb.Preferences{}
+	}
+	if prefs.Disabled {
+		return nil
+	}
+	for _, e := range prefs.MutedEvents {
+		if e == ev.kind {
+			return nil
+		}
+	}
+
+	user, err := n.users.Get(userID)
+	if err != nil {
+		logger.Debug("not notifying unknown user")
+		return nil
+	}
+
+	subject, body, err := n.templates.render(ev.kind, &templateData{
+		RequestID: ev.requestID,
+		Request:   ev.request,
+		Answer:    ev.answer,
+		User:      user,
+	})
+	if err != nil {
+		logger.WithError(err).Error("problem rendering notification")
+		return nil
+	}
+
+	var notifications []*notificationspb.Notification
+	add := func(channel notificationspb.Channel, recipient string) {
+		if _, ok := n.senders[channel]; !ok {
+			return
+		}
+		for _, c := range prefs.DisabledChannels {
+			if c == channel {
+				return
+			}
+		}
+		notifications = append(notifications, &notificationspb.Notification{
+			UserId:    userID,
+			Event:     ev.kind,
+			Channel:   channel,
+			Recipient: recipient,
+			Subject:   subject,
+			Body:      body,
+			RequestId: ev.requestID,
+		})
+	}
+	for _, cd := range user.ContactDetails {
+		switch cd.Platform {
+		case userspb.User_ContactDetails_EMAIL:
+			add(notificationspb.Channel_EMAIL, cd.Identifier)
+		case userspb.User_ContactDetails_PHONE:
+			add(notificationspb.Channel_SMS, cd.Identifier)
+		}
+	}
+	if prefs.WebhookUrl != "" {
+		add(notificationspb.Channel_WEBHOOK, prefs.WebhookUrl)
+	}
+	return notifications
+}
+
+func (n *Notifier) deliver(ctx context.Context, notification *notificationspb.Notification) {
+	logger := n.logger.WithFields(logrus.Fields{
+		"user_id":    notification.UserId,
+		"request_id": notification.RequestId,
+		"event":      notification.Event.String(),
+		"channel":    notification.Channel.String(),
+	})
+	sender := n.senders[notification.Channel]
+
+	backoff := n.cfg.Backoff
+	var attempts uint32
+	for {
+		attempts++
+		sctx, cancel := context.WithTimeout(ctx, n.cfg.Timeout)
+		err := sender.Send(sctx, notification)
+		cancel()
+		if err == nil {
+			logger.Debug("notification delivered")
+			return
+		}
+		logger.WithError(err).WithField("attempt", attempts).Warn("problem delivering notification")
+		if int(attempts) >= n.cfg.MaxAttempts {
+			n.bury(notification, attempts, err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			n.bury(notification, attempts, ctx.Err())
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > n.cfg.MaxBackoff {
+			backoff = n.cfg.MaxBackoff
+		}
+	}
+}
+
+// bury stores a notification that couldn't be delivered as a dead letter.
+func (n *Notifier) bury(notification *notificationspb.Notification, attempts uint32, err error) {
+	if err := n.deadLetters.Add(context.Background(), uuid.New().String(), &notificationspb.DeadLetter{
+		Notification: notification,
+		Error:        err.Error(),
+		Attempts:     attempts,
+		FailureDate:  time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		n.logger.WithError(err).Error("problem storing dead letter")
+	}
+}
@@ -0,0 +1,418 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/sirupsen/logrus"
+)
+
+// smtpStub is an SMTP server keeping the messages it receives.
+type smtpStub struct {
+	lis      net.Listener
+	mu       sync.Mutex
+	messages []string
+}
+
+func newSMTPStub(t *testing.T) *smtpStub {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &smtpStub{lis: lis}
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+	reply("220 stub")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+		case "DATA":
+			reply("354 go ahead")
+			var msg strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				msg.WriteString(l)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, msg.String())
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *smtpStub) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+// getTestNotifier returns the notifier of the changes made to the requests returned, its cursor
+// is stored in dir.
+func getTestNotifier(t *testing.T, dir string, senders map[notificationspb.Channel]Sender) (*Notifier, *storage.RequestsStorage) {
+	templates, err := LoadTemplates("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	cfg.MaxAttempts = 3
+	cfg.Backoff = time.Millisecond
+	cfg.MaxBackoff = time.Millisecond
+	outbox := storagetest.Outbox(nil)
+	requests := storagetest.Requests(map[string]*requestspb.Request{
+		"a": {
+			Title:       "help with groceries",
+			RequesterId: "Brown",
+			State:       requestspb.Request_WAITING,
+		},
+	})
+	requests.SetOutbox(outbox)
+	cursor, err := storage.OpenCursor(filepath.Join(dir, "notifier_cursor"), outbox.Last())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(
+		logrus.NewEntry(logrus.New()),
+		outbox,
+		cursor,
+		storagetest.Users(map[string]*userspb.User{
+			"Brown": {
+				Name: "Brown",
+				ContactDetails: []*userspb.User_ContactDetails{
+					{Platform: userspb.User_ContactDetails_EMAIL, Identifier: "brown@example.com"},
+					{Platform: userspb.User_ContactDetails_PHONE, Identifier: "070123456"},
+				},
+			},
+			"Blue": {
+				Name: "Blue",
+				ContactDetails: []*userspb.User_ContactDetails{
+					{Platform: userspb.User_ContactDetails_EMAIL, Identifier: "blue@example.com"},
+				},
+			},
+		}),
+		storagetest.Preferences(nil),
+		storagetest.DeadLetters(nil),
+		senders,
+		templates,
+		cfg,
+	), requests
+}
+
+// change applies the change to the request and returns the event recorded for it.
+func change(t *testing.T, n *Notifier, requests *storage.RequestsStorage, f func(r *requestspb.Request)) *webhookspb.Event {
+	r, err := requests.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f(r)
+	if err := requests.Update(context.Background(), "a", r); err != nil {
+		t.Fatal(err)
+	}
+	events := n.outbox.Since(n.outbox.Last()-1, 1)
+	if len(events) != 1 {
+		t.Fatal("expected the change to be recorded")
+	}
+	return events[0]
+}
+
+// tempDir returns a new temporary directory, removed by the function returned.
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "notifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestNotifyAnswered(t *testing.T) {
+	smtpServer := newSMTPStub(t)
+	defer smtpServer.lis.Close()
+
+	var sms []map[string]string
+	smsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		m := make(map[string]string)
+		_ = json.NewDecoder(r.Body).Decode(&m)
+		sms = append(sms, m)
+	}))
+	defer smsServer.Close()
+
+	dir, remove := tempDir(t)
+	defer remove()
+	n, requests := getTestNotifier(t, dir, map[notificationspb.Channel]Sender{
+		notificationspb.Channel_EMAIL: NewEmailSender(smtpServer.lis.Addr().String(), "noreply@example.com", "", ""),
+		notificationspb.Channel_SMS:   NewSMSSender(smsServer.Client(), smsServer.URL, "secret"),
+	})
+
+	e := change(t, n, requests, func(r *requestspb.Request) {
+		r.Answers = append(r.Answers, &requestspb.Request_Answer{
+			VolunteerId: "Blue",
+			Comment:     "I can go to the shop tomorrow",
+		})
+	})
+	notifications := n.handle(e)
+	if len(notifications) != 2 {
+		t.Fatalf("expected an email and an SMS, got %v", notifications)
+	}
+	for _, notification := range notifications {
+		n.deliver(context.Background(), notification)
+	}
+
+	messages := smtpServer.received()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 email, got %d", len(messages))
+	}
+	if !strings.Contains(messages[0], "To: brown@example.com") || !strings.Contains(messages[0], "I can go to the shop tomorrow") {
+		t.Errorf("unexpected email:\n%s", messages[0])
+	}
+	if len(sms) != 1 || sms[0]["to"] != "070123456" {
+		t.Errorf("unexpected SMS: %v", sms)
+	}
+	if len(n.deadLetters.All()) != 0 {
+		t.Error("expected no dead letters")
+	}
+}
+
+func TestNotifyPreferences(t *testing.T) {
+	dir, remove := tempDir(t)
+	defer remove()
+	n, _ := getTestNotifier(t, dir, map[notificationspb.Channel]Sender{
+		notificationspb.Channel_EMAIL:   NewEmailSender("127.0.0.1:0", "noreply@example.com", "", ""),
+		notificationspb.Channel_SMS:     NewSMSSender(http.DefaultClient, "http://127.0.0.1:0", ""),
+		notificationspb.Channel_WEBHOOK: NewWebhookSender(http.DefaultClient),
+	})
+
+	cases := []struct {
+		name     string
+		prefs    *notificationspb.Preferences
+		channels []notificationspb.Channel
+	}{
+		{
+			name:     "defaults",
+			channels: []notificationspb.Channel{notificationspb.Channel_EMAIL, notificationspb.Channel_SMS},
+		},
+		{
+			name: "disabled",
+			prefs: &notificationspb.Preferences{
+				Disabled: true,
+			},
+		},
+		{
+			name: "muted",
+			prefs: &notificationspb.Preferences{
+				MutedEvents: []notificationspb.Event{notificationspb.Event_ANSWERED},
+			},
+		},
+		{
+			name: "webhook only",
+			prefs: &notificationspb.Preferences{
+				DisabledChannels: []notificationspb.Channel{notificationspb.Channel_EMAIL, notificationspb.Channel_SMS},
+				WebhookUrl:       "https://example.com/hook",
+			},
+			channels: []notificationspb.Channel{notificationspb.Channel_WEBHOOK},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.prefs != nil {
+				if err := n.preferences.Set(context.Background(), "Brown", tc.prefs); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ev := event{
+				kind:       notificationspb.Event_ANSWERED,
+				requestID:  "a",
+				request:    &requestspb.Request{Title: "help with groceries"},
+				answer:     &requestspb.Request_Answer{VolunteerId: "Blue"},
+				recipients: []string{"Brown"},
+			}
+			var channels []notificationspb.Channel
+			for _, notification := range n.notifications("Brown", ev) {
+				channels = append(channels, notification.Channel)
+			}
+			if len(channels) != len(tc.channels) {
+				t.Fatalf("expected %v, got %v", tc.channels, channels)
+			}
+			for i := range channels {
+				if channels[i] != tc.channels[i] {
+					t.Errorf("expected %v, got %v", tc.channels, channels)
+				}
+			}
+		})
+	}
+}
+
+func TestNotifyDeadLetter(t *testing.T) {
+	var calls int
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(b), `"event":"ACCEPTED"`) {
+			t.Errorf("unexpected webhook payload: %s", b)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer webhookServer.Close()
+
+	dir, remove := tempDir(t)
+	defer remove()
+	n, requests := getTestNotifier(t, dir, map[notificationspb.Channel]Sender{
+		notificationspb.Channel_WEBHOOK: NewWebhookSender(webhookServer.Client()),
+	})
+	if err := n.preferences.Set(context.Background(), "Blue", &notificationspb.Preferences{WebhookUrl: webhookServer.URL}); err != nil {
+		t.Fatal(err)
+	}
+
+	e := change(t, n, requests, func(r *requestspb.Request) {
+		r.State = requestspb.Request_ACCEPTED
+		r.VolunteerId = "Blue"
+	})
+	notifications := n.handle(e)
+	if len(notifications) != 1 {
+		t.Fatalf("expected a webhook notification, got %v", notifications)
+	}
+	n.deliver(context.Background(), notifications[0])
+
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+	deadLetters := n.deadLetters.All()
+	if len(deadLetters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(deadLetters))
+	}
+	for _, dl := range deadLetters {
+		if dl.Attempts != 3 || dl.Notification.UserId != "Blue" {
+			t.Errorf("unexpected dead letter: %v", dl)
+		}
+	}
+}
+
+func TestNotifyFromOutbox(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, string(b))
+		mu.Unlock()
+	}))
+	defer webhookServer.Close()
+
+	dir, remove := tempDir(t)
+	defer remove()
+	n, requests := getTestNotifier(t, dir, map[notificationspb.Channel]Sender{
+		notificationspb.Channel_WEBHOOK: NewWebhookSender(webhookServer.Client()),
+	})
+	n.outbox.AddConsumer(n.cursor)
+	if err := n.preferences.Set(context.Background(), "Brown", &notificationspb.Preferences{WebhookUrl: webhookServer.URL}); err != nil {
+		t.Fatal(err)
+	}
+	// the answer is recorded before the notifier starts
+	change(t, n, requests, func(r *requestspb.Request) {
+		r.Answers = append(r.Answers, &requestspb.Request_Answer{VolunteerId: "Blue", Comment: "I can"})
+	})
+	if err := n.outbox.Prune(n.outbox.Last()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		_ = n.Run(ctx)
+		close(stopped)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for n.cursor.Last() != n.outbox.Last() {
+		if time.Now().After(deadline) {
+			t.Fatal("events not handled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-stopped
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || !strings.Contains(received[0], "Someone offered to help") {
+		t.Errorf("expected the answer to be notified, got %v", received)
+	}
+	// the cursor is stored, the notifier carries on from it
+	cursor, err := storage.OpenCursor(filepath.Join(dir, "notifier_cursor"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor.Last() != n.outbox.Last() {
+		t.Errorf("expected the cursor to be stored at %d, got %d", n.outbox.Last(), cursor.Last())
+	}
+}
+
+func TestRequestEventsSkipNilAnswers(t *testing.T) {
+	e := &webhookspb.Event{
+		Type:     storage.HelpCancelled,
+		EntityId: "a",
+		Payload:  `{"requesterId":"Brown","state":"CANCELLED","answers":[{"volunteerId":"Blue"},{"volunteerId":"Blue"},{"volunteerId":"Green"}]}`,
+	}
+	events, err := requestEvents(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || strings.Join(events[0].recipients, ",") != "Blue,Green" {
+		t.Errorf("unexpected events %v", events)
+	}
+	request := &requestspb.Request{Answers: []*requestspb.Request_Answer{nil, {VolunteerId: "Blue"}, nil}}
+	if recipients := cancelledRecipients(request); strings.Join(recipients, ",") != "Blue" {
+		t.Errorf("expected the nil answers to be skipped, got %v", recipients)
+	}
+}
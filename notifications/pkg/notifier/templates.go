@@ -0,0 +1,94 @@
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+)
+
+// Each template defines a "subject" and a "body".
+var defaultTemplates = map[notificationspb.Event]string{ // nolint: gochecknoglobals
+	notificationspb.Event_ANSWERED: `{{define "subject"}}Someone offered to help: {{.Request.Title}}{{end}}` +
+		`{{define "body"}}Hi {{.User.Name}},
+
+a volunteer answered your request "{{.Request.Title}}":
+
+{{.Answer.Comment}}
+
+You can accept their help from the app.{{end}}`,
+	notificationspb.Event_ACCEPTED: `{{define "subject"}}Your help was accepted: {{.Request.Title}}{{end}}` +
+		`{{define "body"}}Hi {{.User.Name}},
+
+your offer to help with "{{.Request.Title}}" was accepted. Thank you!{{end}}`,
+	notificationspb.Event_COMPLETED: `{{define "subject"}}Request completed: {{.Request.Title}}{{end}}` +
+		`{{define "body"}}Hi {{.User.Name}},
+
+the request "{{.Request.Title}}" was marked as completed. Don't forget to rate it!{{end}}`,
+	notificationspb.Event_CANCELLED: `{{define "subject"}}Request cancelled: {{.Request.Title}}{{end}}` +
+		`{{define "body"}}Hi {{.User.Name}},
+
+the request "{{.Request.Title}}" was cancelled, no need to help with it anymore.{{end}}`,
+}
+
+type templateData struct {
+	RequestID string
+	Request   *requestspb.Request
+	Answer    *requestspb.Request_Answer
+	User      *userspb.User
+}
+
+type Templates struct {
+	templates map[notificationspb.Event]*template.Template
+}
+
+// LoadTemplates parses the default templates, overridden by the files named after the events
+// (e.g. answered.tmpl) found in dir if it isn't empty.
+func LoadTemplates(dir string) (*Templates, error) {
+	t := &Templates{
+		templates: make(map[notificationspb.Event]*template.Template),
+	}
+	for e, text := range defaultTemplates {
+		name := strings.ToLower(e.String())
+		if dir != "" {
+			b, err := ioutil.ReadFile(filepath.Join(dir, name+".tmpl"))
+			switch {
+			case err == nil:
+				text = string(b)
+			case !os.IsNotExist(err):
+				return nil, fmt.Errorf("problem reading template: %w", err)
+			}
+		}
+		tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("problem parsing %s template: %w", name, err)
+		}
+		if tmpl.Lookup("subject") == nil || tmpl.Lookup("body") == nil {
+			return nil, fmt.Errorf("%s template must define a subject and a body", name)
+		}
+		t.templates[e] = tmpl
+	}
+	return t, nil
+}
+
+func (t *Templates) render(e notificationspb.Event, data *templateData) (string, string, error) {
+	tmpl, ok := t.templates[e]
+	if !ok {
+		return "", "", fmt.Errorf("no template for %s", e)
+	}
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", fmt.Errorf("problem rendering subject: %w", err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", fmt.Errorf("problem rendering body: %w", err)
+	}
+	return subject.String(), body.String(), nil
+}
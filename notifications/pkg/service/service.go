@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"github.com/euvsvirus-banan/backend/internal/egress"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/version"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Service struct {
+	logger      *logrus.Entry
+	preferences *storage.PreferencesStorage
+	deadLetters *storage.DeadLettersStorage
+	guard       *egress.Guard
+}
+
+func New(logger *logrus.Entry, preferenceData *storage.PreferencesStorage, deadLetterData *storage.DeadLettersStorage, guard *egress.Guard) *Service {
+	return &Service{
+		logger:      logger,
+		preferences: preferenceData,
+		deadLetters: deadLetterData,
+		guard:       guard,
+	}
+}
+
+func (svc *Service) GetVersion(ctx context.Context, req *notificationspb.GetVersionRequest) (*notificationspb.GetVersionResponse, error) {
+	return &notificationspb.GetVersionResponse{
+		Project:     version.Project,
+		Version:     version.Version,
+		BuildDate:   version.BuildDate,
+		GitRevision: version.GitRevision,
+		GoVersion:   version.GoVersion,
+	}, nil
+}
+
+func (svc *Service) GetPreferences(ctx context.Context, req *notificationspb.GetPreferencesRequest) (*notificationspb.GetPreferencesResponse, error) {
+	prefs, err := svc.preferences.Get(req.UserId)
+	if err != nil {
+		prefs = &notificationspb.Preferences{}
+	}
+	return &notificationspb.GetPreferencesResponse{
+		Preferences: prefs,
+	}, nil
+}
+
+func (svc *Service) UpdatePreferences(ctx context.Context, req *notificationspb.UpdatePreferencesRequest) (*notificationspb.UpdatePreferencesResponse, error) {
+	if req.Preferences == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing preferences")
+	}
+	if req.Preferences.WebhookUrl != "" {
+		if err := svc.guard.CheckURL(ctx, req.Preferences.WebhookUrl); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid webhook url: %s", err)
+		}
+	}
+	if err := svc.preferences.Set(ctx, req.UserId, req.Preferences); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &notificationspb.UpdatePreferencesResponse{
+		Preferences: req.Preferences,
+	}, nil
+}
+
+func (svc *Service) GetDeadLetters(req *notificationspb.GetDeadLettersRequest, stream notificationspb.NotificationsRPC_GetDeadLettersServer) error {
+	for id, deadLetter := range svc.deadLetters.All() {
+		if err := stream.Send(
+			&notificationspb.GetDeadLettersResponse{
+				DeadLetterId: id,
+				DeadLetter:   deadLetter,
+			},
+		); err != nil {
+			return status.Error(codes.Unknown, err.Error())
+		}
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/euvsvirus-banan/backend/internal/storage"
 	"github.com/euvsvirus-banan/backend/internal/version"
@@ -15,12 +17,14 @@ import (
 type Service struct {
 	logger   *logrus.Entry
 	requests *storage.RequestsStorage
+	users    *storage.UsersStorage
 }
 
-func New(logger *logrus.Entry, requestData *storage.RequestsStorage) *Service {
+func New(logger *logrus.Entry, requestData *storage.RequestsStorage, userData *storage.UsersStorage) *Service {
 	return &Service{
 		logger:   logger,
 		requests: requestData,
+		users:    userData,
 	}
 }
 
@@ -35,8 +39,15 @@ func (svc *Service) GetVersion(ctx context.Context, req *requestspb.GetVersionRe
 }
 
 func (svc *Service) AddRequest(ctx context.Context, req *requestspb.AddRequestRequest) (*requestspb.AddRequestResponse, error) {
+	if req.Request == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing request")
+	}
 	id := uuid.New().String()
-	if err := svc.requests.Add(id, req.Request); err != nil {
+	// ratings are only added through RateHelp
+	req.Request.Ratings = nil
+	req.Request.CreationDate = time.Now().UTC().Format(time.RFC3339)
+	req.Request.StateDate = req.Request.CreationDate
+	if err := svc.requests.Add(ctx, id, req.Request); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &requestspb.AddRequestResponse{
@@ -45,14 +56,22 @@ func (svc *Service) AddRequest(ctx context.Context, req *requestspb.AddRequestRe
 }
 
 func (svc *Service) DeleteRequest(ctx context.Context, req *requestspb.DeleteRequestRequest) (*requestspb.DeleteRequestResponse, error) {
-	if err := svc.requests.Delete(req.RequestId); err != nil {
+	if err := svc.requests.Delete(ctx, req.RequestId); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &requestspb.DeleteRequestResponse{}, nil
 }
 
 func (svc *Service) UpdateRequest(ctx context.Context, req *requestspb.UpdateRequestRequest) (*requestspb.UpdateRequestResponse, error) {
-	if err := svc.requests.Update(req.RequestId, req.Request); err != nil {
+	if req.Request == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing request")
+	}
+	// the ratings, only added through RateHelp, and the dates are kept by the storage
+	err := svc.requests.Update(ctx, req.RequestId, req.Request)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "request not found")
+	}
+	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	u, err := svc.requests.Get(req.RequestId)
@@ -104,6 +123,9 @@ func (svc *Service) SearchRequestsByPostcode(req *requestspb.SearchRequestsByPos
 }
 
 func (svc *Service) AnswerRequest(ctx context.Context, req *requestspb.AnswerRequestRequest) (*requestspb.AnswerRequestResponse, error) {
+	if req.Answer == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing answer")
+	}
 	request, err := svc.requests.Get(req.RequestId)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, "request not found")
@@ -114,7 +136,7 @@ func (svc *Service) AnswerRequest(ctx context.Context, req *requestspb.AnswerReq
 	}
 
 	request.Answers = append(request.Answers, req.Answer)
-	if err := svc.requests.Update(req.RequestId, request); err != nil {
+	if err := svc.requests.Update(ctx, req.RequestId, request); err != nil {
 		return nil, status.Error(codes.Internal, "problem saving data")
 	}
 	return &requestspb.AnswerRequestResponse{}, nil
@@ -144,7 +166,7 @@ func (svc *Service) AcceptHelp(ctx context.Context, req *requestspb.AcceptHelpRe
 	request.State = requestspb.Request_ACCEPTED
 	request.VolunteerId = req.VolunteerId
 
-	if err := svc.requests.Update(req.RequestId, request); err != nil {
+	if err := svc.requests.Update(ctx, req.RequestId, request); err != nil {
 		return nil, status.Error(codes.Internal, "problem saving data")
 	}
 	return &requestspb.AcceptHelpResponse{}, nil
@@ -162,7 +184,7 @@ func (svc *Service) CompleteHelp(ctx context.Context, req *requestspb.CompleteHe
 
 	request.State = requestspb.Request_COMPLETED
 
-	if err := svc.requests.Update(req.RequestId, request); err != nil {
+	if err := svc.requests.Update(ctx, req.RequestId, request); err != nil {
 		return nil, status.Error(codes.Internal, "problem saving data")
 	}
 	return &requestspb.CompleteHelpResponse{}, nil
@@ -180,8 +202,133 @@ func (svc *Service) CancelHelp(ctx context.Context, req *requestspb.CancelHelpRe
 
 	request.State = requestspb.Request_CANCELLED
 
-	if err := svc.requests.Update(req.RequestId, request); err != nil {
+	if err := svc.requests.Update(ctx, req.RequestId, request); err != nil {
 		return nil, status.Error(codes.Internal, "problem saving data")
 	}
 	return &requestspb.CancelHelpResponse{}, nil
 }
+
+func (svc *Service) RateHelp(ctx context.Context, req *requestspb.RateHelpRequest) (*requestspb.RateHelpResponse, error) {
+	if req.Score < 1 || req.Score > 5 {
+		return nil, status.Error(codes.InvalidArgument, "score must be between 1 and 5")
+	}
+
+	request, err := svc.requests.Get(req.RequestId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "request not found")
+	}
+
+	if request.State != requestspb.Request_COMPLETED {
+		return nil, status.Error(codes.FailedPrecondition, "only completed requests can be rated")
+	}
+
+	var rateeID string
+	switch req.RaterId {
+	case request.RequesterId:
+		rateeID = request.VolunteerId
+	case request.VolunteerId:
+		rateeID = request.RequesterId
+	default:
+		return nil, status.Error(codes.PermissionDenied, "only the requester and the volunteer can rate a request")
+	}
+
+	if _, err := svc.users.Get(rateeID); err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	rating := &requestspb.Request_Rating{
+		RaterId:      req.RaterId,
+		RateeId:      rateeID,
+		Score:        req.Score,
+		Comment:      req.Comment,
+		CreationDate: time.Now().UTC().Format(time.RFC3339),
+	}
+	err = storage.Rate(ctx, svc.users, svc.requests, req.RequestId, rating)
+	switch {
+	case errors.Is(err, storage.ErrDuplicate):
+		return nil, status.Error(codes.AlreadyExists, "request already rated")
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "request or user not found")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "problem saving data")
+	}
+	return &requestspb.RateHelpResponse{}, nil
+}
+
+var watchEvents = map[storage.EventType]requestspb.WatchRequestsResponse_Event{ // nolint: gochecknoglobals
+	storage.EventCreated:      requestspb.WatchRequestsResponse_CREATED,
+	storage.EventUpdated:      requestspb.WatchRequestsResponse_UPDATED,
+	storage.EventStateChanged: requestspb.WatchRequestsResponse_STATE_CHANGED,
+	storage.EventDeleted:      requestspb.WatchRequestsResponse_DELETED,
+}
+
+func (svc *Service) WatchRequests(req *requestspb.WatchRequestsRequest, stream requestspb.RequestsRPC_WatchRequestsServer) error {
+	sub, err := svc.requests.Watch(req.ResumeToken)
+	switch {
+	case errors.Is(err, storage.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, storage.ErrResumeTokenExpired):
+		return status.Error(codes.OutOfRange, "resume token expired: the changes after it aren't kept anymore or the service restarted since, get the requests again and watch without a token")
+	case err != nil:
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-sub.Events():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "client too slow, resume from the last token received")
+			}
+			request := e.Value.(*requestspb.Request)
+			event := watchEvents[e.Type]
+			if !matchesWatch(req, request) {
+				// the clients drop the requests leaving the filter, the others were never sent
+				previous, ok := e.Previous.(*requestspb.Request)
+				if !ok || e.Type == storage.EventDeleted || !matchesWatch(req, previous) {
+					continue
+				}
+				event = requestspb.WatchRequestsResponse_REMOVED
+			}
+			if err := stream.Send(
+				&requestspb.WatchRequestsResponse{
+					Event:       event,
+					RequestId:   e.ID,
+					Request:     request,
+					ResumeToken: e.Token,
+				},
+			); err != nil {
+				return status.Error(codes.Unknown, err.Error())
+			}
+		}
+	}
+}
+
+func matchesWatch(req *requestspb.WatchRequestsRequest, request *requestspb.Request) bool {
+	if len(req.Postcodes) > 0 && !contains(req.Postcodes, request.Postcode) {
+		return false
+	}
+	if len(req.Categories) > 0 && !contains(req.Categories, request.Category) {
+		return false
+	}
+	if len(req.Skills) > 0 {
+		for _, skill := range request.Skills {
+			if contains(req.Skills, skill) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -4,29 +4,20 @@ import (
 	"context"
 	"io"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
 	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-type ws struct {
-	b strings.Builder
-}
-
-func (w *ws) Seek(offset int64, whence int) (int64, error) {
-	w.b = strings.Builder{}
-	return 0, nil
-}
-
-func (w *ws) Write(p []byte) (n int, err error) {
-	return w.b.Write(p)
-}
-
 func getTestService(w io.WriteSeeker, logger *logrus.Entry) *Service {
 	return &Service{
 		logger: logger,
@@ -86,6 +77,29 @@ func getTestService(w io.WriteSeeker, logger *logrus.Entry) *Service {
 							Comment:     "I love dogs, I have hundreds of 'em!!!",
 						},
 					},
+					Ratings: []*requestspb.Request_Rating{
+						{
+							RaterId: "Blue",
+							RateeId: "Brown",
+							Score:   5,
+						},
+					},
+				},
+			},
+		),
+		users: storage.NewUsersStorage(
+			w,
+			map[string]*userspb.User{
+				"Brown": {
+					Name: "Brown",
+					Reputation: &userspb.User_Reputation{
+						Score:   5,
+						Ratings: 1,
+						Total:   5,
+					},
+				},
+				"Blue": {
+					Name: "Blue",
 				},
 			},
 		),
@@ -134,12 +148,19 @@ func TestAnswerRequest(t *testing.T) {
 			},
 			err: status.Error(codes.NotFound, "request not found"),
 		},
+		{
+			name: "missing answer",
+			req: &requestspb.AnswerRequestRequest{
+				RequestId: "a",
+			},
+			err: status.Error(codes.InvalidArgument, "missing answer"),
+		},
 	}
 
 	for _, tc := range cases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			w := &ws{}
+			w := &storagetest.File{}
 			logger := logrus.NewEntry(logrus.New())
 			svc := getTestService(w, logger)
 			_, err := svc.AnswerRequest(context.Background(), tc.req)
@@ -190,7 +211,7 @@ func TestAcceptHelp(t *testing.T) {
 	for _, tc := range cases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			w := &ws{}
+			w := &storagetest.File{}
 			logger := logrus.NewEntry(logrus.New())
 			svc := getTestService(w, logger)
 			_, err := svc.AcceptHelp(context.Background(), tc.req)
@@ -225,7 +246,7 @@ func TestCompleteHelp(t *testing.T) {
 	for _, tc := range cases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			w := &ws{}
+			w := &storagetest.File{}
 			logger := logrus.NewEntry(logrus.New())
 			svc := getTestService(w, logger)
 			_, err := svc.CompleteHelp(context.Background(), tc.req)
@@ -260,7 +281,7 @@ func TestCancelHelp(t *testing.T) {
 	for _, tc := range cases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			w := &ws{}
+			w := &storagetest.File{}
 			logger := logrus.NewEntry(logrus.New())
 			svc := getTestService(w, logger)
 			_, err := svc.CancelHelp(context.Background(), tc.req)
@@ -270,3 +291,330 @@ func TestCancelHelp(t *testing.T) {
 		})
 	}
 }
+
+func TestRateHelp(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *requestspb.RateHelpRequest
+		err  error
+	}{
+		{
+			name: "requester rates volunteer",
+			req: &requestspb.RateHelpRequest{
+				RequestId: "e",
+				RaterId:   "Brown",
+				Score:     4,
+				Comment:   "the dog came back happy",
+			},
+		},
+		{
+			name: "already rated",
+			req: &requestspb.RateHelpRequest{
+				RequestId: "e",
+				RaterId:   "Blue",
+				Score:     5,
+			},
+			err: status.Error(codes.AlreadyExists, "request already rated"),
+		},
+		{
+			name: "not completed",
+			req: &requestspb.RateHelpRequest{
+				RequestId: "c",
+				RaterId:   "Brown",
+				Score:     5,
+			},
+			err: status.Error(codes.FailedPrecondition, "only completed requests can be rated"),
+		},
+		{
+			name: "not a party",
+			req: &requestspb.RateHelpRequest{
+				RequestId: "e",
+				RaterId:   "Green",
+				Score:     5,
+			},
+			err: status.Error(codes.PermissionDenied, "only the requester and the volunteer can rate a request"),
+		},
+		{
+			name: "score out of range",
+			req: &requestspb.RateHelpRequest{
+				RequestId: "e",
+				RaterId:   "Brown",
+				Score:     6,
+			},
+			err: status.Error(codes.InvalidArgument, "score must be between 1 and 5"),
+		},
+		{
+			name: "not found",
+			req: &requestspb.RateHelpRequest{
+				RequestId: "asdasdasd",
+				RaterId:   "Brown",
+				Score:     5,
+			},
+			err: status.Error(codes.NotFound, "request not found"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			w := &storagetest.File{}
+			logger := logrus.NewEntry(logrus.New())
+			svc := getTestService(w, logger)
+			_, err := svc.RateHelp(context.Background(), tc.req)
+			if !cmp.Equal(tc.err, err) {
+				t.Error(cmp.Diff(tc.err, err))
+			}
+		})
+	}
+}
+
+func TestRateHelpReputation(t *testing.T) {
+	w := &storagetest.File{}
+	logger := logrus.NewEntry(logrus.New())
+	svc := getTestService(w, logger)
+
+	if _, err := svc.RateHelp(context.Background(), &requestspb.RateHelpRequest{
+		RequestId: "e",
+		RaterId:   "Brown",
+		Score:     3,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.RateHelp(context.Background(), &requestspb.RateHelpRequest{
+		RequestId: "e",
+		RaterId:   "Brown",
+		Score:     3,
+	}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected second rating to be rejected, got %v", err)
+	}
+
+	u, err := svc.users.Get("Blue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &userspb.User_Reputation{
+		Score:   3,
+		Ratings: 1,
+		Total:   3,
+	}
+	if !cmp.Equal(expected, u.Reputation) {
+		t.Error(cmp.Diff(expected, u.Reputation))
+	}
+}
+
+func TestRateHelpConcurrently(t *testing.T) {
+	w := &storagetest.File{}
+	logger := logrus.NewEntry(logrus.New())
+	svc := getTestService(w, logger)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.RateHelp(context.Background(), &requestspb.RateHelpRequest{
+				RequestId: "e",
+				RaterId:   "Brown",
+				Score:     4,
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	rated := 0
+	for err := range errs {
+		switch status.Code(err) {
+		case codes.OK:
+			rated++
+		case codes.AlreadyExists:
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	r, err := svc.requests.Get("e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rated != 1 || len(r.Ratings) != 2 {
+		t.Errorf("expected a single rating by Brown, got %d calls rating and %d ratings", rated, len(r.Ratings))
+	}
+}
+
+func TestUpdateRequestRatings(t *testing.T) {
+	w := &storagetest.File{}
+	logger := logrus.NewEntry(logrus.New())
+	svc := getTestService(w, logger)
+
+	forged := []*requestspb.Request_Rating{{RaterId: "Brown", RateeId: "Blue", Score: 5}}
+	resp, err := svc.UpdateRequest(context.Background(), &requestspb.UpdateRequestRequest{
+		RequestId: "e",
+		Request:   &requestspb.Request{Title: "help with walking the cat", RequesterId: "Brown", VolunteerId: "Blue", State: requestspb.Request_COMPLETED, Ratings: forged},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Request.Ratings) != 1 || resp.Request.Ratings[0].RaterId != "Blue" {
+		t.Errorf("expected the ratings to be kept, got %v", resp.Request.Ratings)
+	}
+
+	added, err := svc.AddRequest(context.Background(), &requestspb.AddRequestRequest{
+		Request: &requestspb.Request{Title: "help with groceries", RequesterId: "Brown", Ratings: forged},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := svc.requests.Get(added.RequestId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Ratings) != 0 {
+		t.Errorf("expected the ratings to be dropped, got %v", r.Ratings)
+	}
+
+	_, err = svc.UpdateRequest(context.Background(), &requestspb.UpdateRequestRequest{RequestId: "missing", Request: &requestspb.Request{}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestRequestDates(t *testing.T) {
+	w := &storagetest.File{}
+	logger := logrus.NewEntry(logrus.New())
+	svc := getTestService(w, logger)
+
+	added, err := svc.AddRequest(context.Background(), &requestspb.AddRequestRequest{
+		Request: &requestspb.Request{Title: "help with groceries", RequesterId: "Brown", CreationDate: "2000-01-01T00:00:00Z", StateDate: "2000-01-01T00:00:00Z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := svc.requests.Get(added.RequestId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := r.CreationDate
+	if created == "2000-01-01T00:00:00Z" || r.StateDate != created {
+		t.Errorf("expected the dates to be set by the service, got %s and %s", created, r.StateDate)
+	}
+
+	// the state date only changes with the state
+	r.CreationDate, r.StateDate, r.Title = "2000-01-01T00:00:00Z", "2000-01-01T00:00:00Z", "help with the groceries"
+	resp, err := svc.UpdateRequest(context.Background(), &requestspb.UpdateRequestRequest{RequestId: added.RequestId, Request: r})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Request.CreationDate != created || resp.Request.StateDate != created {
+		t.Errorf("expected the dates to be kept, got %s and %s", resp.Request.CreationDate, resp.Request.StateDate)
+	}
+	r = resp.Request
+	r.State, r.StateDate = requestspb.Request_CANCELLED, ""
+	resp, err = svc.UpdateRequest(context.Background(), &requestspb.UpdateRequestRequest{RequestId: added.RequestId, Request: r})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Request.StateDate == "" || resp.Request.CreationDate != created {
+		t.Errorf("expected the state date to be set, got %s and %s", resp.Request.CreationDate, resp.Request.StateDate)
+	}
+}
+
+type watchStream struct {
+	requestspb.RequestsRPC_WatchRequestsServer
+	ctx       context.Context
+	responses chan *requestspb.WatchRequestsResponse
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(resp *requestspb.WatchRequestsResponse) error {
+	s.responses <- resp
+	return nil
+}
+
+func TestWatchRequests(t *testing.T) {
+	w := &storagetest.File{}
+	logger := logrus.NewEntry(logrus.New())
+	svc := getTestService(w, logger)
+
+	// resuming from a known token makes the stream replay changes made before it subscribes
+	sub, err := svc.requests.Watch("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AddRequest(context.Background(), &requestspb.AddRequestRequest{
+		Request: &requestspb.Request{Title: "elsewhere", Postcode: "99999"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	token := (<-sub.Events()).Token
+	sub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchStream{
+		ctx:       ctx,
+		responses: make(chan *requestspb.WatchRequestsResponse, 10),
+	}
+	done := make(chan error)
+	go func() {
+		done <- svc.WatchRequests(&requestspb.WatchRequestsRequest{
+			Postcodes:   []string{"12345"},
+			ResumeToken: token,
+		}, stream)
+	}()
+
+	if _, err := svc.AddRequest(context.Background(), &requestspb.AddRequestRequest{
+		Request: &requestspb.Request{Title: "elsewhere", Postcode: "99999"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	added, err := svc.AddRequest(context.Background(), &requestspb.AddRequestRequest{
+		Request: &requestspb.Request{Title: "shopping", Postcode: "12345"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := <-stream.responses
+	if resp.Event != requestspb.WatchRequestsResponse_CREATED || resp.Request.Title != "shopping" {
+		t.Errorf("unexpected event: %v", resp)
+	}
+	if resp.ResumeToken == "" {
+		t.Error("expected resume token")
+	}
+
+	// the request leaving the filter is removed, its changes afterwards aren't sent
+	for _, title := range []string{"shopping", "shopping again"} {
+		if _, err := svc.UpdateRequest(context.Background(), &requestspb.UpdateRequestRequest{
+			RequestId: added.RequestId,
+			Request:   &requestspb.Request{Title: title, Postcode: "99999"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.requests.Delete(context.Background(), added.RequestId); err != nil {
+		t.Fatal(err)
+	}
+	resp = <-stream.responses
+	if resp.Event != requestspb.WatchRequestsResponse_REMOVED || resp.RequestId != added.RequestId {
+		t.Errorf("expected the request to be removed, got %v", resp)
+	}
+	select {
+	case resp := <-stream.responses:
+		t.Errorf("unexpected event: %v", resp)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+
+	// the tokens of another run of the service aren't known
+	err = svc.WatchRequests(&requestspb.WatchRequestsRequest{ResumeToken: "abc-1"}, stream)
+	if status.Code(err) != codes.OutOfRange || !strings.Contains(status.Convert(err).Message(), "watch without a token") {
+		t.Errorf("expected the token to be out of range, got %v", err)
+	}
+}
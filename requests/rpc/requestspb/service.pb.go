@@ -57,19 +57,59 @@ func (Request_State) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_7372dc30ae398822, []int{0, 0}
 }
 
+type WatchRequestsResponse_Event int32
+
+const (
+	WatchRequestsResponse_CREATED       WatchRequestsResponse_Event = 0
+	WatchRequestsResponse_UPDATED       WatchRequestsResponse_Event = 1
+	WatchRequestsResponse_STATE_CHANGED WatchRequestsResponse_Event = 2
+	WatchRequestsResponse_DELETED       WatchRequestsResponse_Event = 3
+	// The request changed and doesn't match the filter anymore, it's still stored
+	WatchRequestsResponse_REMOVED WatchRequestsResponse_Event = 4
+)
+
+var WatchRequestsResponse_Event_name = map[int32]string{
+	0: "CREATED",
+	1: "UPDATED",
+	2: "STATE_CHANGED",
+	3: "DELETED",
+	4: "REMOVED",
+}
+
+var WatchRequestsResponse_Event_value = map[string]int32{
+	"CREATED":       0,
+	"UPDATED":       1,
+	"STATE_CHANGED": 2,
+	"DELETED":       3,
+	"REMOVED":       4,
+}
+
+func (x WatchRequestsResponse_Event) String() string {
+	return proto.EnumName(WatchRequestsResponse_Event_name, int32(x))
+}
+
+func (WatchRequestsResponse_Event) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7372dc30ae398822, []int{26, 0}
+}
+
 type Request struct {
-	Title                string            `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Body                 string            `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
-	RequesterId          string            `protobuf:"bytes,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
-	VolunteerId          string            `protobuf:"bytes,4,opt,name=volunteer_id,json=volunteerId,proto3" json:"volunteer_id,omitempty"`
-	Postcode             string            `protobuf:"bytes,5,opt,name=postcode,proto3" json:"postcode,omitempty"`
-	CreationDate         string            `protobuf:"bytes,6,opt,name=creation_date,json=creationDate,proto3" json:"creation_date,omitempty"`
-	State                Request_State     `protobuf:"varint,7,opt,name=state,proto3,enum=requestspb.Request_State" json:"state,omitempty"`
-	Skills               []string          `protobuf:"bytes,8,rep,name=skills,proto3" json:"skills,omitempty"`
-	Answers              []*Request_Answer `protobuf:"bytes,9,rep,name=answers,proto3" json:"answers,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Title       string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Body        string `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	RequesterId string `protobuf:"bytes,3,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	VolunteerId string `protobuf:"bytes,4,opt,name=volunteer_id,json=volunteerId,proto3" json:"volunteer_id,omitempty"`
+	Postcode    string `protobuf:"bytes,5,opt,name=postcode,proto3" json:"postcode,omitempty"`
+	// Date the request was added, set by the service
+	CreationDate string            `protobuf:"bytes,6,opt,name=creation_date,json=creationDate,proto3" json:"creation_date,omitempty"`
+	State        Request_State     `protobuf:"varint,7,opt,name=state,proto3,enum=requestspb.Request_State" json:"state,omitempty"`
+	Skills       []string          `protobuf:"bytes,8,rep,name=skills,proto3" json:"skills,omitempty"`
+	Answers      []*Request_Answer `protobuf:"bytes,9,rep,name=answers,proto3" json:"answers,omitempty"`
+	Ratings      []*Request_Rating `protobuf:"bytes,10,rep,name=ratings,proto3" json:"ratings,omitempty"`
+	Category     string            `protobuf:"bytes,11,opt,name=category,proto3" json:"category,omitempty"`
+	// Date the request entered its state, set by the service
+	StateDate            string   `protobuf:"bytes,12,opt,name=state_date,json=stateDate,proto3" json:"state_date,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Request) Reset()         { *m = Request{} }
@@ -168,6 +208,27 @@ func (m *Request) GetAnswers() []*Request_Answer {
 	return nil
 }
 
+func (m *Request) GetRatings() []*Request_Rating {
+	if m != nil {
+		return m.Ratings
+	}
+	return nil
+}
+
+func (m *Request) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *Request) GetStateDate() string {
+	if m != nil {
+		return m.StateDate
+	}
+	return ""
+}
+
 type Request_Answer struct {
 	VolunteerId          string   `protobuf:"bytes,1,opt,name=volunteer_id,json=volunteerId,proto3" json:"volunteer_id,omitempty"`
 	Comment              string   `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
@@ -223,6 +284,85 @@ func (m *Request_Answer) GetComment() string {
 	return ""
 }
 
+type Request_Rating struct {
+	RaterId              string   `protobuf:"bytes,1,opt,name=rater_id,json=raterId,proto3" json:"rater_id,omitempty"`
+	RateeId              string   `protobuf:"bytes,2,opt,name=ratee_id,json=rateeId,proto3" json:"ratee_id,omitempty"`
+	Score                int32    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Comment              string   `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreationDate         string   `protobuf:"bytes,5,opt,name=creation_date,json=creationDate,proto3" json:"creation_date,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Request_Rating) Reset()         { *m = Request_Rating{} }
+func (m *Request_Rating) String() string { return proto.CompactTextString(m) }
+func (*Request_Rating) ProtoMessage()    {}
+func (*Request_Rating) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7372dc30ae398822, []int{0, 1}
+}
+func (m *Request_Rating) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Request_Rating) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Request_Rating.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Request_Rating) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Request_Rating.Merge(m, src)
+}
+func (m *Request_Rating) XXX_Size() int {
+	return m.Size()
+}
+func (m *Request_Rating) XXX_DiscardUnknown() {
+	xxx_messageInfo_Request_Rating.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Request_Rating proto.InternalMessageInfo
+
+func (m *Request_Rating) GetRaterId() string {
+	if m != nil {
+		return m.RaterId
+	}
+	return ""
+}
+
+func (m *Request_Rating) GetRateeId() string {
+	if m != nil {
+		return m.RateeId
+	}
+	return ""
+}
+
+func (m *Request_Rating) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Request_Rating) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *Request_Rating) GetCreationDate() string {
+	if m != nil {
+		return m.CreationDate
+	}
+	return ""
+}
+
 type GetVersionRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1273,10 +1413,264 @@ func (m *CancelHelpResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CancelHelpResponse proto.InternalMessageInfo
 
+type RateHelpRequest struct {
+	RequestId            string   `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	RaterId              string   `protobuf:"bytes,2,opt,name=rater_id,json=raterId,proto3" json:"rater_id,omitempty"`
+	Score                int32    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Comment              string   `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateHelpRequest) Reset()         { *m = RateHelpRequest{} }
+func (m *RateHelpRequest) String() string { return proto.CompactTextString(m) }
+func (*RateHelpRequest) ProtoMessage()    {}
+func (*RateHelpRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7372dc30ae398822, []int{23}
+}
+func (m *RateHelpRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateHelpRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RateHelpRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RateHelpRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateHelpRequest.Merge(m, src)
+}
+func (m *RateHelpRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateHelpRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateHelpRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateHelpRequest proto.InternalMessageInfo
+
+func (m *RateHelpRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *RateHelpRequest) GetRaterId() string {
+	if m != nil {
+		return m.RaterId
+	}
+	return ""
+}
+
+func (m *RateHelpRequest) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *RateHelpRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type RateHelpResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateHelpResponse) Reset()         { *m = RateHelpResponse{} }
+func (m *RateHelpResponse) String() string { return proto.CompactTextString(m) }
+func (*RateHelpResponse) ProtoMessage()    {}
+func (*RateHelpResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7372dc30ae398822, []int{24}
+}
+func (m *RateHelpResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateHelpResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RateHelpResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RateHelpResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateHelpResponse.Merge(m, src)
+}
+func (m *RateHelpResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateHelpResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateHelpResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateHelpResponse proto.InternalMessageInfo
+
+type WatchRequestsRequest struct {
+	Postcodes            []string `protobuf:"bytes,1,rep,name=postcodes,proto3" json:"postcodes,omitempty"`
+	Skills               []string `protobuf:"bytes,2,rep,name=skills,proto3" json:"skills,omitempty"`
+	Categories           []string `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	ResumeToken          string   `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequestsRequest) Reset()         { *m = WatchRequestsRequest{} }
+func (m *WatchRequestsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequestsRequest) ProtoMessage()    {}
+func (*WatchRequestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7372dc30ae398822, []int{25}
+}
+func (m *WatchRequestsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchRequestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchRequestsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchRequestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequestsRequest.Merge(m, src)
+}
+func (m *WatchRequestsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchRequestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequestsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequestsRequest proto.InternalMessageInfo
+
+func (m *WatchRequestsRequest) GetPostcodes() []string {
+	if m != nil {
+		return m.Postcodes
+	}
+	return nil
+}
+
+func (m *WatchRequestsRequest) GetSkills() []string {
+	if m != nil {
+		return m.Skills
+	}
+	return nil
+}
+
+func (m *WatchRequestsRequest) GetCategories() []string {
+	if m != nil {
+		return m.Categories
+	}
+	return nil
+}
+
+func (m *WatchRequestsRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type WatchRequestsResponse struct {
+	Event                WatchRequestsResponse_Event `protobuf:"varint,1,opt,name=event,proto3,enum=requestspb.WatchRequestsResponse_Event" json:"event,omitempty"`
+	RequestId            string                      `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Request              *Request                    `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	ResumeToken          string                      `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *WatchRequestsResponse) Reset()         { *m = WatchRequestsResponse{} }
+func (m *WatchRequestsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchRequestsResponse) ProtoMessage()    {}
+func (*WatchRequestsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7372dc30ae398822, []int{26}
+}
+func (m *WatchRequestsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchRequestsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchRequestsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchRequestsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequestsResponse.Merge(m, src)
+}
+func (m *WatchRequestsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchRequestsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequestsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequestsResponse proto.InternalMessageInfo
+
+func (m *WatchRequestsResponse) GetEvent() WatchRequestsResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return WatchRequestsResponse_CREATED
+}
+
+func (m *WatchRequestsResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *WatchRequestsResponse) GetRequest() *Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *WatchRequestsResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("requestspb.Request_State", Request_State_name, Request_State_value)
+	proto.RegisterEnum("requestspb.WatchRequestsResponse_Event", WatchRequestsResponse_Event_name, WatchRequestsResponse_Event_value)
 	proto.RegisterType((*Request)(nil), "requestspb.Request")
 	proto.RegisterType((*Request_Answer)(nil), "requestspb.Request.Answer")
+	proto.RegisterType((*Request_Rating)(nil), "requestspb.Request.Rating")
 	proto.RegisterType((*GetVersionRequest)(nil), "requestspb.GetVersionRequest")
 	proto.RegisterType((*GetVersionResponse)(nil), "requestspb.GetVersionResponse")
 	proto.RegisterType((*AddRequestRequest)(nil), "requestspb.AddRequestRequest")
@@ -1299,6 +1693,10 @@ func init() {
 	proto.RegisterType((*CompleteHelpResponse)(nil), "requestspb.CompleteHelpResponse")
 	proto.RegisterType((*CancelHelpRequest)(nil), "requestspb.CancelHelpRequest")
 	proto.RegisterType((*CancelHelpResponse)(nil), "requestspb.CancelHelpResponse")
+	proto.RegisterType((*RateHelpRequest)(nil), "requestspb.RateHelpRequest")
+	proto.RegisterType((*RateHelpResponse)(nil), "requestspb.RateHelpResponse")
+	proto.RegisterType((*WatchRequestsRequest)(nil), "requestspb.WatchRequestsRequest")
+	proto.RegisterType((*WatchRequestsResponse)(nil), "requestspb.WatchRequestsResponse")
 }
 
 func init() {
@@ -1306,62 +1704,81 @@ func init() {
 }
 
 var fileDescriptor_7372dc30ae398822 = []byte{
-	// 869 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6e, 0xda, 0x58,
-	0x14, 0x8e, 0x21, 0x40, 0x38, 0x40, 0x04, 0x17, 0x92, 0xf1, 0x58, 0x0a, 0x01, 0x8f, 0x46, 0x42,
-	0x9a, 0x19, 0x18, 0x91, 0xcc, 0xec, 0x2a, 0x95, 0xbf, 0xa6, 0x28, 0x69, 0x4a, 0x09, 0x69, 0x96,
-	0x08, 0xec, 0x2b, 0xea, 0xd6, 0xc1, 0xae, 0x7d, 0x43, 0x94, 0x37, 0xe9, 0x2b, 0xf4, 0x15, 0xba,
-	0xec, 0xaa, 0xcb, 0x3e, 0x42, 0x95, 0xbe, 0x48, 0x65, 0xfb, 0x1a, 0xfb, 0xda, 0x26, 0x81, 0x4a,
-	0xdd, 0xf9, 0x9c, 0x7b, 0xce, 0x77, 0xfe, 0x3f, 0x19, 0xfe, 0x34, 0xf0, 0xfb, 0x1b, 0x6c, 0x12,
-	0xb3, 0x61, 0xe8, 0x52, 0xc3, 0x15, 0xf4, 0x69, 0xc3, 0xc4, 0xc6, 0x42, 0x91, 0x70, 0x5d, 0x37,
-	0x34, 0xa2, 0x21, 0xf0, 0x5e, 0xc4, 0xcf, 0x71, 0x48, 0x0d, 0x1d, 0x11, 0x95, 0x20, 0x41, 0x14,
-	0xa2, 0x62, 0x9e, 0xab, 0x70, 0xb5, 0xf4, 0xd0, 0x11, 0x10, 0x82, 0xed, 0xa9, 0x26, 0xdf, 0xf1,
-	0x31, 0x5b, 0x69, 0x7f, 0xa3, 0x2a, 0x64, 0x29, 0x06, 0x36, 0xc6, 0x8a, 0xcc, 0xc7, 0xed, 0xb7,
-	0xcc, 0x52, 0xd7, 0x97, 0x2d, 0x93, 0x85, 0xa6, 0xde, 0xcc, 0x09, 0x76, 0x4c, 0xb6, 0x1d, 0x93,
-	0xa5, 0xae, 0x2f, 0x23, 0x01, 0x76, 0x74, 0xcd, 0x24, 0x92, 0x26, 0x63, 0x3e, 0x61, 0x3f, 0x2f,
-	0x65, 0xf4, 0x07, 0xe4, 0x24, 0x03, 0x4f, 0x88, 0xa2, 0xcd, 0xc7, 0xf2, 0x84, 0x60, 0x3e, 0x69,
-	0x1b, 0x64, 0x5d, 0x65, 0x77, 0x42, 0x30, 0x6a, 0x40, 0xc2, 0x24, 0xd6, 0x63, 0xaa, 0xc2, 0xd5,
-	0x76, 0x9b, 0xbf, 0xd7, 0xbd, 0xc2, 0xea, 0xb4, 0xa8, 0xfa, 0x85, 0x65, 0x30, 0x74, 0xec, 0xd0,
-	0x3e, 0x24, 0xcd, 0x77, 0x8a, 0xaa, 0x9a, 0xfc, 0x4e, 0x25, 0x5e, 0x4b, 0x0f, 0xa9, 0x84, 0x8e,
-	0x21, 0x35, 0x99, 0x9b, 0xb7, 0xd8, 0x30, 0xf9, 0x74, 0x25, 0x5e, 0xcb, 0x34, 0x85, 0x28, 0xa8,
-	0x96, 0x6d, 0x32, 0x74, 0x4d, 0x85, 0x1e, 0x24, 0x1d, 0x55, 0xa8, 0x58, 0x2e, 0x5c, 0x2c, 0x0f,
-	0x29, 0x49, 0xbb, 0xbe, 0xc6, 0x73, 0x42, 0x3b, 0xe9, 0x8a, 0xe2, 0x53, 0x48, 0xd8, 0x49, 0xa2,
-	0x0c, 0xa4, 0xae, 0x5a, 0xfd, 0x51, 0xff, 0xfc, 0x24, 0xbf, 0x85, 0xb2, 0xb0, 0xd3, 0xea, 0x74,
-	0x7a, 0x83, 0x51, 0xaf, 0x9b, 0xe7, 0x50, 0x0e, 0xd2, 0x9d, 0x97, 0x2f, 0x06, 0x67, 0x3d, 0x4b,
-	0x8c, 0xd9, 0x62, 0xeb, 0xbc, 0xd3, 0x3b, 0x3b, 0xeb, 0x75, 0xf3, 0x71, 0xb1, 0x08, 0x85, 0x13,
-	0x4c, 0x5e, 0x63, 0xc3, 0x54, 0xb4, 0x39, 0xcd, 0x56, 0xfc, 0xc8, 0x01, 0xf2, 0x6b, 0x4d, 0x5d,
-	0x9b, 0x9b, 0xd8, 0xca, 0x43, 0x37, 0xb4, 0xb7, 0x58, 0x22, 0x34, 0x4b, 0x57, 0xb4, 0x5e, 0x16,
-	0x8e, 0xb1, 0x9b, 0x21, 0x15, 0xd1, 0x01, 0xc0, 0xf4, 0x46, 0x51, 0x65, 0x67, 0x12, 0xce, 0xb0,
-	0xd3, 0xb6, 0xc6, 0x1e, 0x43, 0x15, 0xb2, 0x33, 0x85, 0x8c, 0x0d, 0xbc, 0x50, 0x6c, 0x6f, 0x3a,
-	0xea, 0x99, 0x42, 0x86, 0x54, 0x65, 0x21, 0xcc, 0xb4, 0xb1, 0x0b, 0xef, 0x0c, 0x3b, 0x3d, 0xd3,
-	0x68, 0x72, 0x62, 0x1b, 0x0a, 0x2d, 0x59, 0xa6, 0x99, 0xbb, 0xeb, 0xf8, 0x0f, 0xa4, 0xe8, 0x10,
-	0xec, 0x4c, 0x33, 0xcd, 0x62, 0xc4, 0x50, 0x86, 0xae, 0x8d, 0x78, 0x04, 0xc8, 0x8f, 0x41, 0xcb,
-	0x3d, 0x00, 0x77, 0xdb, 0xbd, 0xb9, 0xa4, 0xa9, 0xa6, 0x2f, 0x8b, 0xff, 0x41, 0xa9, 0x8b, 0x55,
-	0x4c, 0x70, 0x20, 0xf6, 0x23, 0x6e, 0xbf, 0xc1, 0x5e, 0xc0, 0xcd, 0x09, 0x27, 0xca, 0x50, 0xba,
-	0xd4, 0xad, 0x2e, 0x6d, 0x84, 0xe7, 0x2f, 0x35, 0xb6, 0x46, 0xa9, 0xcf, 0x60, 0x2f, 0x10, 0x85,
-	0x56, 0xbb, 0x61, 0xcb, 0x4a, 0xf6, 0x86, 0x50, 0xb5, 0xe9, 0x2e, 0x8e, 0x04, 0x45, 0x46, 0xbb,
-	0x56, 0x27, 0x37, 0x2d, 0xe1, 0x7f, 0xd8, 0xf3, 0x82, 0xb4, 0xef, 0xfa, 0xdd, 0x35, 0x3b, 0x7f,
-	0x02, 0xfb, 0x41, 0xbf, 0x9f, 0xab, 0xfd, 0x09, 0x1c, 0x5e, 0xe0, 0x89, 0x21, 0xbd, 0x71, 0x0b,
-	0x6d, 0xdf, 0x0d, 0x28, 0xf9, 0xb8, 0xa9, 0xf8, 0xf9, 0x89, 0x63, 0xf9, 0x49, 0xd4, 0xa1, 0xb2,
-	0xda, 0xfd, 0x97, 0x74, 0x4c, 0x81, 0x12, 0x25, 0xa0, 0x8d, 0x56, 0xab, 0x09, 0x49, 0x87, 0xaf,
-	0x68, 0x90, 0x87, 0x98, 0x8d, 0x5a, 0x5a, 0xeb, 0x1d, 0x08, 0x45, 0xd7, 0xfb, 0x12, 0x0a, 0x2d,
-	0x49, 0xc2, 0x3a, 0x79, 0x8e, 0x55, 0x7d, 0xcd, 0x04, 0x82, 0xdc, 0x18, 0x0b, 0x71, 0xa3, 0xb5,
-	0x87, 0x7e, 0x58, 0x1a, 0xec, 0x18, 0x8a, 0x1d, 0xed, 0x5a, 0xb7, 0xce, 0x6c, 0xfd, 0x70, 0xe2,
-	0x3e, 0x94, 0x58, 0x2f, 0x8a, 0xd6, 0x84, 0x42, 0x67, 0x32, 0x97, 0xb0, 0xba, 0x01, 0x56, 0x09,
-	0x90, 0xdf, 0xc7, 0x41, 0x6a, 0x7e, 0x4a, 0x41, 0x66, 0x79, 0x1d, 0x83, 0x0e, 0x3a, 0x05, 0xf0,
-	0x78, 0x16, 0x1d, 0xf8, 0xfb, 0x1b, 0x62, 0x65, 0xa1, 0xbc, 0xea, 0x99, 0xee, 0xcc, 0x29, 0x80,
-	0xc7, 0x62, 0x2c, 0x58, 0x88, 0x21, 0x59, 0xb0, 0x08, 0xf2, 0x1b, 0x41, 0x8e, 0xa1, 0x29, 0x54,
-	0xf1, 0x3b, 0x44, 0x11, 0x9f, 0x50, 0x7d, 0xc0, 0xc2, 0x43, 0x65, 0xd8, 0x87, 0x45, 0x8d, 0xa2,
-	0x3f, 0x16, 0x35, 0x9a, 0xba, 0x06, 0x90, 0xf1, 0xb1, 0x0e, 0x0a, 0xf6, 0x29, 0x40, 0x52, 0xc2,
-	0xe1, 0xca, 0x77, 0x07, 0xef, 0x5f, 0x0e, 0x5d, 0xc1, 0x2e, 0x4b, 0x15, 0xa8, 0x1a, 0xed, 0xe4,
-	0xa3, 0x1f, 0x41, 0x7c, 0xc8, 0x84, 0xa6, 0x7a, 0x0b, 0xfc, 0xaa, 0xdb, 0x47, 0x7f, 0xf9, 0xfd,
-	0x1f, 0x21, 0x18, 0xe1, 0xef, 0xf5, 0x8c, 0x97, 0x15, 0x8d, 0x20, 0xc7, 0xdc, 0x25, 0xdb, 0xf9,
-	0x28, 0x76, 0x60, 0x3b, 0x1f, 0x79, 0xd4, 0xf6, 0xca, 0x2d, 0xaf, 0x2f, 0xb0, 0x72, 0xc1, 0x63,
-	0x0f, 0xac, 0x5c, 0xe8, 0x68, 0xd1, 0x2b, 0xc8, 0xfa, 0xcf, 0x0f, 0x31, 0x73, 0x8a, 0x38, 0x67,
-	0xa1, 0xb2, 0xda, 0xc0, 0xcb, 0xcf, 0xbb, 0x42, 0x36, 0xbf, 0xd0, 0x45, 0xb3, 0xf9, 0x85, 0x8f,
-	0xb7, 0x9d, 0xff, 0x72, 0x5f, 0xe6, 0xbe, 0xde, 0x97, 0xb9, 0x6f, 0xf7, 0x65, 0xee, 0xc3, 0xf7,
-	0xf2, 0xd6, 0x34, 0x69, 0xff, 0x14, 0x1f, 0xfd, 0x08, 0x00, 0x00, 0xff, 0xff, 0x7e, 0xce, 0x9d,
-	0x3d, 0x3d, 0x0b, 0x00, 0x00,
+	// 1170 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6e, 0xe3, 0x54,
+	0x10, 0xae, 0x93, 0xe6, 0x6f, 0x92, 0x2c, 0xe9, 0x69, 0x5a, 0xbc, 0x66, 0x9b, 0x4d, 0x8c, 0x10,
+	0x95, 0x80, 0x16, 0x65, 0x17, 0xee, 0x56, 0x22, 0x4d, 0x4c, 0x37, 0xda, 0x6e, 0x37, 0xb8, 0x69,
+	0x7b, 0x19, 0xb9, 0xf6, 0x51, 0x30, 0x9b, 0xc6, 0xc6, 0x3e, 0xed, 0xaa, 0xe2, 0x21, 0xb8, 0xe0,
+	0x02, 0x5e, 0x81, 0x37, 0x81, 0x3b, 0x6e, 0xb9, 0x43, 0xe5, 0x45, 0xd0, 0xf9, 0x71, 0xfc, 0x13,
+	0x27, 0x4d, 0x90, 0xf6, 0xaa, 0x9d, 0x99, 0x6f, 0xe6, 0xcc, 0xcc, 0x99, 0x33, 0x9f, 0x03, 0x9f,
+	0x78, 0xf8, 0xc7, 0x1b, 0xec, 0x13, 0xff, 0xd0, 0x73, 0xcd, 0xc3, 0x40, 0x70, 0xaf, 0x0e, 0x7d,
+	0xec, 0xdd, 0xda, 0x26, 0x3e, 0x70, 0x3d, 0x87, 0x38, 0x08, 0x42, 0x8b, 0xfa, 0x67, 0x0e, 0x0a,
+	0x3a, 0x17, 0x51, 0x1d, 0x72, 0xc4, 0x26, 0x13, 0x2c, 0x4b, 0x4d, 0x69, 0xbf, 0xa4, 0x73, 0x01,
+	0x21, 0xd8, 0xbc, 0x72, 0xac, 0x3b, 0x39, 0xc3, 0x94, 0xec, 0x7f, 0xd4, 0x82, 0x8a, 0x88, 0x81,
+	0xbd, 0x91, 0x6d, 0xc9, 0x59, 0x66, 0x2b, 0xcf, 0x74, 0x7d, 0x8b, 0x42, 0x6e, 0x9d, 0xc9, 0xcd,
+	0x94, 0x60, 0x0e, 0xd9, 0xe4, 0x90, 0x99, 0xae, 0x6f, 0x21, 0x05, 0x8a, 0xae, 0xe3, 0x13, 0xd3,
+	0xb1, 0xb0, 0x9c, 0x63, 0xe6, 0x99, 0x8c, 0x3e, 0x86, 0xaa, 0xe9, 0x61, 0x83, 0xd8, 0xce, 0x74,
+	0x64, 0x19, 0x04, 0xcb, 0x79, 0x06, 0xa8, 0x04, 0xca, 0x9e, 0x41, 0x30, 0x3a, 0x84, 0x9c, 0x4f,
+	0xa8, 0xb1, 0xd0, 0x94, 0xf6, 0x1f, 0xb5, 0x1f, 0x1f, 0x84, 0x85, 0x1d, 0x88, 0xa2, 0x0e, 0xce,
+	0x28, 0x40, 0xe7, 0x38, 0xb4, 0x0b, 0x79, 0xff, 0xad, 0x3d, 0x99, 0xf8, 0x72, 0xb1, 0x99, 0xdd,
+	0x2f, 0xe9, 0x42, 0x42, 0xcf, 0xa1, 0x60, 0x4c, 0xfd, 0x77, 0xd8, 0xf3, 0xe5, 0x52, 0x33, 0xbb,
+	0x5f, 0x6e, 0x2b, 0x69, 0xa1, 0x3a, 0x0c, 0xa2, 0x07, 0x50, 0xea, 0xe5, 0x19, 0xc4, 0x9e, 0x8e,
+	0x7d, 0x19, 0x16, 0x7b, 0xe9, 0x0c, 0xa2, 0x07, 0x50, 0x5a, 0xb5, 0x69, 0x10, 0x3c, 0x76, 0xbc,
+	0x3b, 0xb9, 0xcc, 0xab, 0x0e, 0x64, 0xb4, 0x07, 0xc0, 0x12, 0xe5, 0x25, 0x57, 0x98, 0xb5, 0xc4,
+	0x34, 0xb4, 0x5e, 0x45, 0x83, 0x3c, 0xcf, 0x61, 0xae, 0xbb, 0xd2, 0x7c, 0x77, 0x65, 0x28, 0x98,
+	0xce, 0xf5, 0x35, 0x9e, 0x12, 0x71, 0x75, 0x81, 0xa8, 0xfc, 0x22, 0x41, 0x9e, 0x67, 0x85, 0x1e,
+	0x43, 0xd1, 0x33, 0x48, 0x34, 0x46, 0x81, 0xc9, 0x7d, 0x2b, 0x30, 0x61, 0x6a, 0xca, 0x84, 0x26,
+	0xdc, 0xb7, 0xe8, 0xa0, 0xf8, 0xa6, 0xe3, 0x61, 0x76, 0xef, 0x39, 0x9d, 0x0b, 0xd1, 0x03, 0x37,
+	0x63, 0x07, 0xce, 0x5f, 0x66, 0x6e, 0xfe, 0x32, 0xd5, 0x6f, 0x20, 0xc7, 0xee, 0x0a, 0x95, 0xa1,
+	0x70, 0xd9, 0xe9, 0x0f, 0xfb, 0xa7, 0xc7, 0xb5, 0x0d, 0x54, 0x81, 0x62, 0xa7, 0xdb, 0xd5, 0x06,
+	0x43, 0xad, 0x57, 0x93, 0x50, 0x15, 0x4a, 0xdd, 0x37, 0xaf, 0x07, 0x27, 0x1a, 0x15, 0x33, 0x4c,
+	0xec, 0x9c, 0x76, 0xb5, 0x93, 0x13, 0xad, 0x57, 0xcb, 0xaa, 0xdb, 0xb0, 0x75, 0x8c, 0xc9, 0x05,
+	0xf6, 0x7c, 0xdb, 0x99, 0x8a, 0xf6, 0xab, 0xbf, 0x4b, 0x80, 0xa2, 0x5a, 0xdf, 0x75, 0xa6, 0x3e,
+	0x4b, 0xd6, 0xf5, 0x9c, 0x1f, 0xb0, 0x49, 0x82, 0xba, 0x85, 0x48, 0x2d, 0xb7, 0x1c, 0x1c, 0x94,
+	0x2d, 0x44, 0x7a, 0x3b, 0x57, 0x37, 0xf6, 0xc4, 0xe2, 0x35, 0xf0, 0x99, 0x2f, 0x31, 0x0d, 0x9b,
+	0xc6, 0x16, 0x54, 0xc6, 0x36, 0x19, 0x79, 0xf8, 0xd6, 0x66, 0xde, 0x62, 0xe2, 0xc7, 0x36, 0xd1,
+	0x85, 0x8a, 0x46, 0x18, 0x3b, 0xa3, 0x20, 0x3c, 0xef, 0x42, 0x69, 0xec, 0x88, 0xe4, 0xd4, 0x23,
+	0xd8, 0xea, 0x58, 0x96, 0xc8, 0x5c, 0xfc, 0x41, 0x5f, 0x40, 0x41, 0x4c, 0x15, 0xcb, 0xb4, 0xdc,
+	0xde, 0x4e, 0x99, 0x32, 0x3d, 0xc0, 0xa8, 0xcf, 0x00, 0x45, 0x63, 0x88, 0x72, 0xf7, 0x20, 0x78,
+	0xf4, 0xe1, 0x4d, 0x97, 0x84, 0xa6, 0x6f, 0xa9, 0x5f, 0x41, 0xbd, 0x87, 0x27, 0x98, 0xe0, 0xc4,
+	0xd9, 0x0f, 0xb8, 0x7d, 0x08, 0x3b, 0x09, 0x37, 0x7e, 0x9c, 0x6a, 0x41, 0xfd, 0xdc, 0xb5, 0x8c,
+	0x88, 0x61, 0x95, 0x78, 0xd1, 0x52, 0x33, 0x2b, 0x94, 0xfa, 0x2d, 0xec, 0x24, 0x4e, 0x11, 0xd5,
+	0xae, 0xd9, 0xb2, 0x3a, 0x9b, 0x10, 0xa1, 0xf6, 0x83, 0xc1, 0x31, 0x61, 0x3b, 0xa6, 0x5d, 0xa9,
+	0x93, 0xeb, 0x96, 0xf0, 0x35, 0xec, 0x84, 0x87, 0x1c, 0xdd, 0xf5, 0x7b, 0x2b, 0x76, 0xfe, 0x18,
+	0x76, 0x93, 0x7e, 0xff, 0xaf, 0xf6, 0x17, 0xf0, 0xf4, 0x0c, 0x1b, 0x9e, 0xf9, 0x7d, 0x50, 0xe8,
+	0xd1, 0xdd, 0x40, 0xec, 0xe0, 0x20, 0x95, 0xe8, 0x9a, 0x96, 0xe2, 0x6b, 0x5a, 0x75, 0xa1, 0xb9,
+	0xd8, 0xfd, 0xbd, 0x74, 0xcc, 0x86, 0xba, 0xd8, 0xc3, 0x6b, 0x8d, 0x56, 0x1b, 0xf2, 0x7c, 0x6d,
+	0x8b, 0x43, 0x96, 0x2d, 0x78, 0x81, 0xa4, 0xe3, 0x9d, 0x38, 0x4a, 0x8c, 0xf7, 0x39, 0x6c, 0x75,
+	0x4c, 0x13, 0xbb, 0xe4, 0x25, 0x9e, 0xb8, 0x2b, 0x26, 0x90, 0xdc, 0xd8, 0x99, 0xb9, 0x8d, 0x4d,
+	0xe7, 0x30, 0x1a, 0x56, 0x1c, 0xf6, 0x1c, 0xb6, 0xbb, 0xce, 0xb5, 0x4b, 0x9f, 0xd9, 0xea, 0xc7,
+	0xa9, 0xbb, 0x50, 0x8f, 0x7b, 0x89, 0x68, 0x6d, 0xd8, 0xea, 0x1a, 0x53, 0x13, 0x4f, 0xd6, 0x88,
+	0x55, 0x07, 0x14, 0xf5, 0x11, 0x91, 0x7e, 0x82, 0x0f, 0x74, 0x63, 0x9d, 0x9c, 0x62, 0x64, 0x93,
+	0x89, 0x93, 0xcd, 0x9a, 0x8c, 0xa2, 0x22, 0xa8, 0x85, 0x87, 0x8b, 0x84, 0x7e, 0x96, 0xa0, 0x7e,
+	0x69, 0x90, 0x70, 0x16, 0x83, 0xb4, 0x9e, 0x40, 0x29, 0x18, 0x58, 0x5f, 0x96, 0x18, 0xf1, 0x87,
+	0x8a, 0xc8, 0x37, 0x41, 0x26, 0xf6, 0x4d, 0xd0, 0x00, 0x10, 0xbc, 0x6c, 0x63, 0x5f, 0xce, 0x32,
+	0x5b, 0x44, 0xc3, 0xbf, 0x81, 0xfc, 0x9b, 0x6b, 0x3c, 0x22, 0xce, 0x5b, 0x3c, 0x5b, 0xf7, 0x5c,
+	0x37, 0xa4, 0x2a, 0xf5, 0xd7, 0x0c, 0xec, 0x24, 0x32, 0x12, 0x6f, 0xe2, 0x05, 0xe4, 0xf0, 0x2d,
+	0xad, 0x4b, 0x62, 0x5f, 0x2e, 0x9f, 0x46, 0xa7, 0x31, 0xd5, 0xe3, 0x40, 0xa3, 0x70, 0x9d, 0x7b,
+	0x25, 0x1a, 0x9d, 0x59, 0xf2, 0xa4, 0xb2, 0x0f, 0x3f, 0xa9, 0x55, 0x2a, 0x39, 0x85, 0x1c, 0x4b,
+	0x80, 0x92, 0x73, 0x57, 0xd7, 0x3a, 0x94, 0x7f, 0x37, 0xa8, 0x70, 0x3e, 0xe8, 0x75, 0x38, 0x37,
+	0x6f, 0x41, 0xf5, 0x6c, 0xd8, 0x19, 0x6a, 0xa3, 0xee, 0xcb, 0xce, 0xe9, 0x31, 0xe3, 0xe7, 0x32,
+	0x14, 0x7a, 0x1a, 0x27, 0xeb, 0x2c, 0x15, 0x74, 0xed, 0xf5, 0x9b, 0x0b, 0xad, 0x57, 0xdb, 0x6c,
+	0xff, 0x5d, 0x84, 0xf2, 0xac, 0xc4, 0x41, 0x17, 0xbd, 0x02, 0x08, 0x49, 0x1a, 0xed, 0x45, 0xd3,
+	0x9d, 0xa3, 0x74, 0xa5, 0xb1, 0xc8, 0x2c, 0x9a, 0xfb, 0x0a, 0x20, 0xa4, 0xc0, 0x78, 0xb0, 0x39,
+	0x7a, 0x55, 0x1a, 0x8b, 0xcc, 0x22, 0xd8, 0x10, 0xaa, 0x31, 0x8e, 0x43, 0xcd, 0xa8, 0x43, 0x1a,
+	0x6b, 0x2a, 0xad, 0x25, 0x88, 0x30, 0x6a, 0x8c, 0xba, 0xe2, 0x51, 0xd3, 0xb8, 0x53, 0x69, 0x2d,
+	0x41, 0x88, 0xa8, 0x03, 0x28, 0x47, 0x28, 0x0b, 0x25, 0xfb, 0x94, 0x78, 0x17, 0xca, 0xd3, 0x85,
+	0x76, 0x1e, 0xef, 0x4b, 0x09, 0x5d, 0xc2, 0xa3, 0x38, 0xcf, 0xa0, 0x56, 0xba, 0x53, 0x84, 0xbb,
+	0x14, 0x75, 0x19, 0x44, 0xa4, 0xfa, 0x0e, 0xe4, 0x45, 0xc4, 0x81, 0x3e, 0x8b, 0xfa, 0x3f, 0xc0,
+	0x4e, 0xca, 0xe7, 0xab, 0x81, 0x67, 0x15, 0x0d, 0xa1, 0x1a, 0x5b, 0xea, 0xf1, 0xce, 0xa7, 0x51,
+	0x8b, 0xd2, 0x5a, 0x82, 0x88, 0x8c, 0xdc, 0x6c, 0x75, 0x27, 0x46, 0x2e, 0xc9, 0x14, 0x4a, 0x63,
+	0x91, 0x59, 0x04, 0xfb, 0x0e, 0x2a, 0xd1, 0xdd, 0x8d, 0x62, 0xf7, 0x94, 0xc2, 0x05, 0x4a, 0x73,
+	0x31, 0x20, 0xcc, 0x2f, 0x5c, 0xe1, 0xf1, 0xfc, 0xe6, 0xe8, 0x40, 0x69, 0x2c, 0x32, 0x8b, 0x60,
+	0x1a, 0x14, 0x83, 0xe5, 0x8b, 0x3e, 0x8a, 0x62, 0x13, 0x7c, 0xa0, 0x3c, 0x49, 0x37, 0x8a, 0x30,
+	0x17, 0x50, 0x8d, 0xad, 0xba, 0xf8, 0x4d, 0xa4, 0x6d, 0x72, 0xa5, 0xb5, 0x04, 0x11, 0xdc, 0xf0,
+	0x51, 0xed, 0x8f, 0xfb, 0x86, 0xf4, 0xd7, 0x7d, 0x43, 0xfa, 0xe7, 0xbe, 0x21, 0xfd, 0xf6, 0x6f,
+	0x63, 0xe3, 0x2a, 0xcf, 0x7e, 0xf7, 0x3e, 0xfb, 0x6f, 0x00, 0xc9, 0x76, 0x41, 0x9f, 0x20, 0x0f,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1393,6 +1810,15 @@ type RequestsRPCClient interface {
 	AcceptHelp(ctx context.Context, in *AcceptHelpRequest, opts ...grpc.CallOption) (*AcceptHelpResponse, error)
 	CompleteHelp(ctx context.Context, in *CompleteHelpRequest, opts ...grpc.CallOption) (*CompleteHelpResponse, error)
 	CancelHelp(ctx context.Context, in *CancelHelpRequest, opts ...grpc.CallOption) (*CancelHelpResponse, error)
+	// Rates the other party of a completed request, both the requester and the volunteer can rate
+	// each other once, the score goes from 1 to 5
+	RateHelp(ctx context.Context, in *RateHelpRequest, opts ...grpc.CallOption) (*RateHelpResponse, error)
+	// Streams the requests matching the filter as they are created, updated or change state, empty
+	// filter fields match everything, and the requests that stop matching it. Passing the resume
+	// token of the last event received replays the events missed while disconnected. The events are
+	// only kept in memory: the tokens of the events no longer kept and of a previous run of the
+	// service fail with OUT_OF_RANGE, the requests have to be listed again
+	WatchRequests(ctx context.Context, in *WatchRequestsRequest, opts ...grpc.CallOption) (RequestsRPC_WatchRequestsClient, error)
 }
 
 type requestsRPCClient struct {
@@ -1548,25 +1974,75 @@ func (c *requestsRPCClient) CancelHelp(ctx context.Context, in *CancelHelpReques
 	return out, nil
 }
 
-// RequestsRPCServer is the server API for RequestsRPC service.
-type RequestsRPCServer interface {
-	// Returns software version and build details
-	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
-	// Adds a new request
-	AddRequest(context.Context, *AddRequestRequest) (*AddRequestResponse, error)
-	// Deletes an existing request
-	DeleteRequest(context.Context, *DeleteRequestRequest) (*DeleteRequestResponse, error)
-	// Updates an existing request, method requires passing all the information about the request as
-	// the object is simply replaced
-	UpdateRequest(context.Context, *UpdateRequestRequest) (*UpdateRequestResponse, error)
-	// Returns all the users in the system
-	GetRequests(*GetRequestsRequest, RequestsRPC_GetRequestsServer) error
-	GetRequestByID(context.Context, *GetRequestByIDRequest) (*GetRequestByIDResponse, error)
-	SearchRequestsByPostcode(*SearchRequestsByPostcodeRequest, RequestsRPC_SearchRequestsByPostcodeServer) error
-	AnswerRequest(context.Context, *AnswerRequestRequest) (*AnswerRequestResponse, error)
-	AcceptHelp(context.Context, *AcceptHelpRequest) (*AcceptHelpResponse, error)
-	CompleteHelp(context.Context, *CompleteHelpRequest) (*CompleteHelpResponse, error)
-	CancelHelp(context.Context, *CancelHelpRequest) (*CancelHelpResponse, error)
+func (c *requestsRPCClient) RateHelp(ctx context.Context, in *RateHelpRequest, opts ...grpc.CallOption) (*RateHelpResponse, error) {
+	out := new(RateHelpResponse)
+	err := c.cc.Invoke(ctx, "/requestspb.RequestsRPC/RateHelp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *requestsRPCClient) WatchRequests(ctx context.Context, in *WatchRequestsRequest, opts ...grpc.CallOption) (RequestsRPC_WatchRequestsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RequestsRPC_serviceDesc.Streams[2], "/requestspb.RequestsRPC/WatchRequests", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &requestsRPCWatchRequestsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RequestsRPC_WatchRequestsClient interface {
+	Recv() (*WatchRequestsResponse, error)
+	grpc.ClientStream
+}
+
+type requestsRPCWatchRequestsClient struct {
+	grpc.ClientStream
+}
+
+func (x *requestsRPCWatchRequestsClient) Recv() (*WatchRequestsResponse, error) {
+	m := new(WatchRequestsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RequestsRPCServer is the server API for RequestsRPC service.
+type RequestsRPCServer interface {
+	// Returns software version and build details
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	// Adds a new request
+	AddRequest(context.Context, *AddRequestRequest) (*AddRequestResponse, error)
+	// Deletes an existing request
+	DeleteRequest(context.Context, *DeleteRequestRequest) (*DeleteRequestResponse, error)
+	// Updates an existing request, method requires passing all the information about the request as
+	// the object is simply replaced
+	UpdateRequest(context.Context, *UpdateRequestRequest) (*UpdateRequestResponse, error)
+	// Returns all the users in the system
+	GetRequests(*GetRequestsRequest, RequestsRPC_GetRequestsServer) error
+	GetRequestByID(context.Context, *GetRequestByIDRequest) (*GetRequestByIDResponse, error)
+	SearchRequestsByPostcode(*SearchRequestsByPostcodeRequest, RequestsRPC_SearchRequestsByPostcodeServer) error
+	AnswerRequest(context.Context, *AnswerRequestRequest) (*AnswerRequestResponse, error)
+	AcceptHelp(context.Context, *AcceptHelpRequest) (*AcceptHelpResponse, error)
+	CompleteHelp(context.Context, *CompleteHelpRequest) (*CompleteHelpResponse, error)
+	CancelHelp(context.Context, *CancelHelpRequest) (*CancelHelpResponse, error)
+	// Rates the other party of a completed request, both the requester and the volunteer can rate
+	// each other once, the score goes from 1 to 5
+	RateHelp(context.Context, *RateHelpRequest) (*RateHelpResponse, error)
+	// Streams the requests matching the filter as they are created, updated or change state, empty
+	// filter fields match everything, and the requests that stop matching it. Passing the resume
+	// token of the last event received replays the events missed while disconnected. The events are
+	// only kept in memory: the tokens of the events no longer kept and of a previous run of the
+	// service fail with OUT_OF_RANGE, the requests have to be listed again
+	WatchRequests(*WatchRequestsRequest, RequestsRPC_WatchRequestsServer) error
 }
 
 // UnimplementedRequestsRPCServer can be embedded to have forward compatible implementations.
@@ -1606,6 +2082,12 @@ func (*UnimplementedRequestsRPCServer) CompleteHelp(ctx context.Context, req *Co
 func (*UnimplementedRequestsRPCServer) CancelHelp(ctx context.Context, req *CancelHelpRequest) (*CancelHelpResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelHelp not implemented")
 }
+func (*UnimplementedRequestsRPCServer) RateHelp(ctx context.Context, req *RateHelpRequest) (*RateHelpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateHelp not implemented")
+}
+func (*UnimplementedRequestsRPCServer) WatchRequests(req *WatchRequestsRequest, srv RequestsRPC_WatchRequestsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRequests not implemented")
+}
 
 func RegisterRequestsRPCServer(s *grpc.Server, srv RequestsRPCServer) {
 	s.RegisterService(&_RequestsRPC_serviceDesc, srv)
@@ -1815,6 +2297,45 @@ func _RequestsRPC_CancelHelp_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RequestsRPC_RateHelp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateHelpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RequestsRPCServer).RateHelp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/requestspb.RequestsRPC/RateHelp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RequestsRPCServer).RateHelp(ctx, req.(*RateHelpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RequestsRPC_WatchRequests_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequestsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RequestsRPCServer).WatchRequests(m, &requestsRPCWatchRequestsServer{stream})
+}
+
+type RequestsRPC_WatchRequestsServer interface {
+	Send(*WatchRequestsResponse) error
+	grpc.ServerStream
+}
+
+type requestsRPCWatchRequestsServer struct {
+	grpc.ServerStream
+}
+
+func (x *requestsRPCWatchRequestsServer) Send(m *WatchRequestsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _RequestsRPC_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "requestspb.RequestsRPC",
 	HandlerType: (*RequestsRPCServer)(nil),
@@ -1855,6 +2376,10 @@ var _RequestsRPC_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CancelHelp",
 			Handler:    _RequestsRPC_CancelHelp_Handler,
 		},
+		{
+			MethodName: "RateHelp",
+			Handler:    _RequestsRPC_RateHelp_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1867,6 +2392,11 @@ var _RequestsRPC_serviceDesc = grpc.ServiceDesc{
 			Handler:       _RequestsRPC_SearchRequestsByPostcode_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchRequests",
+			Handler:       _RequestsRPC_WatchRequests_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "requests/rpc/requestspb/service.proto",
 }
@@ -1895,6 +2425,34 @@ func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.StateDate) > 0 {
+		i -= len(m.StateDate)
+		copy(dAtA[i:], m.StateDate)
+		i = encodeVarintService(dAtA, i, uint64(len(m.StateDate)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.Category) > 0 {
+		i -= len(m.Category)
+		copy(dAtA[i:], m.Category)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Category)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.Ratings) > 0 {
+		for iNdEx := len(m.Ratings) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ratings[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
 	if len(m.Answers) > 0 {
 		for iNdEx := len(m.Answers) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2009,6 +2567,66 @@ func (m *Request_Answer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Request_Rating) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Request_Rating) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_Rating) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.CreationDate) > 0 {
+		i -= len(m.CreationDate)
+		copy(dAtA[i:], m.CreationDate)
+		i = encodeVarintService(dAtA, i, uint64(len(m.CreationDate)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Comment) > 0 {
+		i -= len(m.Comment)
+		copy(dAtA[i:], m.Comment)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Comment)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Score != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Score))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.RateeId) > 0 {
+		i -= len(m.RateeId)
+		copy(dAtA[i:], m.RateeId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.RateeId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RaterId) > 0 {
+		i -= len(m.RaterId)
+		copy(dAtA[i:], m.RaterId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.RaterId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *GetVersionRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2806,86 +3424,330 @@ func (m *CancelHelpResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintService(dAtA []byte, offset int, v uint64) int {
-	offset -= sovService(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *RateHelpRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Request) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *RateHelpRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RateHelpRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Title)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	l = len(m.Body)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	l = len(m.RequesterId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	l = len(m.VolunteerId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	l = len(m.Postcode)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	l = len(m.CreationDate)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.State != 0 {
-		n += 1 + sovService(uint64(m.State))
+	if len(m.Comment) > 0 {
+		i -= len(m.Comment)
+		copy(dAtA[i:], m.Comment)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Comment)))
+		i--
+		dAtA[i] = 0x22
 	}
-	if len(m.Skills) > 0 {
-		for _, s := range m.Skills {
-			l = len(s)
-			n += 1 + l + sovService(uint64(l))
-		}
+	if m.Score != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Score))
+		i--
+		dAtA[i] = 0x18
 	}
-	if len(m.Answers) > 0 {
-		for _, e := range m.Answers {
-			l = e.Size()
-			n += 1 + l + sovService(uint64(l))
-		}
+	if len(m.RaterId) > 0 {
+		i -= len(m.RaterId)
+		copy(dAtA[i:], m.RaterId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.RaterId)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.RequestId) > 0 {
+		i -= len(m.RequestId)
+		copy(dAtA[i:], m.RequestId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.RequestId)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Request_Answer) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RateHelpResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RateHelpResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RateHelpResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.VolunteerId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	l = len(m.Comment)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchRequestsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchRequestsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchRequestsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ResumeToken) > 0 {
+		i -= len(m.ResumeToken)
+		copy(dAtA[i:], m.ResumeToken)
+		i = encodeVarintService(dAtA, i, uint64(len(m.ResumeToken)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Categories) > 0 {
+		for iNdEx := len(m.Categories) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Categories[iNdEx])
+			copy(dAtA[i:], m.Categories[iNdEx])
+			i = encodeVarintService(dAtA, i, uint64(len(m.Categories[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Skills) > 0 {
+		for iNdEx := len(m.Skills) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Skills[iNdEx])
+			copy(dAtA[i:], m.Skills[iNdEx])
+			i = encodeVarintService(dAtA, i, uint64(len(m.Skills[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Postcodes) > 0 {
+		for iNdEx := len(m.Postcodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Postcodes[iNdEx])
+			copy(dAtA[i:], m.Postcodes[iNdEx])
+			i = encodeVarintService(dAtA, i, uint64(len(m.Postcodes[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchRequestsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchRequestsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchRequestsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ResumeToken) > 0 {
+		i -= len(m.ResumeToken)
+		copy(dAtA[i:], m.ResumeToken)
+		i = encodeVarintService(dAtA, i, uint64(len(m.ResumeToken)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Request != nil {
+		{
+			size, err := m.Request.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.RequestId) > 0 {
+		i -= len(m.RequestId)
+		copy(dAtA[i:], m.RequestId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.RequestId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Event != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Event))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintService(dAtA []byte, offset int, v uint64) int {
+	offset -= sovService(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Body)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.RequesterId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.VolunteerId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Postcode)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.CreationDate)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.State != 0 {
+		n += 1 + sovService(uint64(m.State))
+	}
+	if len(m.Skills) > 0 {
+		for _, s := range m.Skills {
+			l = len(s)
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.Answers) > 0 {
+		for _, e := range m.Answers {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.Ratings) > 0 {
+		for _, e := range m.Ratings {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	l = len(m.Category)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.StateDate)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Request_Answer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.VolunteerId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Comment)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Request_Rating) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RaterId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.RateeId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Score != 0 {
+		n += 1 + sovService(uint64(m.Score))
+	}
+	l = len(m.Comment)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.CreationDate)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
 
 func (m *GetVersionRequest) Size() (n int) {
@@ -3248,26 +4110,126 @@ func (m *CancelHelpResponse) Size() (n int) {
 	return n
 }
 
-func sovService(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *RateHelpRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RequestId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.RaterId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Score != 0 {
+		n += 1 + sovService(uint64(m.Score))
+	}
+	l = len(m.Comment)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func sozService(x uint64) (n int) {
-	return sovService(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *RateHelpResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowService
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
+
+func (m *WatchRequestsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Postcodes) > 0 {
+		for _, s := range m.Postcodes {
+			l = len(s)
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.Skills) > 0 {
+		for _, s := range m.Skills {
+			l = len(s)
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.Categories) > 0 {
+		for _, s := range m.Categories {
+			l = len(s)
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	l = len(m.ResumeToken)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WatchRequestsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Event != 0 {
+		n += 1 + sovService(uint64(m.Event))
+	}
+	l = len(m.RequestId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Request != nil {
+		l = m.Request.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.ResumeToken)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovService(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozService(x uint64) (n int) {
+	return sovService(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Request) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
 			iNdEx++
 			wire |= uint64(b&0x7F) << shift
 			if b < 0x80 {
@@ -3560,6 +4522,104 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ratings", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ratings = append(m.Ratings, &Request_Rating{})
+			if err := m.Ratings[len(m.Ratings)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Category", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Category = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateDate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StateDate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -3703,61 +4763,7 @@ func (m *Request_Answer) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetVersionRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowService
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetVersionRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipService(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
+func (m *Request_Rating) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3780,15 +4786,15 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetVersionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: Rating: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetVersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Rating: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RaterId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3816,11 +4822,11 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Project = string(dAtA[iNdEx:postIndex])
+			m.RaterId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RateeId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3848,13 +4854,13 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			m.RateeId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BuildDate", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
 			}
-			var stringLen uint64
+			m.Score = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -3864,27 +4870,14 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Score |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthService
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthService
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.BuildDate = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GitRevision", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Comment", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3912,11 +4905,11 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GitRevision = string(dAtA[iNdEx:postIndex])
+			m.Comment = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreationDate", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3944,7 +4937,7 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GoVersion = string(dAtA[iNdEx:postIndex])
+			m.CreationDate = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3971,7 +4964,7 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddRequestRequest) Unmarshal(dAtA []byte) error {
+func (m *GetVersionRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3994,48 +4987,12 @@ func (m *AddRequestRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddRequestRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetVersionRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddRequestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthService
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthService
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Request == nil {
-				m.Request = &Request{}
-			}
-			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -4061,7 +5018,7 @@ func (m *AddRequestRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddRequestResponse) Unmarshal(dAtA []byte) error {
+func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4084,15 +5041,15 @@ func (m *AddRequestResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddRequestResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetVersionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddRequestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetVersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4120,7 +5077,135 @@ func (m *AddRequestResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RequestId = string(dAtA[iNdEx:postIndex])
+			m.Project = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BuildDate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BuildDate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GitRevision", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GitRevision = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoVersion = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4147,7 +5232,7 @@ func (m *AddRequestResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteRequestRequest) Unmarshal(dAtA []byte) error {
+func (m *AddRequestRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4170,17 +5255,17 @@ func (m *DeleteRequestRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteRequestRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddRequestRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteRequestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddRequestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -4190,23 +5275,27 @@ func (m *DeleteRequestRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RequestId = string(dAtA[iNdEx:postIndex])
+			if m.Request == nil {
+				m.Request = &Request{}
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4233,7 +5322,7 @@ func (m *DeleteRequestRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteRequestResponse) Unmarshal(dAtA []byte) error {
+func (m *AddRequestResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4256,12 +5345,44 @@ func (m *DeleteRequestResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteRequestResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddRequestResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteRequestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddRequestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -4287,7 +5408,7 @@ func (m *DeleteRequestResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateRequestRequest) Unmarshal(dAtA []byte) error {
+func (m *DeleteRequestRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4310,10 +5431,10 @@ func (m *UpdateRequestRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateRequestRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteRequestRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateRequestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteRequestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4348,19 +5469,159 @@ func (m *UpdateRequestRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.RequestId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteRequestResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteRequestResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteRequestResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateRequestRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateRequestRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateRequestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
 				iNdEx++
 				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
@@ -5687,6 +6948,584 @@ func (m *CancelHelpResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RateHelpRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateHelpRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateHelpRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RaterId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RaterId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			m.Score = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Score |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Comment", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Comment = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RateHelpResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateHelpResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateHelpResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchRequestsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchRequestsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchRequestsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Postcodes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Postcodes = append(m.Postcodes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Skills", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Skills = append(m.Skills, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Categories", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Categories = append(m.Categories, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchRequestsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchRequestsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchRequestsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			m.Event = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Event |= WatchRequestsResponse_Event(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Request == nil {
+				m.Request = &Request{}
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipService(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (svc *Service) GrantConsent(ctx context.Context, req *userspb.GrantConsentRequest) (*userspb.GrantConsentResponse, error) {
+	version := req.PolicyVersion
+	if version == "" {
+		version = svc.policyVersion
+	}
+	for _, p := range req.Purposes {
+		if p != userspb.User_Consent_PRIVACY_POLICY {
+			continue
+		}
+		if version == "" {
+			return nil, status.Error(codes.InvalidArgument, "missing privacy policy version")
+		}
+		if svc.policyVersion != "" && version != svc.policyVersion {
+			return nil, status.Errorf(codes.FailedPrecondition, "privacy policy %s isn't the current version %s", version, svc.policyVersion)
+		}
+	}
+	consents, err := svc.recordConsent(ctx, req.UserId, req.Purposes, true, version, req.Source)
+	if err != nil {
+		return nil, err
+	}
+	return &userspb.GrantConsentResponse{Consents: consents}, nil
+}
+
+func (svc *Service) RevokeConsent(ctx context.Context, req *userspb.RevokeConsentRequest) (*userspb.RevokeConsentResponse, error) {
+	consents, err := svc.recordConsent(ctx, req.UserId, req.Purposes, false, "", req.Source)
+	if err != nil {
+		return nil, err
+	}
+	return &userspb.RevokeConsentResponse{Consents: consents}, nil
+}
+
+func (svc *Service) GetConsent(ctx context.Context, req *userspb.GetConsentRequest) (*userspb.GetConsentResponse, error) {
+	user, err := svc.users.Get(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &userspb.GetConsentResponse{
+		Consents:          inEffect(user),
+		PolicyVersion:     svc.policyVersion,
+		ReconsentRequired: svc.reconsentRequired(user),
+	}, nil
+}
+
+// recordConsent appends a record per purpose to the consents of the user and returns the consents
+// in effect.
+func (svc *Service) recordConsent(
+	ctx context.Context,
+	userID string,
+	purposes []userspb.User_Consent_Purpose,
+	granted bool,
+	version string,
+	source string,
+) ([]*userspb.User_Consent, error) {
+	if len(purposes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing purposes")
+	}
+	date := time.Now().UTC().Format(time.RFC3339)
+	consents := make([]*userspb.User_Consent, len(purposes))
+	for i, p := range purposes {
+		if _, ok := userspb.User_Consent_Purpose_name[int32(p)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown purpose %d", p)
+		}
+		consents[i] = &userspb.User_Consent{Purpose: p, Granted: granted, Date: date, Source: source}
+		if granted && p == userspb.User_Consent_PRIVACY_POLICY {
+			consents[i].PolicyVersion = version
+		}
+	}
+	user, err := svc.users.AppendConsent(ctx, userID, consents...)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	svc.logger.WithFields(
+		logrus.Fields{
+			"user_id":  userID,
+			"purposes": purposes,
+			"granted":  granted,
+			"source":   source,
+		},
+	).Info("consent recorded")
+	return inEffect(user), nil
+}
+
+// reconsentRequired reports whether the user has to accept the current privacy policy.
+func (svc *Service) reconsentRequired(user *userspb.User) bool {
+	c := storage.LatestConsent(user, userspb.User_Consent_PRIVACY_POLICY)
+	if c == nil || !c.Granted {
+		return true
+	}
+	return svc.policyVersion != "" && c.PolicyVersion != svc.policyVersion
+}
+
+// inEffect returns the latest consent of each purpose recorded for the user.
+func inEffect(user *userspb.User) []*userspb.User_Consent {
+	latest := make(map[userspb.User_Consent_Purpose]*userspb.User_Consent)
+	for _, c := range user.Consents {
+		latest[c.Purpose] = c
+	}
+	consents := make([]*userspb.User_Consent, 0, len(latest))
+	for _, c := range latest {
+		consents = append(consents, c)
+	}
+	sort.Slice(consents, func(i, j int) bool { return consents[i].Purpose < consents[j].Purpose })
+	return consents
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConsent(t *testing.T) {
+	svc := getTestService()
+	ctx := context.Background()
+	if err := svc.users.Update(context.Background(), "Blue", &userspb.User{
+		Name:           "Blue",
+		ContactDetails: []*userspb.User_ContactDetails{{Platform: userspb.User_ContactDetails_EMAIL, Identifier: "blue@example.org"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := svc.GetConsent(ctx, &userspb.GetConsentRequest{UserId: "Blue"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Consents) != 0 || !got.ReconsentRequired || got.PolicyVersion != "2026-10" {
+		t.Errorf("expected no consent, got %+v", got)
+	}
+	// the contact details aren't shared without consent
+	user, err := svc.GetUserByID(ctx, &userspb.GetUserByIDRequest{UserId: "Blue"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(user.User.ContactDetails) != 0 {
+		t.Errorf("expected the contact details to be hidden, got %v", user.User.ContactDetails)
+	}
+	// and aren't lost updating the user as it's returned
+	user.User.Name = "Blue Sky"
+	if _, err := svc.UpdateUser(ctx, &userspb.UpdateUserRequest{UserId: "Blue", User: user.User}); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := svc.users.Get("Blue"); len(stored.ContactDetails) != 1 {
+		t.Errorf("expected the contact details to be kept, got %v", stored.ContactDetails)
+	}
+
+	granted, err := svc.GrantConsent(ctx, &userspb.GrantConsentRequest{
+		UserId:   "Blue",
+		Purposes: []userspb.User_Consent_Purpose{userspb.User_Consent_PRIVACY_POLICY, userspb.User_Consent_CONTACT_SHARING, userspb.User_Consent_MARKETING},
+		Source:   "app",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*userspb.User_Consent{
+		{Purpose: userspb.User_Consent_PRIVACY_POLICY, Granted: true, PolicyVersion: "2026-10", Source: "app"},
+		{Purpose: userspb.User_Consent_CONTACT_SHARING, Granted: true, Source: "app"},
+		{Purpose: userspb.User_Consent_MARKETING, Granted: true, Source: "app"},
+	}
+	if granted.Consents[0].Date == "" {
+		t.Error("expected the consent to be dated")
+	}
+	if diff := cmp.Diff(want, undated(granted.Consents)); diff != "" {
+		t.Errorf("granted consents differ (-want +got):\n%s", diff)
+	}
+	user, err = svc.GetUserByID(ctx, &userspb.GetUserByIDRequest{UserId: "Blue"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(user.User.ContactDetails) != 1 {
+		t.Errorf("expected the contact details to be shared, got %v", user.User.ContactDetails)
+	}
+
+	revoked, err := svc.RevokeConsent(ctx, &userspb.RevokeConsentRequest{
+		UserId:   "Blue",
+		Purposes: []userspb.User_Consent_Purpose{userspb.User_Consent_CONTACT_SHARING},
+		Source:   "support",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want[1] = &userspb.User_Consent{Purpose: userspb.User_Consent_CONTACT_SHARING, Source: "support"}
+	if diff := cmp.Diff(want, undated(revoked.Consents)); diff != "" {
+		t.Errorf("consents after revoking differ (-want +got):\n%s", diff)
+	}
+	users := &usersStream{}
+	if err := svc.GetUsers(&userspb.GetUsersRequest{}, users); err != nil {
+		t.Fatal(err)
+	}
+	for _, resp := range users.responses {
+		if resp.UserId == "Blue" && len(resp.User.ContactDetails) != 0 {
+			t.Errorf("expected the contact details to be hidden once revoked, got %v", resp.User.ContactDetails)
+		}
+	}
+	// the history is kept as proof and can't be changed by updating the user
+	if _, err := svc.UpdateUser(ctx, &userspb.UpdateUserRequest{UserId: "Blue", User: &userspb.User{Name: "Blue"}}); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := svc.users.Get("Blue"); len(stored.Consents) != 4 {
+		t.Errorf("expected 4 consent records, got %v", stored.Consents)
+	}
+
+	// a new policy version needs the users to accept it again
+	svc.policyVersion = "2027-01"
+	got, err = svc.GetConsent(ctx, &userspb.GetConsentRequest{UserId: "Blue"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.ReconsentRequired {
+		t.Error("expected the user to have to accept the new policy")
+	}
+	_, err = svc.GrantConsent(ctx, &userspb.GrantConsentRequest{
+		UserId:        "Blue",
+		Purposes:      []userspb.User_Consent_Purpose{userspb.User_Consent_PRIVACY_POLICY},
+		PolicyVersion: "2026-10",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition accepting an old policy, got %v", err)
+	}
+	if _, err := svc.GrantConsent(ctx, &userspb.GrantConsentRequest{
+		UserId:        "Blue",
+		Purposes:      []userspb.User_Consent_Purpose{userspb.User_Consent_PRIVACY_POLICY},
+		PolicyVersion: "2027-01",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := svc.GetConsent(ctx, &userspb.GetConsentRequest{UserId: "Blue"}); got.ReconsentRequired {
+		t.Error("expected the new policy to be accepted")
+	}
+
+	_, err = svc.GrantConsent(ctx, &userspb.GrantConsentRequest{UserId: "Blue"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument without purposes, got %v", err)
+	}
+	_, err = svc.RevokeConsent(ctx, &userspb.RevokeConsentRequest{UserId: "Green", Purposes: []userspb.User_Consent_Purpose{userspb.User_Consent_MARKETING}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+type usersStream struct {
+	userspb.UsersRPC_GetUsersServer
+	responses []*userspb.GetUsersResponse
+}
+
+func (s *usersStream) Send(resp *userspb.GetUsersResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+// undated returns copies of the consents without their date.
+func undated(consents []*userspb.User_Consent) []*userspb.User_Consent {
+	copies := make([]*userspb.User_Consent, len(consents))
+	for i, c := range consents {
+		copies[i] = proto.Clone(c).(*userspb.User_Consent)
+		copies[i].Date = ""
+	}
+	return copies
+}
+
+func TestClearContactDetails(t *testing.T) {
+	svc := getTestService()
+	ctx := context.Background()
+	if err := svc.users.Update(context.Background(), "Blue", &userspb.User{
+		Name:           "Blue",
+		ContactDetails: []*userspb.User_ContactDetails{{Platform: userspb.User_ContactDetails_EMAIL, Identifier: "blue@example.org"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// the hidden contact details can't be given back while clearing them
+	_, err := svc.UpdateUser(ctx, &userspb.UpdateUserRequest{
+		UserId:              "Blue",
+		User:                &userspb.User{Name: "Blue", ContactDetails: []*userspb.User_ContactDetails{{Identifier: "070123456"}}},
+		ClearContactDetails: true,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	if _, err := svc.UpdateUser(ctx, &userspb.UpdateUserRequest{
+		UserId:              "Blue",
+		User:                &userspb.User{Name: "Blue"},
+		ClearContactDetails: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := svc.users.Get("Blue"); len(stored.ContactDetails) != 0 {
+		t.Errorf("expected the contact details to be cleared, got %v", stored.ContactDetails)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bundle is the document returned by ExportMyData, the messages are encoded like the API encodes
+// them.
+type bundle struct {
+	UserID     string          `json:"userId"`
+	ExportDate string          `json:"exportDate"`
+	User       json.RawMessage `json:"user"`
+	// Requests are the requests of the user, with the answers and ratings they received.
+	Requests map[string]json.RawMessage `json:"requests"`
+	// Participations are the requests of the other users the user answered, helped with or rated.
+	Participations           []participation            `json:"participations"`
+	NotificationPreferences  json.RawMessage            `json:"notificationPreferences,omitempty"`
+	UndeliveredNotifications map[string]json.RawMessage `json:"undeliveredNotifications"`
+}
+
+// participation is what a user did on the request of another user.
+type participation struct {
+	RequestID string `json:"requestId"`
+	Title     string `json:"title"`
+	State     string `json:"state"`
+	// Volunteer is set when the user is the volunteer helping.
+	Volunteer bool              `json:"volunteer"`
+	Answers   []json.RawMessage `json:"answers,omitempty"`
+	// Ratings are the ratings given or received by the user.
+	Ratings []json.RawMessage `json:"ratings,omitempty"`
+}
+
+func (svc *Service) ExportMyData(ctx context.Context, req *userspb.ExportMyDataRequest) (*userspb.ExportMyDataResponse, error) {
+	user, err := svc.users.Get(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	b := &bundle{
+		UserID:                   req.UserId,
+		ExportDate:               time.Now().UTC().Format(time.RFC3339),
+		User:                     marshal(user),
+		Requests:                 make(map[string]json.RawMessage),
+		Participations:           []participation{},
+		UndeliveredNotifications: make(map[string]json.RawMessage),
+	}
+	for id, r := range svc.requests.All() {
+		if r.RequesterId == req.UserId {
+			b.Requests[id] = marshal(r)
+			continue
+		}
+		p := participation{
+			RequestID: id,
+			Title:     r.Title,
+			State:     r.State.String(),
+			Volunteer: r.VolunteerId == req.UserId,
+		}
+		for _, a := range r.Answers {
+			if a.VolunteerId == req.UserId {
+				p.Answers = append(p.Answers, marshal(a))
+			}
+		}
+		for _, rating := range r.Ratings {
+			if rating.RaterId == req.UserId || rating.RateeId == req.UserId {
+				p.Ratings = append(p.Ratings, marshal(rating))
+			}
+		}
+		if p.Volunteer || len(p.Answers) > 0 || len(p.Ratings) > 0 {
+			b.Participations = append(b.Participations, p)
+		}
+	}
+	sort.Slice(b.Participations, func(i, j int) bool { return b.Participations[i].RequestID < b.Participations[j].RequestID })
+	if preferences, err := svc.preferences.Get(req.UserId); err == nil {
+		b.NotificationPreferences = marshal(preferences)
+	}
+	for id, d := range svc.deadLetters.All() {
+		if d.Notification.GetUserId() == req.UserId {
+			b.UndeliveredNotifications[id] = marshal(d)
+		}
+	}
+
+	doc, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &userspb.ExportMyDataResponse{
+		Bundle:   string(doc),
+		FileName: "user-" + req.UserId + ".json",
+	}, nil
+}
+
+func (svc *Service) EraseUser(ctx context.Context, req *userspb.EraseUserRequest) (*userspb.EraseUserResponse, error) {
+	if _, err := svc.users.Get(req.UserId); err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	// the profile is deleted last, an erasure that failed can be done again
+	pseudonym := storage.Pseudonym()
+	resp := &userspb.EraseUserResponse{}
+	for id, r := range svc.requests.All() {
+		if !storage.References(r, req.UserId) {
+			continue
+		}
+		erase := func(r *requestspb.Request) *requestspb.Request { return storage.Pseudonymize(r, req.UserId, pseudonym) }
+		if err := svc.requests.Erase(ctx, id, erase); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Requests++
+	}
+	err := svc.preferences.Delete(ctx, req.UserId)
+	if err != nil && err != storage.ErrNotFound {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for id, d := range svc.deadLetters.All() {
+		if d.Notification.GetUserId() != req.UserId {
+			continue
+		}
+		if err := svc.deadLetters.Delete(ctx, id); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Notifications++
+	}
+	if err := svc.users.Erase(ctx, req.UserId); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp.ErasureDate = time.Now().UTC().Format(time.RFC3339)
+
+	svc.logger.WithFields(
+		logrus.Fields{
+			"user_id":       req.UserId,
+			"requests":      resp.Requests,
+			"notifications": resp.Notifications,
+		},
+	).Info("user erased")
+	return resp, nil
+}
+
+func marshal(m proto.Message) json.RawMessage {
+	var b bytes.Buffer
+	_ = (&jsonpb.Marshaler{}).Marshal(&b, m)
+	return b.Bytes()
+}
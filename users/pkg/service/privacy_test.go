@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/notifications/rpc/notificationspb"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/users/rpc/userspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func getTestService() *Service {
+	return New(
+		logrus.NewEntry(logrus.New()),
+		storagetest.Users(map[string]*userspb.User{
+			"Brown": {Name: "Brown", Reputation: &userspb.User_Reputation{Score: 5, Ratings: 1, Total: 5}},
+			"Blue":  {Name: "Blue", Reputation: &userspb.User_Reputation{Score: 4, Ratings: 1, Total: 4}},
+		}),
+		storagetest.Requests(map[string]*requestspb.Request{
+			"a": {
+				Title:       "help with groceries",
+				RequesterId: "Brown",
+				VolunteerId: "Blue",
+				State:       requestspb.Request_COMPLETED,
+				Answers:     []*requestspb.Request_Answer{{VolunteerId: "Blue", Comment: "I live next door"}},
+				Ratings: []*requestspb.Request_Rating{
+					{RaterId: "Brown", RateeId: "Blue", Score: 4, Comment: "thanks Blue"},
+					{RaterId: "Blue", RateeId: "Brown", Score: 5, Comment: "lovely"},
+				},
+			},
+			"b": {
+				Title:       "help walking the dog",
+				RequesterId: "Blue",
+				State:       requestspb.Request_WAITING,
+			},
+		}),
+		storagetest.Preferences(map[string]*notificationspb.Preferences{
+			"Blue": {Disabled: true},
+		}),
+		storagetest.DeadLetters(map[string]*notificationspb.DeadLetter{
+			"d1": {Notification: &notificationspb.Notification{UserId: "Blue", Recipient: "blue@example.org"}},
+			"d2": {Notification: &notificationspb.Notification{UserId: "Brown", Recipient: "brown@example.org"}},
+		}),
+		"2026-10",
+	)
+}
+
+func TestExportMyData(t *testing.T) {
+	svc := getTestService()
+
+	resp, err := svc.ExportMyData(context.Background(), &userspb.ExportMyDataRequest{UserId: "Blue"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.FileName != "user-Blue.json" {
+		t.Errorf("unexpected file name %s", resp.FileName)
+	}
+	var b bundle
+	if err := json.Unmarshal([]byte(resp.Bundle), &b); err != nil {
+		t.Fatalf("invalid bundle: %s", err)
+	}
+	if compact(t, b.User) != `{"name":"Blue","reputation":{"score":4,"ratings":1,"total":4}}` {
+		t.Errorf("unexpected user %s", b.User)
+	}
+	if _, ok := b.Requests["b"]; !ok || len(b.Requests) != 1 {
+		t.Errorf("expected the request of the user, got %v", b.Requests)
+	}
+	if len(b.Participations) != 1 {
+		t.Fatalf("expected the participation in request a, got %+v", b.Participations)
+	}
+	p := b.Participations[0]
+	if p.RequestID != "a" || !p.Volunteer || p.State != "COMPLETED" || len(p.Answers) != 1 || len(p.Ratings) != 2 {
+		t.Errorf("unexpected participation %+v", p)
+	}
+	if compact(t, b.NotificationPreferences) != `{"disabled":true}` {
+		t.Errorf("unexpected preferences %s", b.NotificationPreferences)
+	}
+	if _, ok := b.UndeliveredNotifications["d1"]; !ok || len(b.UndeliveredNotifications) != 1 {
+		t.Errorf("expected the undelivered notification of the user, got %v", b.UndeliveredNotifications)
+	}
+
+	_, err = svc.ExportMyData(context.Background(), &userspb.ExportMyDataRequest{UserId: "Green"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestEraseUser(t *testing.T) {
+	svc := getTestService()
+	outbox := storagetest.Outbox(nil)
+	svc.users.SetOutbox(outbox)
+	svc.requests.SetOutbox(outbox)
+	// events not delivered yet when the user is erased
+	if err := svc.users.Update(context.Background(), "Blue", &userspb.User{Name: "Blue", Address: &userspb.User_Address{Postcode: "12345"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.requests.Update(context.Background(), "b", &requestspb.Request{Title: "help walking the dog", Body: "I live at 1 Main St", RequesterId: "Blue"}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := svc.EraseUser(context.Background(), &userspb.EraseUserRequest{UserId: "Blue"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Requests != 2 || resp.Notifications != 1 || resp.ErasureDate == "" {
+		t.Errorf("unexpected erasure %+v", resp)
+	}
+
+	if _, err := svc.users.Get("Blue"); err != storage.ErrNotFound {
+		t.Errorf("expected the user to be deleted, got %v", err)
+	}
+	if _, err := svc.preferences.Get("Blue"); err != storage.ErrNotFound {
+		t.Errorf("expected the preferences to be deleted, got %v", err)
+	}
+	if letters := svc.deadLetters.All(); len(letters) != 1 || letters["d2"] == nil {
+		t.Errorf("expected only the dead letter of Brown to be kept, got %v", letters)
+	}
+
+	a, _ := svc.requests.Get("a")
+	pseudonym := a.VolunteerId
+	if !storage.IsPseudonym(pseudonym) {
+		t.Fatalf("expected a pseudonym, got %s", pseudonym)
+	}
+	want := &requestspb.Request{
+		Title:       "help with groceries",
+		RequesterId: "Brown",
+		VolunteerId: pseudonym,
+		State:       requestspb.Request_COMPLETED,
+		Answers:     []*requestspb.Request_Answer{{VolunteerId: pseudonym}},
+		Ratings: []*requestspb.Request_Rating{
+			{RaterId: "Brown", RateeId: pseudonym, Score: 4},
+			{RaterId: pseudonym, RateeId: "Brown", Score: 5},
+		},
+	}
+	if diff := cmp.Diff(want, a); diff != "" {
+		t.Errorf("request a differs (-want +got):\n%s", diff)
+	}
+	b, _ := svc.requests.Get("b")
+	if diff := cmp.Diff(&requestspb.Request{RequesterId: pseudonym, State: requestspb.Request_WAITING}, b); diff != "" {
+		t.Errorf("request b differs (-want +got):\n%s", diff)
+	}
+	// the ratings given by the user still count
+	if brown, _ := svc.users.Get("Brown"); brown.Reputation.Score != 5 {
+		t.Errorf("expected the reputation of Brown to be kept, got %v", brown.Reputation)
+	}
+
+	var erased *webhookspb.Event
+	for _, e := range outbox.Since(0, 100) {
+		if e.Type == storage.UserErased {
+			erased = e
+		}
+		if e.EntityType == "user" && e.EntityId == "Blue" && e.Payload != "{}" {
+			t.Errorf("expected the pending %s event to be erased, got %s", e.Type, e.Payload)
+		}
+		if strings.Contains(e.Payload, "Blue") || strings.Contains(e.Payload, "Main St") || strings.Contains(e.Payload, "walking the dog") {
+			t.Errorf("expected the pending %s event of %s to be erased, got %s", e.Type, e.EntityId, e.Payload)
+		}
+	}
+	if erased == nil || erased.EntityId != "Blue" || erased.Payload != "{}" {
+		t.Errorf("expected the erasure to be recorded without the user, got %v", erased)
+	}
+
+	_, err = svc.EraseUser(context.Background(), &userspb.EraseUserRequest{UserId: "Blue"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound erasing again, got %v", err)
+	}
+}
+
+func compact(t *testing.T, m json.RawMessage) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, m); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
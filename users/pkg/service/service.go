@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/euvsvirus-banan/backend/internal/storage"
 	"github.com/euvsvirus-banan/backend/internal/version"
@@ -13,14 +14,32 @@ import (
 )
 
 type Service struct {
-	logger *logrus.Entry
-	users  *storage.UsersStorage
+	logger        *logrus.Entry
+	users         *storage.UsersStorage
+	requests      *storage.RequestsStorage
+	preferences   *storage.PreferencesStorage
+	deadLetters   *storage.DeadLettersStorage
+	policyVersion string
 }
 
-func New(logger *logrus.Entry, userData *storage.UsersStorage) *Service {
+// New returns the users service, the requests, notification preferences and dead letters are read
+// and erased along with the users by ExportMyData and EraseUser. The users are asked to accept the
+// privacy policy again once policyVersion changes, any version is accepted if it's empty.
+func New(
+	logger *logrus.Entry,
+	userData *storage.UsersStorage,
+	requestData *storage.RequestsStorage,
+	preferenceData *storage.PreferencesStorage,
+	deadLetterData *storage.DeadLettersStorage,
+	policyVersion string,
+) *Service {
 	return &Service{
-		logger: logger,
-		users:  userData,
+		logger:        logger,
+		users:         userData,
+		requests:      requestData,
+		preferences:   preferenceData,
+		deadLetters:   deadLetterData,
+		policyVersion: policyVersion,
 	}
 }
 
@@ -35,8 +54,13 @@ func (svc *Service) GetVersion(ctx context.Context, req *userspb.GetVersionReque
 }
 
 func (svc *Service) AddUser(ctx context.Context, req *userspb.AddUserRequest) (*userspb.AddUserResponse, error) {
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing user")
+	}
 	id := uuid.New().String()
-	if err := svc.users.Add(id, req.User); err != nil {
+	req.User.Reputation = nil
+	req.User.Consents = nil
+	if err := svc.users.Add(ctx, id, req.User); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &userspb.AddUserResponse{
@@ -45,21 +69,29 @@ func (svc *Service) AddUser(ctx context.Context, req *userspb.AddUserRequest) (*
 }
 
 func (svc *Service) DeleteUser(ctx context.Context, req *userspb.DeleteUserRequest) (*userspb.DeleteUserResponse, error) {
-	if err := svc.users.Delete(req.UserId); err != nil {
+	if err := svc.users.Delete(ctx, req.UserId); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &userspb.DeleteUserResponse{}, nil
 }
 
 func (svc *Service) UpdateUser(ctx context.Context, req *userspb.UpdateUserRequest) (*userspb.UpdateUserResponse, error) {
-	if err := svc.users.Update(req.UserId, req.User); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing user")
+	}
+	if req.ClearContactDetails && len(req.User.ContactDetails) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "contact details given while clearing them")
+	}
+	// the reputation and consents are kept, and so are the contact details not shared as they
+	// aren't returned
+	u, err := svc.users.UpdateProfile(ctx, req.UserId, req.User, req.ClearContactDetails)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
 	}
-	u, err := svc.users.Get(req.UserId)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return &userspb.UpdateUserResponse{User: u}, nil
+	return &userspb.UpdateUserResponse{User: storage.Shared(u)}, nil
 }
 
 func (svc *Service) GetUsers(req *userspb.GetUsersRequest, stream userspb.UsersRPC_GetUsersServer) error {
@@ -67,7 +99,7 @@ func (svc *Service) GetUsers(req *userspb.GetUsersRequest, stream userspb.UsersR
 		if err := stream.Send(
 			&userspb.GetUsersResponse{
 				UserId: id,
-				User:   user,
+				User:   storage.Shared(user),
 			},
 		); err != nil {
 			return status.Error(codes.Unknown, err.Error())
@@ -82,7 +114,7 @@ func (svc *Service) GetUserByID(ctx context.Context, req *userspb.GetUserByIDReq
 		return nil, status.Error(codes.NotFound, "user not found")
 	}
 	return &userspb.GetUserByIDResponse{
-		User: user,
+		User: storage.Shared(user),
 	}, nil
 }
 
@@ -94,7 +126,7 @@ func (svc *Service) SearchUsersByPostcode(req *userspb.SearchUsersByPostcodeRequ
 		if err := stream.Send(
 			&userspb.SearchUsersByPostcodeResponse{
 				UserId: id,
-				User:   user,
+				User:   storage.Shared(user),
 			},
 		); err != nil {
 			return status.Error(codes.Unknown, err.Error())
@@ -102,3 +134,17 @@ func (svc *Service) SearchUsersByPostcode(req *userspb.SearchUsersByPostcodeRequ
 	}
 	return nil
 }
+
+func (svc *Service) GetUserReputation(ctx context.Context, req *userspb.GetUserReputationRequest) (*userspb.GetUserReputationResponse, error) {
+	user, err := svc.users.Get(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	reputation := user.Reputation
+	if reputation == nil {
+		reputation = &userspb.User_Reputation{}
+	}
+	return &userspb.GetUserReputationResponse{
+		Reputation: reputation,
+	}, nil
+}
@@ -5,6 +5,7 @@ package userspb
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
@@ -63,14 +64,47 @@ func (User_ContactDetails_Platform) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_0d81801f7458a2ec, []int{0, 1, 0}
 }
 
+type User_Consent_Purpose int32
+
+const (
+	User_Consent_PRIVACY_POLICY  User_Consent_Purpose = 0
+	User_Consent_CONTACT_SHARING User_Consent_Purpose = 1
+	User_Consent_MARKETING       User_Consent_Purpose = 2
+)
+
+var User_Consent_Purpose_name = map[int32]string{
+	0: "PRIVACY_POLICY",
+	1: "CONTACT_SHARING",
+	2: "MARKETING",
+}
+
+var User_Consent_Purpose_value = map[string]int32{
+	"PRIVACY_POLICY":  0,
+	"CONTACT_SHARING": 1,
+	"MARKETING":       2,
+}
+
+func (x User_Consent_Purpose) String() string {
+	return proto.EnumName(User_Consent_Purpose_name, int32(x))
+}
+
+func (User_Consent_Purpose) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{0, 3, 0}
+}
+
 type User struct {
-	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Address              *User_Address          `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	ContactDetails       []*User_ContactDetails `protobuf:"bytes,3,rep,name=contact_details,json=contactDetails,proto3" json:"contact_details,omitempty"`
-	Skills               []string               `protobuf:"bytes,4,rep,name=skills,proto3" json:"skills,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Name    string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address *User_Address `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Only returned to the other users when the user consented to CONTACT_SHARING
+	ContactDetails []*User_ContactDetails `protobuf:"bytes,3,rep,name=contact_details,json=contactDetails,proto3" json:"contact_details,omitempty"`
+	Skills         []string               `protobuf:"bytes,4,rep,name=skills,proto3" json:"skills,omitempty"`
+	Reputation     *User_Reputation       `protobuf:"bytes,5,opt,name=reputation,proto3" json:"reputation,omitempty"`
+	// Consents given and revoked by the user, the oldest first, the latest of each purpose is in
+	// effect. They're only recorded by GrantConsent and RevokeConsent.
+	Consents             []*User_Consent `protobuf:"bytes,6,rep,name=consents,proto3" json:"consents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *User) Reset()         { *m = User{} }
@@ -134,6 +168,20 @@ func (m *User) GetSkills() []string {
 	return nil
 }
 
+func (m *User) GetReputation() *User_Reputation {
+	if m != nil {
+		return m.Reputation
+	}
+	return nil
+}
+
+func (m *User) GetConsents() []*User_Consent {
+	if m != nil {
+		return m.Consents
+	}
+	return nil
+}
+
 type User_Address struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	City                 string   `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
@@ -260,6 +308,150 @@ func (m *User_ContactDetails) GetIdentifier() string {
 	return ""
 }
 
+type User_Reputation struct {
+	Score                float64  `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Ratings              uint32   `protobuf:"varint,2,opt,name=ratings,proto3" json:"ratings,omitempty"`
+	Total                uint32   `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *User_Reputation) Reset()         { *m = User_Reputation{} }
+func (m *User_Reputation) String() string { return proto.CompactTextString(m) }
+func (*User_Reputation) ProtoMessage()    {}
+func (*User_Reputation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{0, 2}
+}
+func (m *User_Reputation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *User_Reputation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_User_Reputation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *User_Reputation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_User_Reputation.Merge(m, src)
+}
+func (m *User_Reputation) XXX_Size() int {
+	return m.Size()
+}
+func (m *User_Reputation) XXX_DiscardUnknown() {
+	xxx_messageInfo_User_Reputation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_User_Reputation proto.InternalMessageInfo
+
+func (m *User_Reputation) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *User_Reputation) GetRatings() uint32 {
+	if m != nil {
+		return m.Ratings
+	}
+	return 0
+}
+
+func (m *User_Reputation) GetTotal() uint32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type User_Consent struct {
+	Purpose User_Consent_Purpose `protobuf:"varint,1,opt,name=purpose,proto3,enum=userspb.User_Consent_Purpose" json:"purpose,omitempty"`
+	Granted bool                 `protobuf:"varint,2,opt,name=granted,proto3" json:"granted,omitempty"`
+	// Version of the privacy policy accepted, only set for PRIVACY_POLICY
+	PolicyVersion string `protobuf:"bytes,3,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
+	Date          string `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	// Where the consent was given or revoked, like web, app or support
+	Source               string   `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *User_Consent) Reset()         { *m = User_Consent{} }
+func (m *User_Consent) String() string { return proto.CompactTextString(m) }
+func (*User_Consent) ProtoMessage()    {}
+func (*User_Consent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{0, 3}
+}
+func (m *User_Consent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *User_Consent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_User_Consent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *User_Consent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_User_Consent.Merge(m, src)
+}
+func (m *User_Consent) XXX_Size() int {
+	return m.Size()
+}
+func (m *User_Consent) XXX_DiscardUnknown() {
+	xxx_messageInfo_User_Consent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_User_Consent proto.InternalMessageInfo
+
+func (m *User_Consent) GetPurpose() User_Consent_Purpose {
+	if m != nil {
+		return m.Purpose
+	}
+	return User_Consent_PRIVACY_POLICY
+}
+
+func (m *User_Consent) GetGranted() bool {
+	if m != nil {
+		return m.Granted
+	}
+	return false
+}
+
+func (m *User_Consent) GetPolicyVersion() string {
+	if m != nil {
+		return m.PolicyVersion
+	}
+	return ""
+}
+
+func (m *User_Consent) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *User_Consent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 type GetVersionRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -559,8 +751,10 @@ func (m *DeleteUserResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_DeleteUserResponse proto.InternalMessageInfo
 
 type UpdateUserRequest struct {
-	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	User                 *User    `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	User   *User  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// clears the contact details, the hidden ones are kept when the user has none otherwise
+	ClearContactDetails  bool     `protobuf:"varint,3,opt,name=clear_contact_details,json=clearContactDetails,proto3" json:"clear_contact_details,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -613,6 +807,13 @@ func (m *UpdateUserRequest) GetUser() *User {
 	return nil
 }
 
+func (m *UpdateUserRequest) GetClearContactDetails() bool {
+	if m != nil {
+		return m.ClearContactDetails
+	}
+	return false
+}
+
 type UpdateUserResponse struct {
 	User                 *User    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -950,1478 +1151,4814 @@ func (m *SearchUsersByPostcodeResponse) GetUser() *User {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("userspb.User_ContactDetails_Platform", User_ContactDetails_Platform_name, User_ContactDetails_Platform_value)
-	proto.RegisterType((*User)(nil), "userspb.User")
-	proto.RegisterType((*User_Address)(nil), "userspb.User.Address")
-	proto.RegisterType((*User_ContactDetails)(nil), "userspb.User.ContactDetails")
-	proto.RegisterType((*GetVersionRequest)(nil), "userspb.GetVersionRequest")
-	proto.RegisterType((*GetVersionResponse)(nil), "userspb.GetVersionResponse")
-	proto.RegisterType((*AddUserRequest)(nil), "userspb.AddUserRequest")
-	proto.RegisterType((*AddUserResponse)(nil), "userspb.AddUserResponse")
-	proto.RegisterType((*DeleteUserRequest)(nil), "userspb.DeleteUserRequest")
-	proto.RegisterType((*DeleteUserResponse)(nil), "userspb.DeleteUserResponse")
-	proto.RegisterType((*UpdateUserRequest)(nil), "userspb.UpdateUserRequest")
-	proto.RegisterType((*UpdateUserResponse)(nil), "userspb.UpdateUserResponse")
-	proto.RegisterType((*GetUsersRequest)(nil), "userspb.GetUsersRequest")
-	proto.RegisterType((*GetUsersResponse)(nil), "userspb.GetUsersResponse")
-	proto.RegisterType((*GetUserByIDRequest)(nil), "userspb.GetUserByIDRequest")
-	proto.RegisterType((*GetUserByIDResponse)(nil), "userspb.GetUserByIDResponse")
-	proto.RegisterType((*SearchUsersByPostcodeRequest)(nil), "userspb.SearchUsersByPostcodeRequest")
-	proto.RegisterType((*SearchUsersByPostcodeResponse)(nil), "userspb.SearchUsersByPostcodeResponse")
+type GetUserReputationRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("users/rpc/userspb/service.proto", fileDescriptor_0d81801f7458a2ec) }
-
-var fileDescriptor_0d81801f7458a2ec = []byte{
-	// 756 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xdd, 0x6e, 0xd3, 0x4c,
-	0x10, 0xad, 0x1b, 0xb7, 0x4d, 0x26, 0xfd, 0x52, 0x67, 0xfb, 0x95, 0x1a, 0xb7, 0x0d, 0xa9, 0x25,
-	0x50, 0x84, 0x20, 0x41, 0xe9, 0x05, 0x08, 0x71, 0xe3, 0x34, 0xa6, 0xad, 0xfa, 0x93, 0xc8, 0x6d,
-	0x41, 0x88, 0x8b, 0xc8, 0xb5, 0xb7, 0xa9, 0x21, 0x8d, 0x8d, 0x77, 0x53, 0x29, 0xef, 0xc1, 0x05,
-	0xaf, 0xc0, 0x9b, 0x20, 0xae, 0x78, 0x04, 0x54, 0x24, 0x9e, 0x03, 0xed, 0x7a, 0x9d, 0x38, 0x24,
-	0x6d, 0x23, 0x71, 0xb7, 0x33, 0x73, 0xe6, 0xec, 0x99, 0xc9, 0x59, 0x07, 0x1e, 0xf4, 0x08, 0x0e,
-	0x49, 0x25, 0x0c, 0x9c, 0x0a, 0x3f, 0x05, 0x67, 0x15, 0x82, 0xc3, 0x2b, 0xcf, 0xc1, 0xe5, 0x20,
-	0xf4, 0xa9, 0x8f, 0x16, 0x44, 0x5a, 0xff, 0x9d, 0x02, 0xf9, 0x94, 0xe0, 0x10, 0x21, 0x90, 0xbb,
-	0xf6, 0x25, 0x56, 0xa5, 0xa2, 0x54, 0xca, 0x58, 0xfc, 0x8c, 0x2a, 0xb0, 0x60, 0xbb, 0x6e, 0x88,
-	0x09, 0x51, 0x67, 0x8b, 0x52, 0x29, 0x5b, 0x5d, 0x29, 0x8b, 0xbe, 0x32, 0xeb, 0x29, 0x1b, 0x51,
-	0xd1, 0x8a, 0x51, 0xc8, 0x84, 0x25, 0xc7, 0xef, 0x52, 0xdb, 0xa1, 0x2d, 0x17, 0x53, 0xdb, 0xeb,
-	0x10, 0x35, 0x55, 0x4c, 0x95, 0xb2, 0xd5, 0xf5, 0xd1, 0xc6, 0xed, 0x08, 0x54, 0x8f, 0x30, 0x56,
-	0xce, 0x19, 0x89, 0xd1, 0x3d, 0x98, 0x27, 0x1f, 0xbd, 0x4e, 0x87, 0xa8, 0x72, 0x31, 0x55, 0xca,
-	0x58, 0x22, 0xd2, 0x2e, 0x61, 0x41, 0x5c, 0x89, 0xd4, 0xa1, 0xb4, 0x48, 0xf1, 0x40, 0x03, 0x02,
-	0xd9, 0xf1, 0x68, 0x9f, 0x2b, 0xce, 0x58, 0xfc, 0x8c, 0x34, 0x48, 0x07, 0x3e, 0xa1, 0x8e, 0xef,
-	0x62, 0x35, 0xc5, 0xf3, 0x83, 0x98, 0x31, 0x39, 0x7e, 0xaf, 0x4b, 0xc3, 0xbe, 0x2a, 0x47, 0x4c,
-	0x22, 0xd4, 0xbe, 0x4b, 0x90, 0x1b, 0x55, 0x8a, 0x0c, 0x48, 0x07, 0x1d, 0x9b, 0x9e, 0xfb, 0xe1,
-	0x25, 0xbf, 0x37, 0x57, 0x7d, 0x78, 0xdb, 0x64, 0xe5, 0xa6, 0x00, 0x5b, 0x83, 0x36, 0x54, 0x00,
-	0xf0, 0x5c, 0xdc, 0xa5, 0xde, 0xb9, 0x87, 0x43, 0xa1, 0x32, 0x91, 0xd1, 0x4f, 0x21, 0x1d, 0x77,
-	0xa1, 0x0c, 0xcc, 0x35, 0x77, 0x1b, 0x47, 0xa6, 0x32, 0xc3, 0x8e, 0xe6, 0xa1, 0xb1, 0x77, 0xa0,
-	0x48, 0x68, 0x11, 0xd2, 0x6f, 0x77, 0x8d, 0x93, 0x63, 0xa3, 0xd9, 0x54, 0x66, 0x59, 0xf4, 0xda,
-	0xd8, 0x36, 0x6b, 0x8d, 0xc6, 0xbe, 0x92, 0x62, 0xd1, 0x89, 0x79, 0x60, 0xee, 0x58, 0xc6, 0xa1,
-	0x22, 0xb3, 0xa6, 0xe3, 0xfd, 0x77, 0x4d, 0x53, 0x99, 0xd3, 0x97, 0x21, 0xbf, 0x83, 0xe9, 0x1b,
-	0x1c, 0x12, 0xcf, 0xef, 0x5a, 0xf8, 0x53, 0x0f, 0x13, 0xaa, 0x7f, 0x95, 0x00, 0x25, 0xb3, 0x24,
-	0xf0, 0xbb, 0x84, 0xaf, 0x24, 0x08, 0xfd, 0x0f, 0xd8, 0xa1, 0xf1, 0x72, 0x45, 0xc8, 0x2a, 0x57,
-	0x11, 0x58, 0x28, 0x8f, 0x43, 0xb4, 0x01, 0x70, 0xd6, 0xf3, 0x3a, 0x6e, 0xcb, 0xb5, 0x69, 0xbc,
-	0xe4, 0x0c, 0xcf, 0xd4, 0x6d, 0x8a, 0xd1, 0x26, 0x2c, 0xb6, 0x3d, 0xda, 0x0a, 0xf1, 0x95, 0xc7,
-	0xbb, 0xa3, 0x55, 0x67, 0xdb, 0x1e, 0xb5, 0x44, 0x8a, 0x31, 0xb4, 0xfd, 0x56, 0x4c, 0x3f, 0x17,
-	0x31, 0xb4, 0x7d, 0x21, 0x4e, 0xdf, 0x82, 0x9c, 0xe1, 0xba, 0x6c, 0xc9, 0x42, 0x3d, 0xda, 0x04,
-	0x99, 0xed, 0x9e, 0x6b, 0xcc, 0x56, 0xff, 0x1b, 0xf9, 0x21, 0x2c, 0x5e, 0xd2, 0x1f, 0xc3, 0xd2,
-	0xa0, 0x49, 0x0c, 0xb7, 0x0a, 0xdc, 0xfc, 0x2d, 0xcf, 0x15, 0xc3, 0xcd, 0xb3, 0x70, 0xcf, 0xd5,
-	0x9f, 0x40, 0xbe, 0x8e, 0x3b, 0x98, 0xe2, 0xe4, 0x1d, 0x37, 0xa2, 0xff, 0x07, 0x94, 0x44, 0x47,
-	0xe4, 0x7a, 0x03, 0xf2, 0xa7, 0x01, 0xdb, 0xc0, 0x34, 0x1c, 0x83, 0x01, 0x66, 0x6f, 0x1e, 0xe0,
-	0x39, 0xa0, 0x24, 0xa1, 0x98, 0x61, 0x8a, 0xc9, 0xf3, 0xb0, 0xb4, 0x83, 0x29, 0x4b, 0x90, 0xf8,
-	0xd7, 0x3e, 0x02, 0x65, 0x98, 0xba, 0x63, 0x1b, 0xd3, 0x68, 0x7b, 0xca, 0xcd, 0xc3, 0x12, 0xb5,
-	0xfe, 0x5e, 0xfd, 0xce, 0x8d, 0xbd, 0x80, 0xe5, 0x11, 0xf8, 0xf4, 0xb3, 0xbc, 0x84, 0xf5, 0x63,
-	0x6c, 0x87, 0xce, 0x05, 0xd7, 0x5e, 0xeb, 0x37, 0xc5, 0xdb, 0x8d, 0xaf, 0x4c, 0x3e, 0x6f, 0x69,
-	0xf4, 0x79, 0xeb, 0xef, 0x61, 0xe3, 0x86, 0xde, 0x7f, 0xdf, 0x40, 0xf5, 0xb3, 0x0c, 0xe9, 0x68,
-	0x9f, 0xcd, 0x6d, 0x64, 0x02, 0x0c, 0xdf, 0x12, 0xd2, 0x06, 0xf8, 0xb1, 0x67, 0xa7, 0xad, 0x4d,
-	0xac, 0x09, 0x3d, 0xaf, 0xf8, 0x47, 0x8e, 0x7f, 0x93, 0x57, 0x07, 0xb8, 0x51, 0xe7, 0x6b, 0xea,
-	0x78, 0x41, 0x74, 0x9b, 0x00, 0x43, 0x5b, 0x26, 0x44, 0x8c, 0x39, 0x3b, 0x21, 0x62, 0xdc, 0xc7,
-	0x8c, 0x66, 0x68, 0xbb, 0x04, 0xcd, 0x98, 0xb9, 0x13, 0x34, 0x13, 0x7c, 0x6a, 0x40, 0x3a, 0x76,
-	0x1c, 0x52, 0x93, 0x43, 0x27, 0x7d, 0xa9, 0xdd, 0x9f, 0x50, 0x89, 0x08, 0x9e, 0x49, 0x68, 0x17,
-	0xb2, 0x09, 0xd7, 0xa0, 0xb5, 0xbf, 0xb1, 0x09, 0xeb, 0x69, 0xeb, 0x93, 0x8b, 0x42, 0xcc, 0x05,
-	0xac, 0x4c, 0x74, 0x02, 0x1a, 0x7e, 0xc2, 0x6f, 0x73, 0x99, 0xf6, 0xe8, 0x2e, 0x58, 0xac, 0xb9,
-	0xa6, 0x7c, 0xbb, 0x2e, 0x48, 0x3f, 0xae, 0x0b, 0xd2, 0xcf, 0xeb, 0x82, 0xf4, 0xe5, 0x57, 0x61,
-	0xe6, 0x6c, 0x9e, 0xff, 0xed, 0x6e, 0xfd, 0x09, 0x00, 0x00, 0xff, 0xff, 0x1d, 0x9a, 0xef, 0xdf,
-	0x99, 0x07, 0x00, 0x00,
+func (m *GetUserReputationRequest) Reset()         { *m = GetUserReputationRequest{} }
+func (m *GetUserReputationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUserReputationRequest) ProtoMessage()    {}
+func (*GetUserReputationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{15}
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// UsersRPCClient is the client API for UsersRPC service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type UsersRPCClient interface {
-	// Returns software version and build details
-	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
-	// Adds a new user
-	AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*AddUserResponse, error)
-	// Deletes an existing user
-	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
-	// Updates an existing user, method requires passing all the information about the user as
-	// the object is simply replaced
-	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
-	// Returns all the users in the system
-	GetUsers(ctx context.Context, in *GetUsersRequest, opts ...grpc.CallOption) (UsersRPC_GetUsersClient, error)
-	GetUserByID(ctx context.Context, in *GetUserByIDRequest, opts ...grpc.CallOption) (*GetUserByIDResponse, error)
-	SearchUsersByPostcode(ctx context.Context, in *SearchUsersByPostcodeRequest, opts ...grpc.CallOption) (UsersRPC_SearchUsersByPostcodeClient, error)
+func (m *GetUserReputationRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-type usersRPCClient struct {
-	cc *grpc.ClientConn
+func (m *GetUserReputationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetUserReputationRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-func NewUsersRPCClient(cc *grpc.ClientConn) UsersRPCClient {
-	return &usersRPCClient{cc}
+func (m *GetUserReputationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUserReputationRequest.Merge(m, src)
 }
-
-func (c *usersRPCClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
-	out := new(GetVersionResponse)
-	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/GetVersion", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetUserReputationRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (c *usersRPCClient) AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*AddUserResponse, error) {
-	out := new(AddUserResponse)
-	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/AddUser", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetUserReputationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUserReputationRequest.DiscardUnknown(m)
 }
 
-func (c *usersRPCClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error) {
-	out := new(DeleteUserResponse)
-	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/DeleteUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetUserReputationRequest proto.InternalMessageInfo
+
+func (m *GetUserReputationRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *usersRPCClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error) {
-	out := new(UpdateUserResponse)
-	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/UpdateUser", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type GetUserReputationResponse struct {
+	Reputation           *User_Reputation `protobuf:"bytes,1,opt,name=reputation,proto3" json:"reputation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (c *usersRPCClient) GetUsers(ctx context.Context, in *GetUsersRequest, opts ...grpc.CallOption) (UsersRPC_GetUsersClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_UsersRPC_serviceDesc.Streams[0], "/userspb.UsersRPC/GetUsers", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &usersRPCGetUsersClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *GetUserReputationResponse) Reset()         { *m = GetUserReputationResponse{} }
+func (m *GetUserReputationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetUserReputationResponse) ProtoMessage()    {}
+func (*GetUserReputationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{16}
+}
+func (m *GetUserReputationResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetUserReputationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetUserReputationResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return x, nil
 }
-
-type UsersRPC_GetUsersClient interface {
-	Recv() (*GetUsersResponse, error)
-	grpc.ClientStream
+func (m *GetUserReputationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUserReputationResponse.Merge(m, src)
 }
-
-type usersRPCGetUsersClient struct {
-	grpc.ClientStream
+func (m *GetUserReputationResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetUserReputationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUserReputationResponse.DiscardUnknown(m)
 }
 
-func (x *usersRPCGetUsersClient) Recv() (*GetUsersResponse, error) {
-	m := new(GetUsersResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_GetUserReputationResponse proto.InternalMessageInfo
+
+func (m *GetUserReputationResponse) GetReputation() *User_Reputation {
+	if m != nil {
+		return m.Reputation
 	}
-	return m, nil
+	return nil
 }
 
-func (c *usersRPCClient) GetUserByID(ctx context.Context, in *GetUserByIDRequest, opts ...grpc.CallOption) (*GetUserByIDResponse, error) {
-	out := new(GetUserByIDResponse)
-	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/GetUserByID", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ExportMyDataRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *usersRPCClient) SearchUsersByPostcode(ctx context.Context, in *SearchUsersByPostcodeRequest, opts ...grpc.CallOption) (UsersRPC_SearchUsersByPostcodeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_UsersRPC_serviceDesc.Streams[1], "/userspb.UsersRPC/SearchUsersByPostcode", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &usersRPCSearchUsersByPostcodeClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *ExportMyDataRequest) Reset()         { *m = ExportMyDataRequest{} }
+func (m *ExportMyDataRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportMyDataRequest) ProtoMessage()    {}
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{17}
+}
+func (m *ExportMyDataRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportMyDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportMyDataRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return x, nil
 }
-
-type UsersRPC_SearchUsersByPostcodeClient interface {
-	Recv() (*SearchUsersByPostcodeResponse, error)
-	grpc.ClientStream
+func (m *ExportMyDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportMyDataRequest.Merge(m, src)
 }
-
-type usersRPCSearchUsersByPostcodeClient struct {
-	grpc.ClientStream
+func (m *ExportMyDataRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (x *usersRPCSearchUsersByPostcodeClient) Recv() (*SearchUsersByPostcodeResponse, error) {
-	m := new(SearchUsersByPostcodeResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *ExportMyDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportMyDataRequest.DiscardUnknown(m)
 }
 
-// UsersRPCServer is the server API for UsersRPC service.
-type UsersRPCServer interface {
-	// Returns software version and build details
-	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
-	// Adds a new user
-	AddUser(context.Context, *AddUserRequest) (*AddUserResponse, error)
-	// Deletes an existing user
-	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
-	// Updates an existing user, method requires passing all the information about the user as
-	// the object is simply replaced
-	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
-	// Returns all the users in the system
-	GetUsers(*GetUsersRequest, UsersRPC_GetUsersServer) error
-	GetUserByID(context.Context, *GetUserByIDRequest) (*GetUserByIDResponse, error)
-	SearchUsersByPostcode(*SearchUsersByPostcodeRequest, UsersRPC_SearchUsersByPostcodeServer) error
-}
+var xxx_messageInfo_ExportMyDataRequest proto.InternalMessageInfo
 
-// UnimplementedUsersRPCServer can be embedded to have forward compatible implementations.
-type UnimplementedUsersRPCServer struct {
+func (m *ExportMyDataRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
 }
 
-func (*UnimplementedUsersRPCServer) GetVersion(ctx context.Context, req *GetVersionRequest) (*GetVersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+type ExportMyDataResponse struct {
+	// JSON document holding the profile, the requests, answers and ratings, the notification
+	// preferences and the undelivered notifications of the user
+	Bundle string `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// Name suggested for the downloaded document
+	FileName             string   `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (*UnimplementedUsersRPCServer) AddUser(ctx context.Context, req *AddUserRequest) (*AddUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddUser not implemented")
+
+func (m *ExportMyDataResponse) Reset()         { *m = ExportMyDataResponse{} }
+func (m *ExportMyDataResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportMyDataResponse) ProtoMessage()    {}
+func (*ExportMyDataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{18}
 }
-func (*UnimplementedUsersRPCServer) DeleteUser(ctx context.Context, req *DeleteUserRequest) (*DeleteUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+func (m *ExportMyDataResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedUsersRPCServer) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*UpdateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+func (m *ExportMyDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportMyDataResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedUsersRPCServer) GetUsers(req *GetUsersRequest, srv UsersRPC_GetUsersServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetUsers not implemented")
+func (m *ExportMyDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportMyDataResponse.Merge(m, src)
 }
-func (*UnimplementedUsersRPCServer) GetUserByID(ctx context.Context, req *GetUserByIDRequest) (*GetUserByIDResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserByID not implemented")
+func (m *ExportMyDataResponse) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedUsersRPCServer) SearchUsersByPostcode(req *SearchUsersByPostcodeRequest, srv UsersRPC_SearchUsersByPostcodeServer) error {
-	return status.Errorf(codes.Unimplemented, "method SearchUsersByPostcode not implemented")
+func (m *ExportMyDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportMyDataResponse.DiscardUnknown(m)
 }
 
-func RegisterUsersRPCServer(s *grpc.Server, srv UsersRPCServer) {
-	s.RegisterService(&_UsersRPC_serviceDesc, srv)
-}
+var xxx_messageInfo_ExportMyDataResponse proto.InternalMessageInfo
 
-func _UsersRPC_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetVersionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UsersRPCServer).GetVersion(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/userspb.UsersRPC/GetVersion",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UsersRPCServer).GetVersion(ctx, req.(*GetVersionRequest))
+func (m *ExportMyDataResponse) GetBundle() string {
+	if m != nil {
+		return m.Bundle
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _UsersRPC_AddUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddUserRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UsersRPCServer).AddUser(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/userspb.UsersRPC/AddUser",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UsersRPCServer).AddUser(ctx, req.(*AddUserRequest))
+func (m *ExportMyDataResponse) GetFileName() string {
+	if m != nil {
+		return m.FileName
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _UsersRPC_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteUserRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UsersRPCServer).DeleteUser(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/userspb.UsersRPC/DeleteUser",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UsersRPCServer).DeleteUser(ctx, req.(*DeleteUserRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type EraseUserRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _UsersRPC_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UsersRPCServer).UpdateUser(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/userspb.UsersRPC/UpdateUser",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UsersRPCServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+func (m *EraseUserRequest) Reset()         { *m = EraseUserRequest{} }
+func (m *EraseUserRequest) String() string { return proto.CompactTextString(m) }
+func (*EraseUserRequest) ProtoMessage()    {}
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{19}
+}
+func (m *EraseUserRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EraseUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EraseUserRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *EraseUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserRequest.Merge(m, src)
+}
+func (m *EraseUserRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *EraseUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserRequest.DiscardUnknown(m)
 }
 
-func _UsersRPC_GetUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetUsersRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+var xxx_messageInfo_EraseUserRequest proto.InternalMessageInfo
+
+func (m *EraseUserRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	return srv.(UsersRPCServer).GetUsers(m, &usersRPCGetUsersServer{stream})
+	return ""
 }
 
-type UsersRPC_GetUsersServer interface {
-	Send(*GetUsersResponse) error
-	grpc.ServerStream
+type EraseUserResponse struct {
+	ErasureDate string `protobuf:"bytes,1,opt,name=erasure_date,json=erasureDate,proto3" json:"erasure_date,omitempty"`
+	// Number of requests whose references to the user were pseudonymized
+	Requests uint32 `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	// Number of undelivered notifications deleted
+	Notifications        uint32   `protobuf:"varint,3,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type usersRPCGetUsersServer struct {
-	grpc.ServerStream
+func (m *EraseUserResponse) Reset()         { *m = EraseUserResponse{} }
+func (m *EraseUserResponse) String() string { return proto.CompactTextString(m) }
+func (*EraseUserResponse) ProtoMessage()    {}
+func (*EraseUserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{20}
 }
-
-func (x *usersRPCGetUsersServer) Send(m *GetUsersResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *EraseUserResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func _UsersRPC_GetUserByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserByIDRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UsersRPCServer).GetUserByID(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/userspb.UsersRPC/GetUserByID",
+func (m *EraseUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EraseUserResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UsersRPCServer).GetUserByID(ctx, req.(*GetUserByIDRequest))
+}
+func (m *EraseUserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserResponse.Merge(m, src)
+}
+func (m *EraseUserResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *EraseUserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EraseUserResponse proto.InternalMessageInfo
+
+func (m *EraseUserResponse) GetErasureDate() string {
+	if m != nil {
+		return m.ErasureDate
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _UsersRPC_SearchUsersByPostcode_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SearchUsersByPostcodeRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *EraseUserResponse) GetRequests() uint32 {
+	if m != nil {
+		return m.Requests
 	}
-	return srv.(UsersRPCServer).SearchUsersByPostcode(m, &usersRPCSearchUsersByPostcodeServer{stream})
+	return 0
 }
 
-type UsersRPC_SearchUsersByPostcodeServer interface {
-	Send(*SearchUsersByPostcodeResponse) error
-	grpc.ServerStream
+func (m *EraseUserResponse) GetNotifications() uint32 {
+	if m != nil {
+		return m.Notifications
+	}
+	return 0
 }
 
-type usersRPCSearchUsersByPostcodeServer struct {
-	grpc.ServerStream
+type GrantConsentRequest struct {
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Purposes []User_Consent_Purpose `protobuf:"varint,2,rep,packed,name=purposes,proto3,enum=userspb.User_Consent_Purpose" json:"purposes,omitempty"`
+	// Version of the privacy policy accepted, the current version if empty
+	PolicyVersion        string   `protobuf:"bytes,3,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
+	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *usersRPCSearchUsersByPostcodeServer) Send(m *SearchUsersByPostcodeResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *GrantConsentRequest) Reset()         { *m = GrantConsentRequest{} }
+func (m *GrantConsentRequest) String() string { return proto.CompactTextString(m) }
+func (*GrantConsentRequest) ProtoMessage()    {}
+func (*GrantConsentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{21}
 }
-
-var _UsersRPC_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "userspb.UsersRPC",
-	HandlerType: (*UsersRPCServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetVersion",
-			Handler:    _UsersRPC_GetVersion_Handler,
-		},
-		{
-			MethodName: "AddUser",
-			Handler:    _UsersRPC_AddUser_Handler,
-		},
-		{
-			MethodName: "DeleteUser",
-			Handler:    _UsersRPC_DeleteUser_Handler,
-		},
-		{
-			MethodName: "UpdateUser",
-			Handler:    _UsersRPC_UpdateUser_Handler,
-		},
-		{
-			MethodName: "GetUserByID",
-			Handler:    _UsersRPC_GetUserByID_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "GetUsers",
-			Handler:       _UsersRPC_GetUsers_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "SearchUsersByPostcode",
-			Handler:       _UsersRPC_SearchUsersByPostcode_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "users/rpc/userspb/service.proto",
+func (m *GrantConsentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *User) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GrantConsentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GrantConsentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *User) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GrantConsentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantConsentRequest.Merge(m, src)
+}
+func (m *GrantConsentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GrantConsentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantConsentRequest.DiscardUnknown(m)
 }
 
-func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Skills) > 0 {
-		for iNdEx := len(m.Skills) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Skills[iNdEx])
-			copy(dAtA[i:], m.Skills[iNdEx])
-			i = encodeVarintService(dAtA, i, uint64(len(m.Skills[iNdEx])))
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if len(m.ContactDetails) > 0 {
-		for iNdEx := len(m.ContactDetails) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.ContactDetails[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintService(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+var xxx_messageInfo_GrantConsentRequest proto.InternalMessageInfo
+
+func (m *GrantConsentRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	if m.Address != nil {
-		{
-			size, err := m.Address.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintService(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	return ""
+}
+
+func (m *GrantConsentRequest) GetPurposes() []User_Consent_Purpose {
+	if m != nil {
+		return m.Purposes
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintService(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *GrantConsentRequest) GetPolicyVersion() string {
+	if m != nil {
+		return m.PolicyVersion
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *User_Address) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GrantConsentRequest) GetSource() string {
+	if m != nil {
+		return m.Source
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *User_Address) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type GrantConsentResponse struct {
+	// Consents in effect, one per purpose
+	Consents             []*User_Consent `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *User_Address) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Country) > 0 {
-		i -= len(m.Country)
-		copy(dAtA[i:], m.Country)
-		i = encodeVarintService(dAtA, i, uint64(len(m.Country)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Postcode) > 0 {
-		i -= len(m.Postcode)
-		copy(dAtA[i:], m.Postcode)
-		i = encodeVarintService(dAtA, i, uint64(len(m.Postcode)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.City) > 0 {
-		i -= len(m.City)
-		copy(dAtA[i:], m.City)
-		i = encodeVarintService(dAtA, i, uint64(len(m.City)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Address) > 0 {
-		i -= len(m.Address)
-		copy(dAtA[i:], m.Address)
-		i = encodeVarintService(dAtA, i, uint64(len(m.Address)))
-		i--
-		dAtA[i] = 0xa
+func (m *GrantConsentResponse) Reset()         { *m = GrantConsentResponse{} }
+func (m *GrantConsentResponse) String() string { return proto.CompactTextString(m) }
+func (*GrantConsentResponse) ProtoMessage()    {}
+func (*GrantConsentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{22}
+}
+func (m *GrantConsentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GrantConsentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GrantConsentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *GrantConsentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantConsentResponse.Merge(m, src)
+}
+func (m *GrantConsentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GrantConsentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantConsentResponse.DiscardUnknown(m)
 }
 
-func (m *User_ContactDetails) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GrantConsentResponse proto.InternalMessageInfo
+
+func (m *GrantConsentResponse) GetConsents() []*User_Consent {
+	if m != nil {
+		return m.Consents
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *User_ContactDetails) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RevokeConsentRequest struct {
+	UserId               string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Purposes             []User_Consent_Purpose `protobuf:"varint,2,rep,packed,name=purposes,proto3,enum=userspb.User_Consent_Purpose" json:"purposes,omitempty"`
+	Source               string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *User_ContactDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Identifier) > 0 {
-		i -= len(m.Identifier)
-		copy(dAtA[i:], m.Identifier)
-		i = encodeVarintService(dAtA, i, uint64(len(m.Identifier)))
-		i--
-		dAtA[i] = 0x12
+func (m *RevokeConsentRequest) Reset()         { *m = RevokeConsentRequest{} }
+func (m *RevokeConsentRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeConsentRequest) ProtoMessage()    {}
+func (*RevokeConsentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{23}
+}
+func (m *RevokeConsentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeConsentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeConsentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Platform != 0 {
-		i = encodeVarintService(dAtA, i, uint64(m.Platform))
-		i--
-		dAtA[i] = 0x8
+}
+func (m *RevokeConsentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeConsentRequest.Merge(m, src)
+}
+func (m *RevokeConsentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeConsentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeConsentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeConsentRequest proto.InternalMessageInfo
+
+func (m *RevokeConsentRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *GetVersionRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RevokeConsentRequest) GetPurposes() []User_Consent_Purpose {
+	if m != nil {
+		return m.Purposes
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetVersionRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GetVersionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *RevokeConsentRequest) GetSource() string {
+	if m != nil {
+		return m.Source
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *GetVersionResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type RevokeConsentResponse struct {
+	// Consents in effect, one per purpose
+	Consents             []*User_Consent `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetVersionResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RevokeConsentResponse) Reset()         { *m = RevokeConsentResponse{} }
+func (m *RevokeConsentResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeConsentResponse) ProtoMessage()    {}
+func (*RevokeConsentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{24}
 }
-
-func (m *GetVersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.GoVersion) > 0 {
-		i -= len(m.GoVersion)
-		copy(dAtA[i:], m.GoVersion)
-		i = encodeVarintService(dAtA, i, uint64(len(m.GoVersion)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.GitRevision) > 0 {
-		i -= len(m.GitRevision)
-		copy(dAtA[i:], m.GitRevision)
-		i = encodeVarintService(dAtA, i, uint64(len(m.GitRevision)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.BuildDate) > 0 {
-		i -= len(m.BuildDate)
-		copy(dAtA[i:], m.BuildDate)
-		i = encodeVarintService(dAtA, i, uint64(len(m.BuildDate)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Version) > 0 {
-		i -= len(m.Version)
-		copy(dAtA[i:], m.Version)
-		i = encodeVarintService(dAtA, i, uint64(len(m.Version)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Project) > 0 {
-		i -= len(m.Project)
-		copy(dAtA[i:], m.Project)
-		i = encodeVarintService(dAtA, i, uint64(len(m.Project)))
-		i--
-		dAtA[i] = 0xa
+func (m *RevokeConsentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeConsentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeConsentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RevokeConsentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeConsentResponse.Merge(m, src)
+}
+func (m *RevokeConsentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeConsentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeConsentResponse.DiscardUnknown(m)
 }
 
-func (m *AddUserRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RevokeConsentResponse proto.InternalMessageInfo
+
+func (m *RevokeConsentResponse) GetConsents() []*User_Consent {
+	if m != nil {
+		return m.Consents
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AddUserRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type GetConsentRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddUserRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.User != nil {
-		{
-			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintService(dAtA, i, uint64(size))
+func (m *GetConsentRequest) Reset()         { *m = GetConsentRequest{} }
+func (m *GetConsentRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConsentRequest) ProtoMessage()    {}
+func (*GetConsentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{25}
+}
+func (m *GetConsentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetConsentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetConsentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *AddUserResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *GetConsentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsentRequest.Merge(m, src)
 }
-
-func (m *AddUserResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GetConsentRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *AddUserResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *GetConsentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsentRequest.DiscardUnknown(m)
 }
 
-func (m *DeleteUserRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetConsentRequest proto.InternalMessageInfo
+
+func (m *GetConsentRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *DeleteUserRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type GetConsentResponse struct {
+	// Consents in effect, one per purpose
+	Consents []*User_Consent `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
+	// Current version of the privacy policy
+	PolicyVersion string `protobuf:"bytes,2,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
+	// Set when the user didn't accept the current privacy policy, they should be asked to
+	ReconsentRequired    bool     `protobuf:"varint,3,opt,name=reconsent_required,json=reconsentRequired,proto3" json:"reconsent_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteUserRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *GetConsentResponse) Reset()         { *m = GetConsentResponse{} }
+func (m *GetConsentResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConsentResponse) ProtoMessage()    {}
+func (*GetConsentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d81801f7458a2ec, []int{26}
 }
-
-func (m *DeleteUserResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GetConsentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetConsentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetConsentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *DeleteUserResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GetConsentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsentResponse.Merge(m, src)
+}
+func (m *GetConsentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetConsentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsentResponse.DiscardUnknown(m)
 }
 
-func (m *DeleteUserResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_GetConsentResponse proto.InternalMessageInfo
+
+func (m *GetConsentResponse) GetConsents() []*User_Consent {
+	if m != nil {
+		return m.Consents
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *UpdateUserRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GetConsentResponse) GetPolicyVersion() string {
+	if m != nil {
+		return m.PolicyVersion
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *UpdateUserRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GetConsentResponse) GetReconsentRequired() bool {
+	if m != nil {
+		return m.ReconsentRequired
+	}
+	return false
 }
 
-func (m *UpdateUserRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.User != nil {
-		{
-			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintService(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func init() {
+	proto.RegisterEnum("userspb.User_ContactDetails_Platform", User_ContactDetails_Platform_name, User_ContactDetails_Platform_value)
+	proto.RegisterEnum("userspb.User_Consent_Purpose", User_Consent_Purpose_name, User_Consent_Purpose_value)
+	proto.RegisterType((*User)(nil), "userspb.User")
+	proto.RegisterType((*User_Address)(nil), "userspb.User.Address")
+	proto.RegisterType((*User_ContactDetails)(nil), "userspb.User.ContactDetails")
+	proto.RegisterType((*User_Reputation)(nil), "userspb.User.Reputation")
+	proto.RegisterType((*User_Consent)(nil), "userspb.User.Consent")
+	proto.RegisterType((*GetVersionRequest)(nil), "userspb.GetVersionRequest")
+	proto.RegisterType((*GetVersionResponse)(nil), "userspb.GetVersionResponse")
+	proto.RegisterType((*AddUserRequest)(nil), "userspb.AddUserRequest")
+	proto.RegisterType((*AddUserResponse)(nil), "userspb.AddUserResponse")
+	proto.RegisterType((*DeleteUserRequest)(nil), "userspb.DeleteUserRequest")
+	proto.RegisterType((*DeleteUserResponse)(nil), "userspb.DeleteUserResponse")
+	proto.RegisterType((*UpdateUserRequest)(nil), "userspb.UpdateUserRequest")
+	proto.RegisterType((*UpdateUserResponse)(nil), "userspb.UpdateUserResponse")
+	proto.RegisterType((*GetUsersRequest)(nil), "userspb.GetUsersRequest")
+	proto.RegisterType((*GetUsersResponse)(nil), "userspb.GetUsersResponse")
+	proto.RegisterType((*GetUserByIDRequest)(nil), "userspb.GetUserByIDRequest")
+	proto.RegisterType((*GetUserByIDResponse)(nil), "userspb.GetUserByIDResponse")
+	proto.RegisterType((*SearchUsersByPostcodeRequest)(nil), "userspb.SearchUsersByPostcodeRequest")
+	proto.RegisterType((*SearchUsersByPostcodeResponse)(nil), "userspb.SearchUsersByPostcodeResponse")
+	proto.RegisterType((*GetUserReputationRequest)(nil), "userspb.GetUserReputationRequest")
+	proto.RegisterType((*GetUserReputationResponse)(nil), "userspb.GetUserReputationResponse")
+	proto.RegisterType((*ExportMyDataRequest)(nil), "userspb.ExportMyDataRequest")
+	proto.RegisterType((*ExportMyDataResponse)(nil), "userspb.ExportMyDataResponse")
+	proto.RegisterType((*EraseUserRequest)(nil), "userspb.EraseUserRequest")
+	proto.RegisterType((*EraseUserResponse)(nil), "userspb.EraseUserResponse")
+	proto.RegisterType((*GrantConsentRequest)(nil), "userspb.GrantConsentRequest")
+	proto.RegisterType((*GrantConsentResponse)(nil), "userspb.GrantConsentResponse")
+	proto.RegisterType((*RevokeConsentRequest)(nil), "userspb.RevokeConsentRequest")
+	proto.RegisterType((*RevokeConsentResponse)(nil), "userspb.RevokeConsentResponse")
+	proto.RegisterType((*GetConsentRequest)(nil), "userspb.GetConsentRequest")
+	proto.RegisterType((*GetConsentResponse)(nil), "userspb.GetConsentResponse")
 }
 
-func (m *UpdateUserResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func init() { proto.RegisterFile("users/rpc/userspb/service.proto", fileDescriptor_0d81801f7458a2ec) }
+
+var fileDescriptor_0d81801f7458a2ec = []byte{
+	// 1315 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x5f, 0x53, 0x1b, 0x55,
+	0x14, 0xef, 0x92, 0x40, 0x92, 0x03, 0x81, 0x70, 0x81, 0x76, 0xbb, 0x14, 0xa4, 0x3b, 0xd6, 0x61,
+	0xb4, 0x0d, 0x4a, 0x1f, 0x5a, 0x1d, 0x5f, 0x96, 0x10, 0x21, 0x52, 0x20, 0x73, 0x81, 0x3a, 0xd5,
+	0x87, 0xcc, 0xb2, 0x7b, 0x4b, 0xd7, 0x86, 0xdd, 0xf5, 0xee, 0x0d, 0x53, 0x5e, 0xf5, 0x4b, 0xf8,
+	0xa0, 0xef, 0xfa, 0x4d, 0x1c, 0x9f, 0xfc, 0x08, 0x4e, 0x1d, 0xbf, 0x80, 0x9f, 0xc0, 0xb9, 0x7f,
+	0xf6, 0x1f, 0x59, 0x48, 0x46, 0x1d, 0xdf, 0xf6, 0xfc, 0xbd, 0xe7, 0x77, 0xee, 0xc9, 0xf9, 0xdd,
+	0xc0, 0x3b, 0x83, 0x88, 0xd0, 0x68, 0x83, 0x86, 0xce, 0x86, 0xf8, 0x0a, 0x4f, 0x37, 0x22, 0x42,
+	0x2f, 0x3c, 0x87, 0x34, 0x43, 0x1a, 0xb0, 0x00, 0x55, 0x94, 0xda, 0xfc, 0xa1, 0x02, 0xe5, 0x93,
+	0x88, 0x50, 0x84, 0xa0, 0xec, 0xdb, 0xe7, 0x44, 0xd7, 0xd6, 0xb4, 0xf5, 0x1a, 0x16, 0xdf, 0x68,
+	0x03, 0x2a, 0xb6, 0xeb, 0x52, 0x12, 0x45, 0xfa, 0xc4, 0x9a, 0xb6, 0x3e, 0xbd, 0xb9, 0xd4, 0x54,
+	0x71, 0x4d, 0x1e, 0xd3, 0xb4, 0xa4, 0x11, 0xc7, 0x5e, 0xa8, 0x0d, 0x73, 0x4e, 0xe0, 0x33, 0xdb,
+	0x61, 0x3d, 0x97, 0x30, 0xdb, 0xeb, 0x47, 0x7a, 0x69, 0xad, 0xb4, 0x3e, 0xbd, 0x79, 0x2f, 0x1f,
+	0xd8, 0x92, 0x4e, 0xdb, 0xd2, 0x07, 0xcf, 0x3a, 0x39, 0x19, 0xdd, 0x86, 0xa9, 0xe8, 0xb5, 0xd7,
+	0xef, 0x47, 0x7a, 0x79, 0xad, 0xb4, 0x5e, 0xc3, 0x4a, 0x42, 0x4f, 0x01, 0x28, 0x09, 0x07, 0xcc,
+	0x66, 0x5e, 0xe0, 0xeb, 0x93, 0xa2, 0x24, 0x3d, 0x9f, 0x19, 0x27, 0x76, 0x9c, 0xf1, 0x45, 0x1f,
+	0x41, 0xd5, 0x09, 0xfc, 0x88, 0xf8, 0x2c, 0xd2, 0xa7, 0xd6, 0x4a, 0xc3, 0x50, 0x5a, 0xd2, 0x8a,
+	0x13, 0x37, 0xe3, 0x1c, 0x2a, 0x0a, 0x1f, 0xd2, 0xd3, 0x3e, 0xc8, 0xf6, 0x24, 0x80, 0x11, 0x94,
+	0x1d, 0x8f, 0x5d, 0x8a, 0xf6, 0xd4, 0xb0, 0xf8, 0x46, 0x06, 0x54, 0xc3, 0x20, 0x62, 0x4e, 0xe0,
+	0x12, 0xbd, 0x24, 0xf4, 0x89, 0xcc, 0x33, 0x39, 0xc1, 0xc0, 0x67, 0xf4, 0x52, 0x2f, 0xcb, 0x4c,
+	0x4a, 0x34, 0x7e, 0xd5, 0x60, 0x36, 0xdf, 0x16, 0x64, 0x41, 0x35, 0xec, 0xdb, 0xec, 0x65, 0x40,
+	0xcf, 0xc5, 0xb9, 0xb3, 0x9b, 0x0f, 0x6e, 0x6a, 0x63, 0xb3, 0xab, 0x9c, 0x71, 0x12, 0x86, 0x56,
+	0x01, 0x3c, 0x97, 0xf8, 0xcc, 0x7b, 0xe9, 0x11, 0xaa, 0xaa, 0xcc, 0x68, 0xcc, 0x13, 0xa8, 0xc6,
+	0x51, 0xa8, 0x06, 0x93, 0xdd, 0xdd, 0xc3, 0x83, 0x76, 0xe3, 0x16, 0xff, 0x6c, 0xef, 0x5b, 0x9d,
+	0x67, 0x0d, 0x0d, 0xcd, 0x40, 0xf5, 0x8b, 0x5d, 0xeb, 0xf8, 0xc8, 0xea, 0x76, 0x1b, 0x13, 0x5c,
+	0xfa, 0xcc, 0x6a, 0xb5, 0xb7, 0x0e, 0x0f, 0xf7, 0x1a, 0x25, 0x2e, 0x1d, 0xb7, 0x9f, 0xb5, 0x77,
+	0xb0, 0xb5, 0xdf, 0x28, 0xf3, 0xa0, 0xa3, 0xbd, 0x17, 0xdd, 0x76, 0x63, 0xd2, 0xc0, 0x00, 0xe9,
+	0x45, 0xa0, 0x45, 0x98, 0x8c, 0x9c, 0x80, 0xca, 0xd9, 0xd2, 0xb0, 0x14, 0x78, 0x2b, 0xa8, 0xcd,
+	0x3c, 0xff, 0x4c, 0x0e, 0x57, 0x1d, 0xc7, 0x22, 0xf7, 0x67, 0x01, 0xb3, 0xfb, 0xa2, 0x7b, 0x75,
+	0x2c, 0x05, 0xe3, 0x2f, 0x0d, 0x2a, 0xea, 0x96, 0xd0, 0x13, 0xa8, 0x84, 0x03, 0x1a, 0x06, 0x11,
+	0x51, 0x8d, 0x59, 0x29, 0xbc, 0xcd, 0x66, 0x57, 0x3a, 0xe1, 0xd8, 0x9b, 0x1f, 0x7a, 0x46, 0x6d,
+	0x9f, 0x11, 0x57, 0x1c, 0x5a, 0xc5, 0xb1, 0x88, 0x1e, 0xc0, 0x6c, 0x18, 0xf4, 0x3d, 0xe7, 0xb2,
+	0x77, 0x41, 0x68, 0xc4, 0xe7, 0x4b, 0xde, 0x5d, 0x5d, 0x6a, 0x9f, 0x4b, 0x25, 0xbf, 0x70, 0xd7,
+	0x66, 0x44, 0xdd, 0x9e, 0xf8, 0x16, 0xe3, 0x1a, 0x0c, 0xa8, 0x43, 0xc4, 0x48, 0xd6, 0xb0, 0x92,
+	0x4c, 0x0b, 0x2a, 0xaa, 0x00, 0x84, 0x60, 0xb6, 0x8b, 0x3b, 0xcf, 0xad, 0xd6, 0x8b, 0x5e, 0xf7,
+	0xf0, 0x59, 0xa7, 0xf5, 0xa2, 0x71, 0x0b, 0x2d, 0xc0, 0x5c, 0xeb, 0xf0, 0xe0, 0xd8, 0x6a, 0x1d,
+	0xf7, 0x8e, 0x76, 0x2d, 0xdc, 0x39, 0xd8, 0x69, 0x68, 0xa8, 0x0e, 0xb5, 0x7d, 0x0b, 0xef, 0xb5,
+	0x8f, 0xb9, 0x38, 0x61, 0x2e, 0xc0, 0xfc, 0x0e, 0x61, 0xea, 0x70, 0x4c, 0xbe, 0x19, 0x90, 0x88,
+	0x99, 0x3f, 0x6b, 0x80, 0xb2, 0xda, 0x28, 0x0c, 0x7c, 0x89, 0x2d, 0xa4, 0xc1, 0xd7, 0xc4, 0x61,
+	0xf1, 0x94, 0x2a, 0x91, 0x5b, 0x62, 0x50, 0x72, 0x04, 0x62, 0x11, 0xad, 0x00, 0x9c, 0x0e, 0xbc,
+	0xbe, 0xdb, 0x13, 0xa0, 0x24, 0xe2, 0x9a, 0xd0, 0x6c, 0x73, 0x64, 0xf7, 0x61, 0xe6, 0xcc, 0x63,
+	0x3d, 0x4a, 0x2e, 0x3c, 0x11, 0x2d, 0x51, 0x4f, 0x9f, 0x79, 0x0c, 0x2b, 0x15, 0xcf, 0x70, 0x16,
+	0x24, 0x3d, 0x93, 0x0d, 0xa8, 0x9d, 0x05, 0xaa, 0x38, 0xf3, 0x31, 0xcc, 0x5a, 0xae, 0xcb, 0x2f,
+	0x45, 0x55, 0x8f, 0xee, 0x43, 0x99, 0xdf, 0x95, 0xa8, 0x71, 0x7a, 0xb3, 0x9e, 0xbb, 0x38, 0x2c,
+	0x4c, 0xe6, 0xfb, 0x30, 0x97, 0x04, 0x29, 0x70, 0x77, 0x40, 0xac, 0xac, 0x9e, 0xe7, 0x2a, 0x70,
+	0x53, 0x5c, 0xec, 0xb8, 0xe6, 0x43, 0x98, 0xdf, 0x26, 0x7d, 0xc2, 0x48, 0xf6, 0x8c, 0x6b, 0xbd,
+	0x17, 0x01, 0x65, 0xbd, 0x65, 0x72, 0xf3, 0x3b, 0x0d, 0xe6, 0x4f, 0x42, 0xd7, 0x8e, 0xd5, 0x37,
+	0x27, 0x49, 0x10, 0x4c, 0x5c, 0x8b, 0x00, 0x6d, 0xc2, 0x92, 0xd3, 0x27, 0x36, 0xed, 0x0d, 0xaf,
+	0x43, 0x3e, 0x75, 0x0b, 0xc2, 0x98, 0xff, 0xf9, 0x9a, 0x4f, 0x00, 0x65, 0x8b, 0x50, 0xc0, 0xc7,
+	0x68, 0xd7, 0x3c, 0xcc, 0xed, 0x10, 0xc6, 0x15, 0x51, 0x3c, 0x22, 0x07, 0xd0, 0x48, 0x55, 0x23,
+	0x5a, 0x38, 0x06, 0x1e, 0xf3, 0x91, 0x98, 0x38, 0xae, 0xd8, 0xba, 0xec, 0x6c, 0x8f, 0x6c, 0xf3,
+	0x53, 0x58, 0xc8, 0xb9, 0x8f, 0x8f, 0xe5, 0x13, 0xb8, 0x77, 0x44, 0x6c, 0xea, 0xbc, 0xe2, 0xba,
+	0x68, 0xeb, 0xb2, 0xab, 0x36, 0x67, 0x7c, 0x64, 0x76, 0xb9, 0x6a, 0xf9, 0xe5, 0x6a, 0x7e, 0x05,
+	0x2b, 0xd7, 0xc4, 0xfe, 0x07, 0x1d, 0x78, 0x0c, 0xba, 0x82, 0x94, 0xa1, 0x98, 0x51, 0x7d, 0x38,
+	0x81, 0xbb, 0x05, 0x41, 0xaa, 0x9a, 0x3c, 0x9b, 0x69, 0xe3, 0xb3, 0x99, 0xd9, 0x84, 0x85, 0xf6,
+	0x9b, 0x30, 0xa0, 0x6c, 0xff, 0x72, 0xdb, 0x66, 0xf6, 0xc8, 0x32, 0xf6, 0x60, 0x31, 0xef, 0xaf,
+	0x2a, 0xb8, 0x0d, 0x53, 0xa7, 0x03, 0xdf, 0xed, 0xc7, 0xad, 0x54, 0x12, 0x5a, 0x86, 0xda, 0x4b,
+	0xaf, 0x4f, 0x7a, 0xe2, 0x41, 0x20, 0x37, 0x46, 0x95, 0x2b, 0x0e, 0xec, 0x73, 0x62, 0x7e, 0x00,
+	0x8d, 0x36, 0xb5, 0xa3, 0xf1, 0x7e, 0x6f, 0x6f, 0x60, 0x3e, 0xe3, 0x9c, 0x8c, 0xc1, 0x0c, 0xa1,
+	0x76, 0x34, 0xa0, 0x44, 0xae, 0x1d, 0x19, 0x32, 0xad, 0x74, 0x62, 0xf1, 0x18, 0x50, 0xa5, 0x32,
+	0x77, 0xcc, 0x0e, 0x89, 0x8c, 0xde, 0x85, 0xba, 0x1f, 0x70, 0xfe, 0x72, 0x44, 0x37, 0x22, 0x45,
+	0x13, 0x79, 0xa5, 0xf9, 0x93, 0x06, 0x0b, 0x3b, 0x7c, 0xb7, 0xc7, 0xcc, 0x3e, 0xea, 0x57, 0xfd,
+	0x31, 0x54, 0x15, 0x4b, 0xf0, 0x23, 0x4b, 0xa3, 0x49, 0x25, 0x71, 0x1f, 0x97, 0x3b, 0x52, 0x9e,
+	0x28, 0xe7, 0x78, 0xa2, 0x03, 0x8b, 0xf9, 0x4a, 0x55, 0x9f, 0xb2, 0x8f, 0x16, 0x6d, 0xac, 0x47,
+	0x8b, 0xf9, 0xad, 0x06, 0x8b, 0x98, 0x5c, 0x04, 0xaf, 0xc9, 0xff, 0x00, 0x3b, 0xc5, 0x53, 0xca,
+	0xe1, 0xf9, 0x1c, 0x96, 0xae, 0xd4, 0xf0, 0xcf, 0x01, 0x3d, 0x14, 0x04, 0x38, 0x26, 0x18, 0xf3,
+	0x47, 0xc9, 0x8c, 0xff, 0xfe, 0xdc, 0x82, 0x2b, 0x9d, 0x28, 0xba, 0xd2, 0x47, 0x80, 0x28, 0x51,
+	0x41, 0x3d, 0x3e, 0xa1, 0x1e, 0x25, 0xae, 0x5a, 0xf2, 0xf3, 0x89, 0x05, 0x2b, 0xc3, 0xe6, 0x9f,
+	0x15, 0xa8, 0xca, 0xa5, 0xdc, 0x6d, 0xa1, 0x36, 0x40, 0xca, 0xe2, 0xc8, 0x48, 0x2a, 0x1a, 0x22,
+	0x7c, 0x63, 0xb9, 0xd0, 0xa6, 0xc0, 0x7d, 0x2a, 0xde, 0xa9, 0x3c, 0x2b, 0xba, 0x93, 0xf8, 0xe5,
+	0x39, 0xd7, 0xd0, 0x87, 0x0d, 0x2a, 0xba, 0x0d, 0x90, 0x12, 0x62, 0xa6, 0x88, 0x21, 0x4e, 0x35,
+	0x96, 0x0b, 0x6d, 0x69, 0x9a, 0x94, 0xbb, 0x32, 0x69, 0x86, 0x58, 0xd5, 0x58, 0x2e, 0xb4, 0xa9,
+	0x34, 0x16, 0x54, 0x63, 0xda, 0x42, 0x7a, 0x16, 0x74, 0x96, 0xdc, 0x8c, 0xbb, 0x05, 0x16, 0x99,
+	0xe0, 0x43, 0x0d, 0xed, 0xc2, 0x74, 0x86, 0x7a, 0xd0, 0xf2, 0x55, 0xdf, 0x0c, 0x7f, 0x19, 0xf7,
+	0x8a, 0x8d, 0xaa, 0x98, 0x57, 0xb0, 0x54, 0x48, 0x27, 0x28, 0x7d, 0x85, 0xdf, 0x44, 0x55, 0xc6,
+	0x7b, 0xa3, 0xdc, 0x92, 0x9a, 0xbf, 0x14, 0x43, 0x9e, 0xa7, 0x09, 0x74, 0xff, 0x6a, 0x71, 0x43,
+	0xbc, 0x63, 0x98, 0x37, 0xb9, 0x28, 0x14, 0x7b, 0x30, 0x93, 0xdd, 0xfd, 0x28, 0xc5, 0x5c, 0x40,
+	0x21, 0xc6, 0xca, 0x35, 0x56, 0x95, 0x6c, 0x0b, 0x6a, 0xc9, 0x3a, 0x47, 0xe9, 0x35, 0x5c, 0xe5,
+	0x03, 0xc3, 0x28, 0x32, 0xa5, 0x05, 0x65, 0xb7, 0x5d, 0xa6, 0xa0, 0x82, 0x75, 0x6d, 0xac, 0x5c,
+	0x63, 0x55, 0xc9, 0x0e, 0xa0, 0x9e, 0x5b, 0x35, 0x28, 0xf5, 0x2f, 0x5a, 0x83, 0xc6, 0xea, 0x75,
+	0xe6, 0x74, 0x8e, 0xd3, 0xfd, 0x91, 0xff, 0x4d, 0x5e, 0xc9, 0xb4, 0x5c, 0x68, 0x93, 0x69, 0xb6,
+	0x1a, 0xbf, 0xbc, 0x5d, 0xd5, 0x7e, 0x7b, 0xbb, 0xaa, 0xfd, 0xfe, 0x76, 0x55, 0xfb, 0xfe, 0x8f,
+	0xd5, 0x5b, 0xa7, 0x53, 0xe2, 0x7f, 0xf7, 0xe3, 0xbf, 0x07, 0x00, 0xf7, 0xe1, 0x49, 0x12, 0x9a,
+	0x0f, 0x00, 0x00,
 }
 
-func (m *UpdateUserResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UsersRPCClient is the client API for UsersRPC service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UsersRPCClient interface {
+	// Returns software version and build details
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	// Adds a new user
+	AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*AddUserResponse, error)
+	// Deletes an existing user
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
+	// Updates an existing user, method requires passing all the information about the user as
+	// the object is simply replaced
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
+	// Returns all the users in the system
+	GetUsers(ctx context.Context, in *GetUsersRequest, opts ...grpc.CallOption) (UsersRPC_GetUsersClient, error)
+	GetUserByID(ctx context.Context, in *GetUserByIDRequest, opts ...grpc.CallOption) (*GetUserByIDResponse, error)
+	SearchUsersByPostcode(ctx context.Context, in *SearchUsersByPostcodeRequest, opts ...grpc.CallOption) (UsersRPC_SearchUsersByPostcodeClient, error)
+	// Returns the reputation of a user, aggregated from the ratings left on completed requests
+	GetUserReputation(ctx context.Context, in *GetUserReputationRequest, opts ...grpc.CallOption) (*GetUserReputationResponse, error)
+	// Returns everything stored about a user as a JSON document, to answer the access requests of
+	// the data subjects
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*ExportMyDataResponse, error)
+	// Deletes a user, their notification preferences and undelivered notifications, and replaces
+	// their id in the requests, answers and ratings with a pseudonym, clearing the texts they wrote.
+	// The states, scores and reputations are kept so the statistics don't change.
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error)
+	// Records the consent of a user to the purposes, accepting the privacy policy records its
+	// version
+	GrantConsent(ctx context.Context, in *GrantConsentRequest, opts ...grpc.CallOption) (*GrantConsentResponse, error)
+	// Records that a user withdrew their consent to the purposes
+	RevokeConsent(ctx context.Context, in *RevokeConsentRequest, opts ...grpc.CallOption) (*RevokeConsentResponse, error)
+	// Returns the consents in effect of a user and whether they have to accept the current privacy
+	// policy
+	GetConsent(ctx context.Context, in *GetConsentRequest, opts ...grpc.CallOption) (*GetConsentResponse, error)
 }
 
-func (m *UpdateUserResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.User != nil {
-		{
-			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintService(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+type usersRPCClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *GetUsersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func NewUsersRPCClient(cc *grpc.ClientConn) UsersRPCClient {
+	return &usersRPCClient{cc}
+}
+
+func (c *usersRPCClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/GetVersion", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GetUsersRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return out, nil
 }
 
-func (m *GetUsersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (c *usersRPCClient) AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*AddUserResponse, error) {
+	out := new(AddUserResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/AddUser", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *GetUsersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *usersRPCClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error) {
+	out := new(DeleteUserResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/DeleteUser", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *GetUsersResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *usersRPCClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error) {
+	out := new(UpdateUserResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/UpdateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *GetUsersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (c *usersRPCClient) GetUsers(ctx context.Context, in *GetUsersRequest, opts ...grpc.CallOption) (UsersRPC_GetUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UsersRPC_serviceDesc.Streams[0], "/userspb.UsersRPC/GetUsers", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.User != nil {
-		{
-			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintService(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	x := &usersRPCGetUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return x, nil
 }
 
-func (m *GetUserByIDRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type UsersRPC_GetUsersClient interface {
+	Recv() (*GetUsersResponse, error)
+	grpc.ClientStream
 }
 
-func (m *GetUserByIDRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type usersRPCGetUsersClient struct {
+	grpc.ClientStream
 }
 
-func (m *GetUserByIDRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
+func (x *usersRPCGetUsersClient) Recv() (*GetUsersResponse, error) {
+	m := new(GetUsersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *GetUserByIDResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *usersRPCClient) GetUserByID(ctx context.Context, in *GetUserByIDRequest, opts ...grpc.CallOption) (*GetUserByIDResponse, error) {
+	out := new(GetUserByIDResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/GetUserByID", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *GetUserByIDResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *usersRPCClient) SearchUsersByPostcode(ctx context.Context, in *SearchUsersByPostcodeRequest, opts ...grpc.CallOption) (UsersRPC_SearchUsersByPostcodeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UsersRPC_serviceDesc.Streams[1], "/userspb.UsersRPC/SearchUsersByPostcode", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &usersRPCSearchUsersByPostcodeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *GetUserByIDResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+type UsersRPC_SearchUsersByPostcodeClient interface {
+	Recv() (*SearchUsersByPostcodeResponse, error)
+	grpc.ClientStream
+}
+
+type usersRPCSearchUsersByPostcodeClient struct {
+	grpc.ClientStream
+}
+
+func (x *usersRPCSearchUsersByPostcodeClient) Recv() (*SearchUsersByPostcodeResponse, error) {
+	m := new(SearchUsersByPostcodeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	if m.User != nil {
-		{
-			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintService(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	return m, nil
+}
+
+func (c *usersRPCClient) GetUserReputation(ctx context.Context, in *GetUserReputationRequest, opts ...grpc.CallOption) (*GetUserReputationResponse, error) {
+	out := new(GetUserReputationResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/GetUserReputation", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *SearchUsersByPostcodeRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *usersRPCClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*ExportMyDataResponse, error) {
+	out := new(ExportMyDataResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/ExportMyData", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *SearchUsersByPostcodeRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *usersRPCClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error) {
+	out := new(EraseUserResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/EraseUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *SearchUsersByPostcodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (c *usersRPCClient) GrantConsent(ctx context.Context, in *GrantConsentRequest, opts ...grpc.CallOption) (*GrantConsentResponse, error) {
+	out := new(GrantConsentResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/GrantConsent", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Postcode) > 0 {
-		i -= len(m.Postcode)
-		copy(dAtA[i:], m.Postcode)
-		i = encodeVarintService(dAtA, i, uint64(len(m.Postcode)))
-		i--
-		dAtA[i] = 0xa
+	return out, nil
+}
+
+func (c *usersRPCClient) RevokeConsent(ctx context.Context, in *RevokeConsentRequest, opts ...grpc.CallOption) (*RevokeConsentResponse, error) {
+	out := new(RevokeConsentResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/RevokeConsent", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *SearchUsersByPostcodeResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *usersRPCClient) GetConsent(ctx context.Context, in *GetConsentRequest, opts ...grpc.CallOption) (*GetConsentResponse, error) {
+	out := new(GetConsentResponse)
+	err := c.cc.Invoke(ctx, "/userspb.UsersRPC/GetConsent", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *SearchUsersByPostcodeResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// UsersRPCServer is the server API for UsersRPC service.
+type UsersRPCServer interface {
+	// Returns software version and build details
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	// Adds a new user
+	AddUser(context.Context, *AddUserRequest) (*AddUserResponse, error)
+	// Deletes an existing user
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	// Updates an existing user, method requires passing all the information about the user as
+	// the object is simply replaced
+	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
+	// Returns all the users in the system
+	GetUsers(*GetUsersRequest, UsersRPC_GetUsersServer) error
+	GetUserByID(context.Context, *GetUserByIDRequest) (*GetUserByIDResponse, error)
+	SearchUsersByPostcode(*SearchUsersByPostcodeRequest, UsersRPC_SearchUsersByPostcodeServer) error
+	// Returns the reputation of a user, aggregated from the ratings left on completed requests
+	GetUserReputation(context.Context, *GetUserReputationRequest) (*GetUserReputationResponse, error)
+	// Returns everything stored about a user as a JSON document, to answer the access requests of
+	// the data subjects
+	ExportMyData(context.Context, *ExportMyDataRequest) (*ExportMyDataResponse, error)
+	// Deletes a user, their notification preferences and undelivered notifications, and replaces
+	// their id in the requests, answers and ratings with a pseudonym, clearing the texts they wrote.
+	// The states, scores and reputations are kept so the statistics don't change.
+	EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error)
+	// Records the consent of a user to the purposes, accepting the privacy policy records its
+	// version
+	GrantConsent(context.Context, *GrantConsentRequest) (*GrantConsentResponse, error)
+	// Records that a user withdrew their consent to the purposes
+	RevokeConsent(context.Context, *RevokeConsentRequest) (*RevokeConsentResponse, error)
+	// Returns the consents in effect of a user and whether they have to accept the current privacy
+	// policy
+	GetConsent(context.Context, *GetConsentRequest) (*GetConsentResponse, error)
 }
 
-func (m *SearchUsersByPostcodeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.User != nil {
-		{
-			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintService(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+// UnimplementedUsersRPCServer can be embedded to have forward compatible implementations.
+type UnimplementedUsersRPCServer struct {
 }
 
-func encodeVarintService(dAtA []byte, offset int, v uint64) int {
-	offset -= sovService(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (*UnimplementedUsersRPCServer) GetVersion(ctx context.Context, req *GetVersionRequest) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
 }
-func (m *User) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	if m.Address != nil {
-		l = m.Address.Size()
-		n += 1 + l + sovService(uint64(l))
+func (*UnimplementedUsersRPCServer) AddUser(ctx context.Context, req *AddUserRequest) (*AddUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUser not implemented")
+}
+func (*UnimplementedUsersRPCServer) DeleteUser(ctx context.Context, req *DeleteUserRequest) (*DeleteUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (*UnimplementedUsersRPCServer) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*UpdateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (*UnimplementedUsersRPCServer) GetUsers(req *GetUsersRequest, srv UsersRPC_GetUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetUsers not implemented")
+}
+func (*UnimplementedUsersRPCServer) GetUserByID(ctx context.Context, req *GetUserByIDRequest) (*GetUserByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByID not implemented")
+}
+func (*UnimplementedUsersRPCServer) SearchUsersByPostcode(req *SearchUsersByPostcodeRequest, srv UsersRPC_SearchUsersByPostcodeServer) error {
+	return status.Errorf(codes.Unimplemented, "method SearchUsersByPostcode not implemented")
+}
+func (*UnimplementedUsersRPCServer) GetUserReputation(ctx context.Context, req *GetUserReputationRequest) (*GetUserReputationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserReputation not implemented")
+}
+func (*UnimplementedUsersRPCServer) ExportMyData(ctx context.Context, req *ExportMyDataRequest) (*ExportMyDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (*UnimplementedUsersRPCServer) EraseUser(ctx context.Context, req *EraseUserRequest) (*EraseUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (*UnimplementedUsersRPCServer) GrantConsent(ctx context.Context, req *GrantConsentRequest) (*GrantConsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantConsent not implemented")
+}
+func (*UnimplementedUsersRPCServer) RevokeConsent(ctx context.Context, req *RevokeConsentRequest) (*RevokeConsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeConsent not implemented")
+}
+func (*UnimplementedUsersRPCServer) GetConsent(ctx context.Context, req *GetConsentRequest) (*GetConsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsent not implemented")
+}
+
+func RegisterUsersRPCServer(s *grpc.Server, srv UsersRPCServer) {
+	s.RegisterService(&_UsersRPC_serviceDesc, srv)
+}
+
+func _UsersRPC_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.ContactDetails) > 0 {
-		for _, e := range m.ContactDetails {
-			l = e.Size()
-			n += 1 + l + sovService(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(UsersRPCServer).GetVersion(ctx, in)
 	}
-	if len(m.Skills) > 0 {
-		for _, s := range m.Skills {
-			l = len(s)
-			n += 1 + l + sovService(uint64(l))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/GetVersion",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).GetVersion(ctx, req.(*GetVersionRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *User_Address) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	l = len(m.City)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+func _UsersRPC_AddUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.Postcode)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	if interceptor == nil {
+		return srv.(UsersRPCServer).AddUser(ctx, in)
 	}
-	l = len(m.Country)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/AddUser",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).AddUser(ctx, req.(*AddUserRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *User_ContactDetails) Size() (n int) {
-	if m == nil {
-		return 0
+func _UsersRPC_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Platform != 0 {
-		n += 1 + sovService(uint64(m.Platform))
+	if interceptor == nil {
+		return srv.(UsersRPCServer).DeleteUser(ctx, in)
 	}
-	l = len(m.Identifier)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/DeleteUser",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).DeleteUser(ctx, req.(*DeleteUserRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetVersionRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _UsersRPC_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if interceptor == nil {
+		return srv.(UsersRPCServer).UpdateUser(ctx, in)
 	}
-	return n
-}
-
-func (m *GetVersionResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/UpdateUser",
 	}
-	var l int
-	_ = l
-	l = len(m.Project)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).UpdateUser(ctx, req.(*UpdateUserRequest))
 	}
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersRPC_GetUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetUsersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	l = len(m.BuildDate)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	return srv.(UsersRPCServer).GetUsers(m, &usersRPCGetUsersServer{stream})
+}
+
+type UsersRPC_GetUsersServer interface {
+	Send(*GetUsersResponse) error
+	grpc.ServerStream
+}
+
+type usersRPCGetUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *usersRPCGetUsersServer) Send(m *GetUsersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UsersRPC_GetUserByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	l = len(m.GitRevision)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	if interceptor == nil {
+		return srv.(UsersRPCServer).GetUserByID(ctx, in)
 	}
-	l = len(m.GoVersion)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/GetUserByID",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).GetUserByID(ctx, req.(*GetUserByIDRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *AddUserRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.User != nil {
-		l = m.User.Size()
-		n += 1 + l + sovService(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func _UsersRPC_SearchUsersByPostcode_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchUsersByPostcodeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return n
+	return srv.(UsersRPCServer).SearchUsersByPostcode(m, &usersRPCSearchUsersByPostcodeServer{stream})
 }
 
-func (m *AddUserResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+type UsersRPC_SearchUsersByPostcodeServer interface {
+	Send(*SearchUsersByPostcodeResponse) error
+	grpc.ServerStream
 }
 
-func (m *DeleteUserRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+type usersRPCSearchUsersByPostcodeServer struct {
+	grpc.ServerStream
 }
 
-func (m *DeleteUserResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (x *usersRPCSearchUsersByPostcodeServer) Send(m *SearchUsersByPostcodeResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *UpdateUserRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _UsersRPC_GetUserReputation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserReputationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	if interceptor == nil {
+		return srv.(UsersRPCServer).GetUserReputation(ctx, in)
 	}
-	if m.User != nil {
-		l = m.User.Size()
-		n += 1 + l + sovService(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/GetUserReputation",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).GetUserReputation(ctx, req.(*GetUserReputationRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *UpdateUserResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _UsersRPC_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.User != nil {
-		l = m.User.Size()
-		n += 1 + l + sovService(uint64(l))
+	if interceptor == nil {
+		return srv.(UsersRPCServer).ExportMyData(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/ExportMyData",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetUsersRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _UsersRPC_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if interceptor == nil {
+		return srv.(UsersRPCServer).EraseUser(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/EraseUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).EraseUser(ctx, req.(*EraseUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetUsersResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _UsersRPC_GrantConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	if interceptor == nil {
+		return srv.(UsersRPCServer).GrantConsent(ctx, in)
 	}
-	if m.User != nil {
-		l = m.User.Size()
-		n += 1 + l + sovService(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/GrantConsent",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).GrantConsent(ctx, req.(*GrantConsentRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetUserByIDRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _UsersRPC_RevokeConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	if interceptor == nil {
+		return srv.(UsersRPCServer).RevokeConsent(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/RevokeConsent",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).RevokeConsent(ctx, req.(*RevokeConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetUserByIDResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _UsersRPC_GetConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.User != nil {
-		l = m.User.Size()
-		n += 1 + l + sovService(uint64(l))
+	if interceptor == nil {
+		return srv.(UsersRPCServer).GetConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/userspb.UsersRPC/GetConsent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersRPCServer).GetConsent(ctx, req.(*GetConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _UsersRPC_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "userspb.UsersRPC",
+	HandlerType: (*UsersRPCServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVersion",
+			Handler:    _UsersRPC_GetVersion_Handler,
+		},
+		{
+			MethodName: "AddUser",
+			Handler:    _UsersRPC_AddUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _UsersRPC_DeleteUser_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _UsersRPC_UpdateUser_Handler,
+		},
+		{
+			MethodName: "GetUserByID",
+			Handler:    _UsersRPC_GetUserByID_Handler,
+		},
+		{
+			MethodName: "GetUserReputation",
+			Handler:    _UsersRPC_GetUserReputation_Handler,
+		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _UsersRPC_ExportMyData_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _UsersRPC_EraseUser_Handler,
+		},
+		{
+			MethodName: "GrantConsent",
+			Handler:    _UsersRPC_GrantConsent_Handler,
+		},
+		{
+			MethodName: "RevokeConsent",
+			Handler:    _UsersRPC_RevokeConsent_Handler,
+		},
+		{
+			MethodName: "GetConsent",
+			Handler:    _UsersRPC_GetConsent_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetUsers",
+			Handler:       _UsersRPC_GetUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SearchUsersByPostcode",
+			Handler:       _UsersRPC_SearchUsersByPostcode_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "users/rpc/userspb/service.proto",
+}
+
+func (m *User) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *User) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Consents) > 0 {
+		for iNdEx := len(m.Consents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Consents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Reputation != nil {
+		{
+			size, err := m.Reputation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Skills) > 0 {
+		for iNdEx := len(m.Skills) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Skills[iNdEx])
+			copy(dAtA[i:], m.Skills[iNdEx])
+			i = encodeVarintService(dAtA, i, uint64(len(m.Skills[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ContactDetails) > 0 {
+		for iNdEx := len(m.ContactDetails) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ContactDetails[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Address != nil {
+		{
+			size, err := m.Address.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *SearchUsersByPostcodeRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *User_Address) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *User_Address) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *User_Address) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Postcode)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Country) > 0 {
+		i -= len(m.Country)
+		copy(dAtA[i:], m.Country)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Country)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Postcode) > 0 {
+		i -= len(m.Postcode)
+		copy(dAtA[i:], m.Postcode)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Postcode)))
+		i--
+		dAtA[i] = 0x1a
 	}
+	if len(m.City) > 0 {
+		i -= len(m.City)
+		copy(dAtA[i:], m.City)
+		i = encodeVarintService(dAtA, i, uint64(len(m.City)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *User_ContactDetails) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *User_ContactDetails) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *User_ContactDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Identifier) > 0 {
+		i -= len(m.Identifier)
+		copy(dAtA[i:], m.Identifier)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Identifier)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Platform != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Platform))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *User_Reputation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *User_Reputation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *User_Reputation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Total != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Ratings != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Ratings))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Score != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Score))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *User_Consent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *User_Consent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *User_Consent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Date) > 0 {
+		i -= len(m.Date)
+		copy(dAtA[i:], m.Date)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Date)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.PolicyVersion) > 0 {
+		i -= len(m.PolicyVersion)
+		copy(dAtA[i:], m.PolicyVersion)
+		i = encodeVarintService(dAtA, i, uint64(len(m.PolicyVersion)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Granted {
+		i--
+		if m.Granted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Purpose != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Purpose))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetVersionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetVersionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetVersionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetVersionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetVersionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetVersionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.GoVersion) > 0 {
+		i -= len(m.GoVersion)
+		copy(dAtA[i:], m.GoVersion)
+		i = encodeVarintService(dAtA, i, uint64(len(m.GoVersion)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.GitRevision) > 0 {
+		i -= len(m.GitRevision)
+		copy(dAtA[i:], m.GitRevision)
+		i = encodeVarintService(dAtA, i, uint64(len(m.GitRevision)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.BuildDate) > 0 {
+		i -= len(m.BuildDate)
+		copy(dAtA[i:], m.BuildDate)
+		i = encodeVarintService(dAtA, i, uint64(len(m.BuildDate)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Project) > 0 {
+		i -= len(m.Project)
+		copy(dAtA[i:], m.Project)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Project)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddUserRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddUserRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddUserRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.User != nil {
+		{
+			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddUserResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddUserResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddUserResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteUserRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteUserRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteUserRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteUserResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteUserResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteUserResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateUserRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateUserRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateUserRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ClearContactDetails {
+		i--
+		if m.ClearContactDetails {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.User != nil {
+		{
+			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateUserResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateUserResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateUserResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.User != nil {
+		{
+			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetUsersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetUsersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetUsersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetUsersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetUsersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetUsersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.User != nil {
+		{
+			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetUserByIDRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetUserByIDRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetUserByIDRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetUserByIDResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetUserByIDResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetUserByIDResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.User != nil {
+		{
+			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SearchUsersByPostcodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchUsersByPostcodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SearchUsersByPostcodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Postcode) > 0 {
+		i -= len(m.Postcode)
+		copy(dAtA[i:], m.Postcode)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Postcode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SearchUsersByPostcodeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchUsersByPostcodeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SearchUsersByPostcodeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.User != nil {
+		{
+			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetUserReputationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetUserReputationRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetUserReputationRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetUserReputationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetUserReputationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetUserReputationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Reputation != nil {
+		{
+			size, err := m.Reputation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintService(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportMyDataRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportMyDataRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportMyDataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportMyDataResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportMyDataResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportMyDataResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.FileName) > 0 {
+		i -= len(m.FileName)
+		copy(dAtA[i:], m.FileName)
+		i = encodeVarintService(dAtA, i, uint64(len(m.FileName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Bundle) > 0 {
+		i -= len(m.Bundle)
+		copy(dAtA[i:], m.Bundle)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Bundle)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EraseUserRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EraseUserRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EraseUserRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EraseUserResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EraseUserResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EraseUserResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Notifications != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Notifications))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Requests != 0 {
+		i = encodeVarintService(dAtA, i, uint64(m.Requests))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ErasureDate) > 0 {
+		i -= len(m.ErasureDate)
+		copy(dAtA[i:], m.ErasureDate)
+		i = encodeVarintService(dAtA, i, uint64(len(m.ErasureDate)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GrantConsentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrantConsentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GrantConsentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.PolicyVersion) > 0 {
+		i -= len(m.PolicyVersion)
+		copy(dAtA[i:], m.PolicyVersion)
+		i = encodeVarintService(dAtA, i, uint64(len(m.PolicyVersion)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Purposes) > 0 {
+		dAtA11 := make([]byte, len(m.Purposes)*10)
+		var j10 int
+		for _, num := range m.Purposes {
+			for num >= 1<<7 {
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j10++
+			}
+			dAtA11[j10] = uint8(num)
+			j10++
+		}
+		i -= j10
+		copy(dAtA[i:], dAtA11[:j10])
+		i = encodeVarintService(dAtA, i, uint64(j10))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GrantConsentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrantConsentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GrantConsentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Consents) > 0 {
+		for iNdEx := len(m.Consents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Consents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevokeConsentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeConsentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevokeConsentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintService(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Purposes) > 0 {
+		dAtA13 := make([]byte, len(m.Purposes)*10)
+		var j12 int
+		for _, num := range m.Purposes {
+			for num >= 1<<7 {
+				dAtA13[j12] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j12++
+			}
+			dAtA13[j12] = uint8(num)
+			j12++
+		}
+		i -= j12
+		copy(dAtA[i:], dAtA13[:j12])
+		i = encodeVarintService(dAtA, i, uint64(j12))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevokeConsentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeConsentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevokeConsentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Consents) > 0 {
+		for iNdEx := len(m.Consents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Consents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetConsentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConsentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetConsentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintService(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetConsentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConsentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetConsentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ReconsentRequired {
+		i--
+		if m.ReconsentRequired {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.PolicyVersion) > 0 {
+		i -= len(m.PolicyVersion)
+		copy(dAtA[i:], m.PolicyVersion)
+		i = encodeVarintService(dAtA, i, uint64(len(m.PolicyVersion)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Consents) > 0 {
+		for iNdEx := len(m.Consents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Consents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintService(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintService(dAtA []byte, offset int, v uint64) int {
+	offset -= sovService(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *User) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Address != nil {
+		l = m.Address.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.ContactDetails) > 0 {
+		for _, e := range m.ContactDetails {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if len(m.Skills) > 0 {
+		for _, s := range m.Skills {
+			l = len(s)
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if m.Reputation != nil {
+		l = m.Reputation.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.Consents) > 0 {
+		for _, e := range m.Consents {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *User_Address) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.City)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Postcode)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Country)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *User_ContactDetails) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Platform != 0 {
+		n += 1 + sovService(uint64(m.Platform))
+	}
+	l = len(m.Identifier)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *User_Reputation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Score != 0 {
+		n += 9
+	}
+	if m.Ratings != 0 {
+		n += 1 + sovService(uint64(m.Ratings))
+	}
+	if m.Total != 0 {
+		n += 1 + sovService(uint64(m.Total))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *User_Consent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Purpose != 0 {
+		n += 1 + sovService(uint64(m.Purpose))
+	}
+	if m.Granted {
+		n += 2
+	}
+	l = len(m.PolicyVersion)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Date)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetVersionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetVersionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Project)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.BuildDate)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.GitRevision)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.GoVersion)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddUserRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.User != nil {
+		l = m.User.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddUserResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteUserRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteUserResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateUserRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.User != nil {
+		l = m.User.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.ClearContactDetails {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateUserResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.User != nil {
+		l = m.User.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetUsersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetUsersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.User != nil {
+		l = m.User.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetUserByIDRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetUserByIDResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.User != nil {
+		l = m.User.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SearchUsersByPostcodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Postcode)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SearchUsersByPostcodeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.User != nil {
+		l = m.User.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetUserReputationRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetUserReputationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Reputation != nil {
+		l = m.Reputation.Size()
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExportMyDataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExportMyDataResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bundle)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.FileName)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EraseUserRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EraseUserResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ErasureDate)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.Requests != 0 {
+		n += 1 + sovService(uint64(m.Requests))
+	}
+	if m.Notifications != 0 {
+		n += 1 + sovService(uint64(m.Notifications))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GrantConsentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.Purposes) > 0 {
+		l = 0
+		for _, e := range m.Purposes {
+			l += sovService(uint64(e))
+		}
+		n += 1 + sovService(uint64(l)) + l
+	}
+	l = len(m.PolicyVersion)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GrantConsentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Consents) > 0 {
+		for _, e := range m.Consents {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RevokeConsentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if len(m.Purposes) > 0 {
+		l = 0
+		for _, e := range m.Purposes {
+			l += sovService(uint64(e))
+		}
+		n += 1 + sovService(uint64(l)) + l
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RevokeConsentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Consents) > 0 {
+		for _, e := range m.Consents {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetConsentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetConsentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Consents) > 0 {
+		for _, e := range m.Consents {
+			l = e.Size()
+			n += 1 + l + sovService(uint64(l))
+		}
+	}
+	l = len(m.PolicyVersion)
+	if l > 0 {
+		n += 1 + l + sovService(uint64(l))
+	}
+	if m.ReconsentRequired {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovService(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozService(x uint64) (n int) {
+	return sovService(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *User) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: User: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: User: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Address == nil {
+				m.Address = &User_Address{}
+			}
+			if err := m.Address.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContactDetails", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContactDetails = append(m.ContactDetails, &User_ContactDetails{})
+			if err := m.ContactDetails[len(m.ContactDetails)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Skills", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Skills = append(m.Skills, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reputation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Reputation == nil {
+				m.Reputation = &User_Reputation{}
+			}
+			if err := m.Reputation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Consents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Consents = append(m.Consents, &User_Consent{})
+			if err := m.Consents[len(m.Consents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *User_Address) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Address: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Address: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field City", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.City = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Postcode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Postcode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Country = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *User_ContactDetails) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContactDetails: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContactDetails: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
+			}
+			m.Platform = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Platform |= User_ContactDetails_Platform(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identifier", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identifier = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *User_Reputation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Reputation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Reputation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Score = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ratings", wireType)
+			}
+			m.Ratings = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ratings |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *User_Consent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Consent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Consent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Purpose", wireType)
+			}
+			m.Purpose = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Purpose |= User_Consent_Purpose(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Granted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Granted = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PolicyVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PolicyVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Date", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Date = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetVersionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetVersionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetVersionResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetVersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Project = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BuildDate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BuildDate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GitRevision", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GitRevision = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GoVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GoVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddUserRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddUserRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.User == nil {
+				m.User = &User{}
+			}
+			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddUserResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddUserResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteUserRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteUserRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *SearchUsersByPostcodeResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovService(uint64(l))
-	}
-	if m.User != nil {
-		l = m.User.Size()
-		n += 1 + l + sovService(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
+func (m *DeleteUserResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteUserResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
 
-func sovService(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozService(x uint64) (n int) {
-	return sovService(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *User) Unmarshal(dAtA []byte) error {
+func (m *UpdateUserRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2444,15 +5981,15 @@ func (m *User) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: User: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateUserRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: User: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2480,11 +6017,11 @@ func (m *User) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2511,18 +6048,18 @@ func (m *User) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Address == nil {
-				m.Address = &User_Address{}
+			if m.User == nil {
+				m.User = &User{}
 			}
-			if err := m.Address.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContactDetails", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClearContactDetails", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -2532,31 +6069,71 @@ func (m *User) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.ClearContactDetails = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthService
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContactDetails = append(m.ContactDetails, &User_ContactDetails{})
-			if err := m.ContactDetails[len(m.ContactDetails)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateUserResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
 			}
-			iNdEx = postIndex
-		case 4:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateUserResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Skills", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -2566,23 +6143,27 @@ func (m *User) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Skills = append(m.Skills, string(dAtA[iNdEx:postIndex]))
+			if m.User == nil {
+				m.User = &User{}
+			}
+			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2609,7 +6190,7 @@ func (m *User) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *User_Address) Unmarshal(dAtA []byte) error {
+func (m *GetUsersRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2632,47 +6213,69 @@ func (m *User_Address) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Address: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetUsersRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Address: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthService
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetUsersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetUsersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field City", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2700,13 +6303,13 @@ func (m *User_Address) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.City = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Postcode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -2716,27 +6319,85 @@ func (m *User_Address) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Postcode = string(dAtA[iNdEx:postIndex])
+			if m.User == nil {
+				m.User = &User{}
+			}
+			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetUserByIDRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetUserByIDRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetUserByIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2764,7 +6425,7 @@ func (m *User_Address) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Country = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2791,7 +6452,7 @@ func (m *User_Address) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *User_ContactDetails) Unmarshal(dAtA []byte) error {
+func (m *GetUserByIDResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2814,36 +6475,17 @@ func (m *User_ContactDetails) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContactDetails: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetUserByIDResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContactDetails: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetUserByIDResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Platform", wireType)
-			}
-			m.Platform = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Platform |= User_ContactDetails_Platform(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Identifier", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -2853,23 +6495,27 @@ func (m *User_ContactDetails) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Identifier = string(dAtA[iNdEx:postIndex])
+			if m.User == nil {
+				m.User = &User{}
+			}
+			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2896,7 +6542,7 @@ func (m *User_ContactDetails) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetVersionRequest) Unmarshal(dAtA []byte) error {
+func (m *SearchUsersByPostcodeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2919,12 +6565,44 @@ func (m *GetVersionRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetVersionRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SearchUsersByPostcodeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SearchUsersByPostcodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Postcode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Postcode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -2950,7 +6628,7 @@ func (m *GetVersionRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
+func (m *SearchUsersByPostcodeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2973,15 +6651,15 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetVersionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SearchUsersByPostcodeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetVersionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SearchUsersByPostcodeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Project", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3009,13 +6687,13 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Project = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -3025,27 +6703,85 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			if m.User == nil {
+				m.User = &User{}
+			}
+			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetUserReputationRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetUserReputationRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetUserReputationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BuildDate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3073,45 +6809,67 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BuildDate = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GitRevision", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipService(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthService
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GitRevision = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetUserReputationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetUserReputationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetUserReputationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GoVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reputation", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -3121,23 +6879,27 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GoVersion = string(dAtA[iNdEx:postIndex])
+			if m.Reputation == nil {
+				m.Reputation = &User_Reputation{}
+			}
+			if err := m.Reputation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3164,7 +6926,7 @@ func (m *GetVersionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddUserRequest) Unmarshal(dAtA []byte) error {
+func (m *ExportMyDataRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3187,17 +6949,17 @@ func (m *AddUserRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddUserRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportMyDataRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportMyDataRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -3207,27 +6969,23 @@ func (m *AddUserRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.User == nil {
-				m.User = &User{}
-			}
-			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3254,7 +7012,7 @@ func (m *AddUserRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddUserResponse) Unmarshal(dAtA []byte) error {
+func (m *ExportMyDataResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3277,15 +7035,15 @@ func (m *AddUserResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddUserResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportMyDataResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportMyDataResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Bundle", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3313,7 +7071,39 @@ func (m *AddUserResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			m.Bundle = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3340,7 +7130,7 @@ func (m *AddUserResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteUserRequest) Unmarshal(dAtA []byte) error {
+func (m *EraseUserRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3363,10 +7153,10 @@ func (m *DeleteUserRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteUserRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: EraseUserRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EraseUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3426,7 +7216,7 @@ func (m *DeleteUserRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteUserResponse) Unmarshal(dAtA []byte) error {
+func (m *EraseUserResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3449,12 +7239,82 @@ func (m *DeleteUserResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteUserResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: EraseUserResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EraseUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErasureDate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErasureDate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			m.Requests = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Requests |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Notifications", wireType)
+			}
+			m.Notifications = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Notifications |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -3480,7 +7340,7 @@ func (m *DeleteUserResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateUserRequest) Unmarshal(dAtA []byte) error {
+func (m *GrantConsentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3499,19 +7359,120 @@ func (m *UpdateUserRequest) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateUserRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateUserRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrantConsentRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrantConsentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v User_Consent_Purpose
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowService
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= User_Consent_Purpose(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Purposes = append(m.Purposes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowService
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthService
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthService
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.Purposes) == 0 {
+					m.Purposes = make([]User_Consent_Purpose, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v User_Consent_Purpose
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowService
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= User_Consent_Purpose(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Purposes = append(m.Purposes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Purposes", wireType)
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PolicyVersion", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3539,13 +7500,13 @@ func (m *UpdateUserRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			m.PolicyVersion = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -3555,27 +7516,23 @@ func (m *UpdateUserRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.User == nil {
-				m.User = &User{}
-			}
-			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Source = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3602,7 +7559,7 @@ func (m *UpdateUserRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateUserResponse) Unmarshal(dAtA []byte) error {
+func (m *GrantConsentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3625,15 +7582,15 @@ func (m *UpdateUserResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateUserResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GrantConsentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateUserResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GrantConsentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Consents", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3660,10 +7617,8 @@ func (m *UpdateUserResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.User == nil {
-				m.User = &User{}
-			}
-			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Consents = append(m.Consents, &User_Consent{})
+			if err := m.Consents[len(m.Consents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3692,61 +7647,7 @@ func (m *UpdateUserResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetUsersRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowService
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetUsersRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetUsersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipService(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetUsersResponse) Unmarshal(dAtA []byte) error {
+func (m *RevokeConsentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3769,10 +7670,10 @@ func (m *GetUsersResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetUsersResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeConsentRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetUsersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeConsentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3808,98 +7709,77 @@ func (m *GetUsersResponse) Unmarshal(dAtA []byte) error {
 			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowService
+			if wireType == 0 {
+				var v User_Consent_Purpose
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowService
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= User_Consent_Purpose(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.Purposes = append(m.Purposes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowService
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthService
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthService
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				if elementCount != 0 && len(m.Purposes) == 0 {
+					m.Purposes = make([]User_Consent_Purpose, 0, elementCount)
 				}
+				for iNdEx < postIndex {
+					var v User_Consent_Purpose
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowService
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= User_Consent_Purpose(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Purposes = append(m.Purposes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Purposes", wireType)
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthService
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthService
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.User == nil {
-				m.User = &User{}
-			}
-			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipService(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthService
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetUserByIDRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowService
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetUserByIDRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetUserByIDRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3927,7 +7807,7 @@ func (m *GetUserByIDRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			m.Source = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3954,7 +7834,7 @@ func (m *GetUserByIDRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetUserByIDResponse) Unmarshal(dAtA []byte) error {
+func (m *RevokeConsentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3977,15 +7857,15 @@ func (m *GetUserByIDResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetUserByIDResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeConsentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetUserByIDResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeConsentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Consents", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4012,10 +7892,8 @@ func (m *GetUserByIDResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.User == nil {
-				m.User = &User{}
-			}
-			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Consents = append(m.Consents, &User_Consent{})
+			if err := m.Consents[len(m.Consents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4044,7 +7922,7 @@ func (m *GetUserByIDResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SearchUsersByPostcodeRequest) Unmarshal(dAtA []byte) error {
+func (m *GetConsentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4067,15 +7945,15 @@ func (m *SearchUsersByPostcodeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SearchUsersByPostcodeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetConsentRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SearchUsersByPostcodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetConsentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Postcode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4103,7 +7981,7 @@ func (m *SearchUsersByPostcodeRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Postcode = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4130,7 +8008,7 @@ func (m *SearchUsersByPostcodeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SearchUsersByPostcodeResponse) Unmarshal(dAtA []byte) error {
+func (m *GetConsentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4153,17 +8031,17 @@ func (m *SearchUsersByPostcodeResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SearchUsersByPostcodeResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetConsentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SearchUsersByPostcodeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetConsentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Consents", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -4173,29 +8051,31 @@ func (m *SearchUsersByPostcodeResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			m.Consents = append(m.Consents, &User_Consent{})
+			if err := m.Consents[len(m.Consents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PolicyVersion", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowService
@@ -4205,28 +8085,44 @@ func (m *SearchUsersByPostcodeResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthService
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthService
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.User == nil {
-				m.User = &User{}
+			m.PolicyVersion = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReconsentRequired", wireType)
 			}
-			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.ReconsentRequired = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipService(dAtA[iNdEx:])
@@ -0,0 +1,307 @@
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/sirupsen/logrus"
+)
+
+// Headers sent along with the events.
+const (
+	SignatureHeader = "X-Signature-256"
+	EventHeader     = "X-Event-Type"
+	SequenceHeader  = "X-Event-Sequence"
+	WebhookHeader   = "X-Webhook-Id"
+)
+
+type Config struct {
+	// MaxAttempts is the number of deliveries tried before giving up on an event
+	MaxAttempts int
+	// Backoff is the time waited after the first failed delivery, doubled after each failure up
+	// to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout limits the time spent on each delivery
+	Timeout time.Duration
+	// BatchSize is the number of events read from the outbox at once
+	BatchSize int
+	// Interval is the time waited between checks of the outbox when nothing gets recorded
+	Interval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxBackoff:  time.Minute,
+		Timeout:     10 * time.Second,
+		BatchSize:   100,
+		Interval:    time.Minute,
+	}
+}
+
+// Dispatcher delivers the events recorded in the outbox to the registered webhooks. Each webhook
+// gets the events in the order they were recorded, which keeps the changes of every entity in
+// order, events that can't be delivered after all the attempts are skipped. Every webhook has its
+// own delivery loop and cursor, the last sequence it handled, so a slow webhook only delays its own
+// events.
+type Dispatcher struct {
+	logger   *logrus.Entry
+	outbox   *storage.Outbox
+	webhooks *storage.WebhooksStorage
+	client   *http.Client
+	cfg      Config
+	// progressed is signalled by the delivery loops once they saved their progress
+	progressed chan struct{}
+}
+
+func New(logger *logrus.Entry, outbox *storage.Outbox, webhookData *storage.WebhooksStorage, client *http.Client, cfg Config) *Dispatcher {
+	return &Dispatcher{
+		logger:     logger,
+		outbox:     outbox,
+		webhooks:   webhookData,
+		client:     client,
+		cfg:        cfg,
+		progressed: make(chan struct{}, 1),
+	}
+}
+
+// Run delivers the events until the context is cancelled. The delivery loops of the webhooks are
+// started and stopped as they're registered and deleted, the events handled by every webhook are
+// pruned from the outbox.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	loops := make(map[string]context.CancelFunc)
+	defer func() {
+		for _, cancel := range loops {
+			cancel()
+		}
+		wg.Wait()
+	}()
+	for {
+		recorded := d.outbox.Wait()
+		webhooks := d.webhooks.All()
+		for id := range webhooks {
+			if _, ok := loops[id]; ok {
+				continue
+			}
+			loopCtx, cancel := context.WithCancel(ctx)
+			loops[id] = cancel
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				d.deliverLoop(loopCtx, id)
+			}(id)
+		}
+		for id, cancel := range loops {
+			if _, ok := webhooks[id]; !ok {
+				cancel()
+				delete(loops, id)
+			}
+		}
+		d.prune()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-recorded:
+		case <-d.progressed:
+		case <-time.After(d.cfg.Interval):
+		}
+	}
+}
+
+// deliverLoop delivers the events to the webhook until the context is cancelled.
+func (d *Dispatcher) deliverLoop(ctx context.Context, id string) {
+	for {
+		recorded := d.outbox.Wait()
+		if d.deliverPending(ctx, id) && ctx.Err() == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-recorded:
+		case <-time.After(d.cfg.Interval):
+		}
+	}
+}
+
+// prune removes the events handled by every webhook from the outbox.
+func (d *Dispatcher) prune() {
+	last := d.outbox.Last()
+	for _, webhook := range d.webhooks.All() {
+		if webhook.LastSequence < last {
+			last = webhook.LastSequence
+		}
+	}
+	if err := d.outbox.Prune(last); err != nil {
+		d.logger.WithError(err).Error("problem pruning outbox")
+	}
+}
+
+// deliverPending delivers a batch of the pending events to the webhook, it returns whether there
+// are more events than the batch size left. The progress is saved once per batch, the events of a
+// batch interrupted by a crash are delivered again.
+func (d *Dispatcher) deliverPending(ctx context.Context, id string) bool {
+	logger := d.logger.WithField("webhook_id", id)
+	webhook, err := d.webhooks.Get(id)
+	if err != nil {
+		// deleted meanwhile
+		return false
+	}
+	events := d.outbox.Since(webhook.LastSequence, d.cfg.BatchSize)
+	var (
+		last      = webhook.LastSequence
+		failed    uint64
+		lastError string
+	)
+	for _, e := range events {
+		if subscribed(webhook, e.Type) {
+			if err := d.deliver(ctx, id, webhook, e); err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				logger.WithError(err).WithField("sequence", e.Sequence).Error("giving up delivering event")
+				failed++
+				lastError = err.Error()
+			}
+		}
+		last = e.Sequence
+	}
+	if last == webhook.LastSequence {
+		return false
+	}
+	// saved even when the context is cancelled, the events delivered aren't sent again
+	if err := d.webhooks.Advance(ctx, id, last, failed, lastError); err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			logger.WithError(err).Error("problem saving webhook progress")
+		}
+		return false
+	}
+	select {
+	case d.progressed <- struct{}{}:
+	default:
+	}
+	return len(events) == d.cfg.BatchSize
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, id string, webhook *webhookspb.Webhook, e *webhookspb.Event) error {
+	backoff := d.cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		_, err := d.Send(ctx, id, webhook, e)
+		if err == nil {
+			return nil
+		}
+		d.logger.WithError(err).WithFields(logrus.Fields{
+			"webhook_id": id,
+			"sequence":   e.Sequence,
+			"attempt":    attempt,
+		}).Warn("problem delivering event")
+		if attempt >= d.cfg.MaxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > d.cfg.MaxBackoff {
+			backoff = d.cfg.MaxBackoff
+		}
+	}
+}
+
+// Send posts the event to the webhook once, returning the status code received.
+func (d *Dispatcher) Send(ctx context.Context, id string, webhook *webhookspb.Webhook, e *webhookspb.Event) (int, error) {
+	body, err := marshal(e)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("%w: problem creating request", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	req.Header.Set(EventHeader, e.Type)
+	req.Header.Set(SequenceHeader, strconv.FormatUint(e.Sequence, 10))
+	req.Header.Set(WebhookHeader, id)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%w: problem sending request", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature of the body sent in the SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func marshal(e *webhookspb.Event) ([]byte, error) {
+	var payload json.RawMessage
+	if e.Payload != "" {
+		payload = json.RawMessage(e.Payload)
+	}
+	b, err := json.Marshal(struct {
+		Sequence     uint64          `json:"sequence"`
+		Type         string          `json:"type"`
+		EntityType   string          `json:"entityType,omitempty"`
+		EntityID     string          `json:"entityId,omitempty"`
+		CreationDate string          `json:"creationDate"`
+		Payload      json.RawMessage `json:"payload,omitempty"`
+	}{
+		Sequence:     e.Sequence,
+		Type:         e.Type,
+		EntityType:   e.EntityType,
+		EntityID:     e.EntityId,
+		CreationDate: e.CreationDate,
+		Payload:      payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("problem marshaling event: %w", err)
+	}
+	return b, nil
+}
+
+func subscribed(webhook *webhookspb.Webhook, eventType string) bool {
+	if len(webhook.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range webhook.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/storage/storagetest"
+	"github.com/euvsvirus-banan/backend/requests/rpc/requestspb"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestDispatch(t *testing.T) {
+	var (
+		received []string
+		failures = 1
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get(SignatureHeader) != Sign("secret", body) {
+			t.Errorf("invalid signature for %s", body)
+		}
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		e := struct {
+			Type     string `json:"type"`
+			EntityID string `json:"entityId"`
+			Payload  struct {
+				Title string `json:"title"`
+			} `json:"payload"`
+		}{}
+		if err := json.Unmarshal(body, &e); err != nil {
+			t.Error(err)
+		}
+		received = append(received, e.Type+" "+e.EntityID+" "+e.Payload.Title)
+	}))
+	defer server.Close()
+
+	outbox := storagetest.Outbox(nil)
+	requests := storagetest.Requests(nil)
+	requests.SetOutbox(outbox)
+	webhooks := storagetest.Webhooks(map[string]*webhookspb.Webhook{
+		"all": {
+			Url:    server.URL,
+			Secret: "secret",
+		},
+		"deleted only": {
+			Url:        server.URL,
+			Secret:     "secret",
+			EventTypes: []string{storage.RequestDeleted},
+		},
+	})
+
+	if err := requests.Add(context.Background(), "a", &requestspb.Request{Title: "groceries"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := requests.Update(context.Background(), "a", &requestspb.Request{
+		Title:   "groceries",
+		Answers: []*requestspb.Request_Answer{{VolunteerId: "Blue"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := requests.Update(context.Background(), "a", &requestspb.Request{
+		Title:       "groceries",
+		State:       requestspb.Request_ACCEPTED,
+		VolunteerId: "Blue",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Backoff = time.Millisecond
+	d := New(logrus.NewEntry(logrus.New()), outbox, webhooks, server.Client(), cfg)
+	for id := range webhooks.All() {
+		if more := d.deliverPending(context.Background(), id); more {
+			t.Errorf("expected all events to be dispatched to %s", id)
+		}
+	}
+	d.prune()
+
+	expected := []string{
+		"RequestAdded a groceries",
+		"RequestAnswered a groceries",
+		"HelpAccepted a groceries",
+	}
+	if !cmp.Equal(expected, received) {
+		t.Error(cmp.Diff(expected, received))
+	}
+	for id, webhook := range webhooks.All() {
+		if webhook.LastSequence != 3 {
+			t.Errorf("expected %s to be at sequence 3, got %d", id, webhook.LastSequence)
+		}
+	}
+	if events := outbox.Since(0, 10); len(events) != 0 {
+		t.Errorf("expected delivered events to be pruned, got %v", events)
+	}
+}
+
+func TestDispatchGiveUp(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	outbox := storagetest.Outbox(nil)
+	requests := storagetest.Requests(nil)
+	requests.SetOutbox(outbox)
+	webhooks := storagetest.Webhooks(map[string]*webhookspb.Webhook{
+		"a": {Url: server.URL},
+	})
+	if err := requests.Add(context.Background(), "a", &requestspb.Request{}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.MaxAttempts = 2
+	cfg.Backoff = time.Millisecond
+	d := New(logrus.NewEntry(logrus.New()), outbox, webhooks, server.Client(), cfg)
+	d.deliverPending(context.Background(), "a")
+
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+	webhook, err := webhooks.Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webhook.LastSequence != 1 || webhook.FailedDeliveries != 1 || webhook.LastError == "" {
+		t.Errorf("expected the event to be skipped, got %v", webhook)
+	}
+}
+
+func TestDispatchSlowWebhook(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+	received := make(chan string, 10)
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get(SequenceHeader)
+	}))
+	defer fast.Close()
+
+	outbox := storagetest.Outbox(nil)
+	requests := storagetest.Requests(nil)
+	requests.SetOutbox(outbox)
+	webhooks := storagetest.Webhooks(map[string]*webhookspb.Webhook{
+		"slow": {Url: slow.URL},
+		"fast": {Url: fast.URL},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := DefaultConfig()
+	cfg.Timeout = time.Minute
+	d := New(logrus.NewEntry(logrus.New()), outbox, webhooks, http.DefaultClient, cfg)
+	stopped := make(chan struct{})
+	go func() {
+		_ = d.Run(ctx)
+		close(stopped)
+	}()
+
+	// the fast webhook gets every event while the slow one is stuck on the first
+	for i, id := range []string{"a", "b", "c"} {
+		if err := requests.Add(context.Background(), id, &requestspb.Request{}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case seq := <-received:
+			if seq != strconv.Itoa(i+1) {
+				t.Errorf("expected event %d, got %s", i+1, seq)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %d not delivered to the fast webhook", i+1)
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for webhook, _ := webhooks.Get("fast"); webhook.LastSequence != 3; webhook, _ = webhooks.Get("fast") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the fast webhook to be at sequence 3, got %d", webhook.LastSequence)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-stopped
+
+	if webhook, _ := webhooks.Get("slow"); webhook.LastSequence != 0 {
+		t.Errorf("expected the slow webhook to be at sequence 0, got %d", webhook.LastSequence)
+	}
+	// the events not delivered to the slow webhook are kept
+	if events := outbox.Since(0, 10); len(events) != 3 {
+		t.Errorf("expected the 3 events to be kept, got %d", len(events))
+	}
+}
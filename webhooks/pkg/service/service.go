@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/euvsvirus-banan/backend/internal/egress"
+	"github.com/euvsvirus-banan/backend/internal/storage"
+	"github.com/euvsvirus-banan/backend/internal/version"
+	"github.com/euvsvirus-banan/backend/webhooks/pkg/dispatcher"
+	"github.com/euvsvirus-banan/backend/webhooks/rpc/webhookspb"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PingEvent is the event sent by TestWebhook.
+const PingEvent = "Ping"
+
+type Service struct {
+	logger     *logrus.Entry
+	webhooks   *storage.WebhooksStorage
+	outbox     *storage.Outbox
+	dispatcher *dispatcher.Dispatcher
+	guard      *egress.Guard
+}
+
+func New(logger *logrus.Entry, webhookData *storage.WebhooksStorage, outbox *storage.Outbox, d *dispatcher.Dispatcher, guard *egress.Guard) *Service {
+	return &Service{
+		logger:     logger,
+		webhooks:   webhookData,
+		outbox:     outbox,
+		dispatcher: d,
+		guard:      guard,
+	}
+}
+
+func (svc *Service) GetVersion(ctx context.Context, req *webhookspb.GetVersionRequest) (*webhookspb.GetVersionResponse, error) {
+	return &webhookspb.GetVersionResponse{
+		Project:     version.Project,
+		Version:     version.Version,
+		BuildDate:   version.BuildDate,
+		GitRevision: version.GitRevision,
+		GoVersion:   version.GoVersion,
+	}, nil
+}
+
+func (svc *Service) RegisterWebhook(ctx context.Context, req *webhookspb.RegisterWebhookRequest) (*webhookspb.RegisterWebhookResponse, error) {
+	if err := svc.guard.CheckURL(ctx, req.Url); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook url: %s", err)
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	id := uuid.New().String()
+	webhook := &webhookspb.Webhook{
+		Url:          req.Url,
+		Secret:       secret,
+		EventTypes:   req.EventTypes,
+		CreationDate: time.Now().UTC().Format(time.RFC3339),
+		LastSequence: svc.outbox.Last(),
+	}
+	if err := svc.webhooks.Add(ctx, id, webhook); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &webhookspb.RegisterWebhookResponse{
+		WebhookId: id,
+		Webhook:   webhook,
+	}, nil
+}
+
+func (svc *Service) DeleteWebhook(ctx context.Context, req *webhookspb.DeleteWebhookRequest) (*webhookspb.DeleteWebhookResponse, error) {
+	if err := svc.webhooks.Delete(ctx, req.WebhookId); err != nil {
+		return nil, status.Error(codes.NotFound, "webhook not found")
+	}
+	return &webhookspb.DeleteWebhookResponse{}, nil
+}
+
+func (svc *Service) GetWebhooks(req *webhookspb.GetWebhooksRequest, stream webhookspb.WebhooksRPC_GetWebhooksServer) error {
+	for id := range svc.webhooks.All() {
+		webhook, err := svc.webhooks.Get(id)
+		if err != nil {
+			continue
+		}
+		webhook.Secret = ""
+		if err := stream.Send(
+			&webhookspb.GetWebhooksResponse{
+				WebhookId: id,
+				Webhook:   webhook,
+			},
+		); err != nil {
+			return status.Error(codes.Unknown, err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *Service) TestWebhook(ctx context.Context, req *webhookspb.TestWebhookRequest) (*webhookspb.TestWebhookResponse, error) {
+	webhook, err := svc.webhooks.Get(req.WebhookId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "webhook not found")
+	}
+	code, err := svc.dispatcher.Send(ctx, req.WebhookId, webhook, &webhookspb.Event{
+		Type:         PingEvent,
+		CreationDate: time.Now().UTC().Format(time.RFC3339),
+	})
+	resp := &webhookspb.TestWebhookResponse{
+		Delivered:  err == nil,
+		StatusCode: int32(code),
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}